@@ -24,7 +24,7 @@ echo.
 
 REM CLI 빌드
 echo 🔨 CLI 버전 빌드 중...
-go build -ldflags="-s -w" -o %BUILD_DIR%\bmw-monitor-cli.exe cmd\cli\main.go
+go build -ldflags="-s -w" -o %BUILD_DIR%\bmw-monitor-cli.exe cmd\cli\*.go
 
 if %ERRORLEVEL% EQU 0 (
     echo ✅ CLI 빌드 성공: %BUILD_DIR%\bmw-monitor-cli.exe
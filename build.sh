@@ -64,7 +64,7 @@ echo ""
 
 # CLI 빌드
 echo "🔨 CLI 버전 빌드 중..."
-go build -ldflags="-s -w" -o ${BUILD_DIR}/bmw-monitor-cli cmd/cli/main.go
+go build -ldflags="-s -w" -o ${BUILD_DIR}/bmw-monitor-cli cmd/cli/*.go
 
 if [ $? -eq 0 ]; then
     echo -e "${GREEN}✅ CLI 빌드 성공: ${BUILD_DIR}/bmw-monitor-cli${NC}"
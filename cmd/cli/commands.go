@@ -0,0 +1,716 @@
+package main
+
+import (
+	"bmw-driving-center-alter/internal/browser"
+	"bmw-driving-center-alter/internal/claims"
+	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/history"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/logging"
+	"bmw-driving-center-alter/internal/models"
+	"bmw-driving-center-alter/internal/monitor"
+	"bmw-driving-center-alter/internal/mute"
+	"bmw-driving-center-alter/internal/session"
+	"bmw-driving-center-alter/internal/watch"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Exit codes of the check command - 스크립트에서 분기할 수 있도록 고정
+const (
+	exitAvailable     = 0 // 예약 가능한 프로그램 있음
+	exitNoneAvailable = 1 // 예약 가능한 프로그램 없음
+	exitCheckError    = 2 // 설정/로그인/확인 오류
+	exitCaptcha       = 3 // CAPTCHA 때문에 결과를 믿을 수 없음
+)
+
+// accountError is an error reported for one account
+type accountError struct {
+	Account string `json:"account,omitempty"`
+	Error   string `json:"error"`
+}
+
+// restrictToAccount returns a copy of the config with only the named account (empty name = all accounts)
+func restrictToAccount(cfg *config.Config, name string) (*config.Config, error) {
+	if name == "" {
+		return cfg, nil
+	}
+	for _, account := range cfg.GetAccounts() {
+		if account.Name == name {
+			restricted := *cfg
+			restricted.Accounts = []config.AccountConfig{account}
+			return &restricted, nil
+		}
+	}
+	return nil, fmt.Errorf(i18n.T("cli.account_not_found"), name)
+}
+
+// loadCommandConfig loads and validates the config for a subcommand
+func loadCommandConfig(path, account string) (*config.Config, error) {
+	cfg, err := loadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return restrictToAccount(cfg, account)
+}
+
+// logEvents prints engine progress to stderr so stdout only carries the result
+func logEvents(event monitor.Event) {
+	if event.Type == monitor.EventCheck || event.Type == monitor.EventOpened {
+		return
+	}
+	if event.Account != "" {
+		logger.Logf(event.Level(), "[%s] %s", event.Account, event.Message)
+		return
+	}
+	logger.Log(event.Level(), event.Message)
+}
+
+// checkJSON is the output of "check -output json"
+type checkJSON struct {
+	CheckedAt time.Time      `json:"checked_at"`
+	Available bool           `json:"available"`
+	ExitCode  int            `json:"exit_code"`
+	Results   []*resultJSON  `json:"results"`
+	Errors    []accountError `json:"errors"`
+}
+
+func runCheck(args []string) int {
+	fs, cfgPath, output := newCommand("check", i18n.T("cli.usage.check"))
+	accountName := fs.String("account", "", i18n.T("cli.flag.check_account"))
+	showBrowser := fs.Bool("show-browser", false, i18n.T("cli.flag.show_browser"))
+	notify := fs.Bool("notify", false, i18n.T("cli.flag.check_notify"))
+	addLogFlags(fs)
+	fs.Parse(args)
+
+	if err := checkOutputFormat(*output); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return exitCheckError
+	}
+
+	checkedAt := time.Now()
+	var errs []accountError
+	var results []*monitor.CheckResult
+
+	cfg, err := loadCommandConfig(*cfgPath, *accountName)
+	if err == nil {
+		err = setupLogging(cfg)
+	}
+	if err == nil {
+		defer logging.Close()
+		if *showBrowser {
+			cfg.Monitor.Headless = false
+		}
+		results, errs = checkOnce(cfg, *notify)
+	} else {
+		errs = append(errs, accountError{Error: err.Error()})
+	}
+
+	code := checkExitCode(results, errs)
+	switch *output {
+	case outputJSON:
+		out := checkJSON{
+			CheckedAt: checkedAt,
+			Available: code == exitAvailable,
+			ExitCode:  code,
+			Results:   []*resultJSON{},
+			Errors:    errs,
+		}
+		if out.Errors == nil {
+			out.Errors = []accountError{}
+		}
+		for _, result := range results {
+			out.Results = append(out.Results, toResultJSON(result))
+		}
+		printJSON(out)
+	case outputTable:
+		printResultTable(results)
+		printAccountErrors(errs)
+	default:
+		for _, result := range results {
+			printCheckResult(result)
+		}
+		printAccountErrors(errs)
+	}
+	return code
+}
+
+// checkExitCode maps check results to the documented exit codes.
+// 예약 가능한 프로그램이 하나라도 있으면 다른 계정의 오류와 관계없이 0을 반환합니다.
+func checkExitCode(results []*monitor.CheckResult, errs []accountError) int {
+	available, captcha := false, false
+	for _, result := range results {
+		captcha = captcha || result.CaptchaDetected
+		for _, program := range result.Programs {
+			available = available || result.Availability[program]
+		}
+	}
+
+	switch {
+	case available:
+		return exitAvailable
+	case len(errs) > 0:
+		return exitCheckError
+	case captcha:
+		return exitCaptcha
+	}
+	return exitNoneAvailable
+}
+
+// checkOnce starts every account, checks once and returns the results and per-account errors
+func checkOnce(cfg *config.Config, notify bool) ([]*monitor.CheckResult, []accountError) {
+	engine, err := monitor.NewEngine(cfg)
+	if err != nil {
+		return nil, []accountError{{Error: err.Error()}}
+	}
+	engine.SetNotify(notify)
+	engine.SetHistory(history.NewStore(""))
+	engine.SetClaims(claims.NewStore(""))
+	engine.SetMutes(mute.NewStore(""))
+	engine.SetWatches(watch.NewStore(""))
+
+	var errs []accountError
+	engine.OnEvent(func(event monitor.Event) {
+		if event.Type == monitor.EventError {
+			errs = append(errs, accountError{Account: event.Account, Error: event.Message})
+		}
+		logEvents(event)
+	})
+	defer engine.Close()
+
+	if err := engine.Start(); err != nil {
+		return nil, append(errs, accountError{Error: err.Error()})
+	}
+
+	var results []*monitor.CheckResult
+	for _, result := range engine.CheckAll() {
+		results = append(results, &result)
+	}
+	return results, errs
+}
+
+func printAccountErrors(errs []accountError) {
+	for _, e := range errs {
+		if e.Account != "" {
+			fmt.Printf("❌ [%s] %s\n", e.Account, e.Error)
+		} else {
+			fmt.Printf("❌ %s\n", e.Error)
+		}
+	}
+}
+
+// programListJSON is one entry of "programs -output json"
+type programListJSON struct {
+	Category   string   `json:"category"`
+	Name       string   `json:"name"`
+	KoreanName string   `json:"korean_name,omitempty"`
+	WatchedBy  []string `json:"watched_by"`
+}
+
+func runPrograms(args []string) int {
+	fs, cfgPath, output := newCommand("programs", i18n.T("cli.usage.programs"))
+	fs.Parse(args)
+
+	if err := checkOutputFormat(*output); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+
+	// 설정이 있으면 어느 계정이 감시 중인지 표시 (없어도 목록은 출력)
+	watchedBy := make(map[string][]string)
+	if cfg, err := loadConfig(*cfgPath); err == nil {
+		for _, account := range cfg.GetAccounts() {
+			for _, program := range account.Programs {
+				watchedBy[program.Name] = append(watchedBy[program.Name], account.Name)
+			}
+		}
+	}
+
+	switch *output {
+	case outputJSON:
+		out := []programListJSON{}
+		for _, category := range models.AllPrograms {
+			for _, program := range category.Programs {
+				accounts := watchedBy[program]
+				if accounts == nil {
+					accounts = []string{}
+				}
+				out = append(out, programListJSON{
+					Category:   category.Name,
+					Name:       program,
+					KoreanName: models.ProgramNameMap[program],
+					WatchedBy:  accounts,
+				})
+			}
+		}
+		printJSON(out)
+	case outputTable:
+		t := newTable(i18n.T("cli.col.category"), i18n.T("cli.col.program"), i18n.T("cli.col.korean_name"), i18n.T("cli.col.watched_by"))
+		for _, category := range models.AllPrograms {
+			for _, program := range category.Programs {
+				t.addRow(category.Name, program, models.ProgramNameMap[program], strings.Join(watchedBy[program], ", "))
+			}
+		}
+		t.print()
+	default:
+		showAvailablePrograms(watchedBy)
+	}
+	return 0
+}
+
+func showAvailablePrograms(watchedBy map[string][]string) {
+	fmt.Print(i18n.T("cli.programs.title"))
+
+	for _, category := range models.AllPrograms {
+		fmt.Printf("【%s】\n", category.Name)
+		for _, program := range category.Programs {
+			watched := ""
+			if accounts := watchedBy[program]; len(accounts) > 0 {
+				watched = fmt.Sprintf("  👀 %s", strings.Join(accounts, ", "))
+			}
+			fmt.Printf("  • %s%s\n", programLabel(program), watched)
+		}
+		fmt.Println()
+	}
+
+	fmt.Println(i18n.T("cli.programs.hint"))
+	fmt.Println(i18n.T("cli.programs.example"))
+	fmt.Println("programs:")
+	fmt.Println("  - name: M Core")
+	fmt.Println("    keywords:")
+	fmt.Println("      - M Core")
+	fmt.Println("      - M 코어")
+}
+
+// accountStatusJSON is one entry of "status -output json"
+type accountStatusJSON struct {
+	Account        string              `json:"account"`
+	Username       string              `json:"username"`
+	Recipients     []string            `json:"recipients"`
+	SavedProfile   bool                `json:"saved_profile"`
+	PendingSession bool                `json:"pending_session"`
+	LastCheck      *time.Time          `json:"last_check,omitempty"`
+	Programs       []programStatusJSON `json:"programs"`
+	Claims         []claims.Claim      `json:"claims,omitempty"` // 예약 담당으로 등록된 회차
+	Mutes          []mute.Mute         `json:"mutes,omitempty"`  // 알림 음소거 중인 프로그램
+}
+
+type programStatusJSON struct {
+	Name       string `json:"name"`
+	KoreanName string `json:"korean_name,omitempty"`
+	Available  *bool  `json:"available"` // 확인 기록이 없으면 null
+}
+
+func runStatus(args []string) int {
+	fs, cfgPath, output := newCommand("status", i18n.T("cli.usage.status"))
+	accountName := fs.String("account", "", i18n.T("cli.flag.status_account"))
+	fs.Parse(args)
+
+	if err := checkOutputFormat(*output); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+
+	cfg, err := loadConfig(*cfgPath)
+	if err == nil {
+		cfg, err = restrictToAccount(cfg, *accountName)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+
+	latest, err := history.NewStore("").Latest()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️ %v\n", err)
+	}
+
+	claimList, err := claims.NewStore("").List()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️ %v\n", err)
+	}
+	muteList, err := mute.NewStore("").List()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️ %v\n", err)
+	}
+
+	var statuses []accountStatusJSON
+	for _, account := range cfg.GetAccounts() {
+		pending, _ := session.LoadPending(browser.AccountStateDir(account.Name))
+		status := accountStatusJSON{
+			Account:        account.Name,
+			Username:       account.Username,
+			Recipients:     cfg.RecipientsFor(account),
+			SavedProfile:   browser.HasSavedProfile(account.Name),
+			PendingSession: pending != nil,
+			Programs:       []programStatusJSON{},
+		}
+
+		entry, checked := latest[account.Name]
+		if checked {
+			checkedAt := entry.Time
+			status.LastCheck = &checkedAt
+		}
+		for _, program := range account.Programs {
+			programStatus := programStatusJSON{
+				Name:       program.Name,
+				KoreanName: models.ProgramNameMap[program.Name],
+			}
+			for _, result := range entry.Programs {
+				if result.Name == program.Name {
+					available := result.Available
+					programStatus.Available = &available
+				}
+			}
+			status.Programs = append(status.Programs, programStatus)
+		}
+		for _, claim := range claimList {
+			if claim.Account == account.Name {
+				status.Claims = append(status.Claims, claim)
+			}
+		}
+		for _, m := range muteList {
+			if m.Account == account.Name {
+				status.Mutes = append(status.Mutes, m)
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	switch *output {
+	case outputJSON:
+		printJSON(statuses)
+	case outputTable:
+		t := newTable(i18n.T("cli.col.account"), i18n.T("cli.col.program"), i18n.T("cli.col.status"), i18n.T("cli.col.last_check"))
+		for _, status := range statuses {
+			lastCheck := "-"
+			if status.LastCheck != nil {
+				lastCheck = status.LastCheck.Format("2006-01-02 15:04:05")
+			}
+			for _, program := range status.Programs {
+				t.addRow(status.Account, programLabel(program.Name), formatAvailability(program.Available), lastCheck)
+			}
+		}
+		t.print()
+	default:
+		for _, status := range statuses {
+			fmt.Printf(i18n.T("cli.status.user"), status.Account, status.Username)
+			if status.SavedProfile {
+				fmt.Println(i18n.T("cli.status.session_saved"))
+			} else {
+				fmt.Println(i18n.T("cli.status.session_missing"))
+			}
+			if status.PendingSession {
+				fmt.Println(i18n.T("cli.status.session_pending"))
+			}
+			if status.LastCheck != nil {
+				fmt.Printf(i18n.T("cli.status.last_check"), status.LastCheck.Format("2006-01-02 15:04:05"))
+			} else {
+				fmt.Println(i18n.T("cli.status.last_check_none"))
+			}
+			for _, program := range status.Programs {
+				fmt.Printf("   %s  %s\n", formatAvailability(program.Available), programLabel(program.Name))
+			}
+			for _, claim := range status.Claims {
+				fmt.Printf(i18n.T("cli.status.claim"), claim.Program, claim.Start.Format("01/02 15:04"), claim.By)
+			}
+			for _, m := range status.Mutes {
+				fmt.Printf(i18n.T("cli.status.muted"), m.Program, m.Until.Format("2006-01-02 15:04"))
+			}
+			fmt.Printf(i18n.T("cli.status.recipients"), strings.Join(status.Recipients, ", "))
+		}
+	}
+	return 0
+}
+
+func formatAvailability(available *bool) string {
+	switch {
+	case available == nil:
+		return i18n.T("cli.state.unknown")
+	case *available:
+		return i18n.T("cli.state.available")
+	}
+	return i18n.T("cli.state.unavailable")
+}
+
+func runHistory(args []string) int {
+	fs, _, output := newCommand("history", i18n.T("cli.usage.history"))
+	accountName := fs.String("account", "", i18n.T("cli.flag.history_account"))
+	program := fs.String("program", "", i18n.T("cli.flag.history_program"))
+	since := fs.Duration("since", 0, i18n.T("cli.flag.history_since"))
+	limit := fs.Int("n", 20, i18n.T("cli.flag.history_limit"))
+	fs.Parse(args)
+
+	if err := checkOutputFormat(*output); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+
+	filter := history.Filter{
+		Account: *accountName,
+		Program: *program,
+		Limit:   *limit,
+	}
+	if *since > 0 {
+		filter.Since = time.Now().Add(-*since)
+	}
+
+	store := history.NewStore("")
+	entries, err := store.Read(filter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+
+	switch *output {
+	case outputJSON:
+		if entries == nil {
+			entries = []history.Entry{}
+		}
+		printJSON(entries)
+	case outputTable:
+		t := newTable(i18n.T("cli.col.checked_at"), i18n.T("cli.col.account"), i18n.T("cli.col.available"), i18n.T("cli.col.checked_programs"))
+		for _, entry := range entries {
+			available := strings.Join(entry.Available(), ", ")
+			if available == "" {
+				available = "-"
+			}
+			if entry.Captcha {
+				available += " (CAPTCHA)"
+			}
+			t.addRow(entry.Time.Format("2006-01-02 15:04:05"), entry.Account, available, fmt.Sprintf(i18n.T("cli.count"), len(entry.Programs)))
+		}
+		t.print()
+	default:
+		if len(entries) == 0 {
+			fmt.Printf(i18n.T("cli.history.empty"), store.Path())
+			return 0
+		}
+		for _, entry := range entries {
+			status := i18n.T("cli.history.none_available")
+			if available := entry.Available(); len(available) > 0 {
+				status = "✅ " + strings.Join(available, ", ")
+			}
+			if entry.Captcha {
+				status += " 🚨 CAPTCHA"
+			}
+			fmt.Printf("%s [%s] %s\n", entry.Time.Format("2006-01-02 15:04:05"), entry.Account, status)
+		}
+	}
+	return 0
+}
+
+// notifyTestJSON is one entry of "test-notify -output json"
+type notifyTestJSON struct {
+	Account    string   `json:"account"`
+	Recipients []string `json:"recipients"`
+	Sent       bool     `json:"sent"`
+	Error      string   `json:"error,omitempty"`
+}
+
+func runTestNotify(args []string) int {
+	fs, cfgPath, output := newCommand("test-notify", i18n.T("cli.usage.test_notify"))
+	accountName := fs.String("account", "", i18n.T("cli.flag.test_notify_account"))
+	fs.Parse(args)
+
+	if err := checkOutputFormat(*output); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+
+	cfg, err := loadCommandConfig(*cfgPath, *accountName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+
+	engine, err := monitor.NewEngine(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+	engine.OnEvent(logEvents)
+
+	exitCode := 0
+	var out []notifyTestJSON
+	for _, result := range engine.SendTestNotification() {
+		entry := notifyTestJSON{
+			Account:    result.Account,
+			Recipients: result.Recipients,
+			Sent:       result.Err == nil,
+		}
+		if result.Err != nil {
+			entry.Error = result.Err.Error()
+			exitCode = 1
+		}
+		out = append(out, entry)
+	}
+
+	switch *output {
+	case outputJSON:
+		printJSON(out)
+	case outputTable:
+		t := newTable(i18n.T("cli.col.account"), i18n.T("cli.col.recipients"), i18n.T("cli.col.result"))
+		for _, entry := range out {
+			status := i18n.T("cli.test_notify.sent_short")
+			if !entry.Sent {
+				status = "❌ " + entry.Error
+			}
+			t.addRow(entry.Account, strings.Join(entry.Recipients, ", "), status)
+		}
+		t.print()
+	default:
+		for _, entry := range out {
+			if entry.Sent {
+				fmt.Printf(i18n.T("cli.test_notify.sent"), entry.Account, strings.Join(entry.Recipients, ", "))
+			} else {
+				fmt.Printf(i18n.T("cli.test_notify.failed"), entry.Account, entry.Error)
+			}
+		}
+	}
+	return exitCode
+}
+
+// validateJSON is the output of "validate -output json"
+type validateJSON struct {
+	Config   string `json:"config"`
+	Valid    bool   `json:"valid"`
+	Error    string `json:"error,omitempty"`
+	Accounts int    `json:"accounts"`
+	Programs int    `json:"programs"`
+}
+
+func runValidate(args []string) int {
+	fs, cfgPath, output := newCommand("validate", i18n.T("cli.usage.validate"))
+	fs.Parse(args)
+
+	if err := checkOutputFormat(*output); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+
+	path := *cfgPath
+	if path == "" {
+		path = config.GetConfigPath()
+	}
+	out := validateJSON{Config: path}
+
+	cfg, err := loadConfig(path)
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		out.Error = err.Error()
+	} else {
+		out.Valid = true
+		for _, account := range cfg.GetAccounts() {
+			out.Accounts++
+			out.Programs += len(account.Programs)
+		}
+	}
+
+	switch *output {
+	case outputJSON:
+		printJSON(out)
+	default:
+		if out.Valid {
+			fmt.Printf(i18n.T("cli.validate.ok"), out.Config, out.Accounts, out.Programs)
+		} else {
+			fmt.Printf(i18n.T("cli.validate.failed"), out.Config, out.Error)
+		}
+	}
+
+	if !out.Valid {
+		return 1
+	}
+	return 0
+}
+
+// loginJSON is one entry of "login -output json"
+type loginJSON struct {
+	Account  string       `json:"account"`
+	LoggedIn bool         `json:"logged_in"`
+	Session  *sessionJSON `json:"session,omitempty"`
+}
+
+func runLogin(args []string) int {
+	fs, cfgPath, output := newCommand("login", i18n.T("cli.usage.login"))
+	accountName := fs.String("account", "", i18n.T("cli.flag.login_account"))
+	showBrowser := fs.Bool("show-browser", false, i18n.T("cli.flag.show_browser_manual"))
+	addLogFlags(fs)
+	fs.Parse(args)
+
+	if err := checkOutputFormat(*output); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+
+	cfg, err := loadCommandConfig(*cfgPath, *accountName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+	if err := setupLogging(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+	defer logging.Close()
+	if *showBrowser {
+		cfg.Monitor.Headless = false
+	}
+
+	engine, err := monitor.NewEngine(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+	engine.OnEvent(logEvents)
+	defer engine.Close()
+
+	// 로그인 결과는 계정별로 출력하므로 전체 실패 오류는 무시
+	engine.Start()
+
+	exitCode := 0
+	var out []loginJSON
+	for _, account := range engine.Accounts() {
+		entry := loginJSON{Account: account.Config.Name, LoggedIn: account.Ready()}
+		if entry.LoggedIn {
+			entry.Session = toSessionJSON(account.SessionInfo())
+		} else {
+			exitCode = 1
+		}
+		out = append(out, entry)
+	}
+
+	switch *output {
+	case outputJSON:
+		printJSON(out)
+	case outputTable:
+		t := newTable(i18n.T("cli.col.account"), i18n.T("cli.col.login"), i18n.T("cli.col.session_expiry"))
+		for _, entry := range out {
+			loggedIn, expires := i18n.T("cli.login.failed_short"), "-"
+			if entry.LoggedIn {
+				loggedIn = i18n.T("cli.login.ok_short")
+				if entry.Session != nil && entry.Session.ExpiresAt != nil {
+					expires = entry.Session.ExpiresAt.Format("2006-01-02 15:04")
+				}
+			}
+			t.addRow(entry.Account, loggedIn, expires)
+		}
+		t.print()
+	default:
+		for _, account := range engine.Accounts() {
+			if account.Ready() {
+				fmt.Printf(i18n.T("cli.login.ok"), account.Config.Name, formatSession(account.SessionInfo()))
+			} else {
+				fmt.Printf(i18n.T("cli.login.failed"), account.Config.Name)
+			}
+		}
+	}
+	return exitCode
+}
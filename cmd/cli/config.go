@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/i18n"
+	"fmt"
+	"os"
+)
+
+// configJSON is the output of "config show -output json"
+type configJSON struct {
+	Config  string         `json:"config"`
+	Profile string         `json:"profile,omitempty"`
+	Values  []config.Value `json:"values"`
+}
+
+// runConfig handles "config show"
+func runConfig(args []string) int {
+	if len(args) == 0 {
+		printConfigUsage()
+		return 2
+	}
+
+	switch args[0] {
+	case "show":
+		return runConfigShow(args[1:])
+	default:
+		printConfigUsage()
+		return 2
+	}
+}
+
+func printConfigUsage() {
+	fmt.Println(i18n.T("cli.usage.header"))
+	fmt.Println(i18n.T("cli.config.usage_show"))
+	fmt.Println(i18n.T("cli.config.usage_note"))
+}
+
+// runConfigShow prints the config values with their sources.
+// -effective가 없으면 설정 파일의 값만, 있으면 프로필/환경 변수/옵션까지 합친 값을 보여줍니다.
+func runConfigShow(args []string) int {
+	fs, cfgPath, output := newCommand("config show", i18n.T("cli.usage.config_show"))
+	effectiveFlag := fs.Bool("effective", false, i18n.T("cli.flag.effective"))
+	fs.Parse(args)
+
+	if err := checkOutputFormat(*output); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+
+	var effective *config.Effective
+	var err error
+	if *effectiveFlag {
+		effective, err = loadEffective(*cfgPath)
+	} else {
+		// 환경 변수 없이 설정 파일만
+		if effective, err = config.LoadEffective(*cfgPath, config.Layers{Env: []string{}}); err == nil {
+			applyLanguage(effective.Config)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+	values := effective.Values()
+
+	switch *output {
+	case outputJSON:
+		printJSON(configJSON{Config: effective.Path, Profile: effective.Profile, Values: values})
+	case outputTable:
+		t := newTable(i18n.T("cli.col.key"), i18n.T("cli.col.value"), i18n.T("cli.col.source"))
+		for _, value := range values {
+			t.addRow(value.Key, value.Value, value.Source.String())
+		}
+		t.print()
+	default:
+		fmt.Printf(i18n.T("cli.config.title"), effective.Path)
+		if effective.Profile != "" {
+			fmt.Printf(i18n.T("cli.profile")+"\n", effective.Profile)
+		}
+		for _, value := range values {
+			fmt.Printf("  %s = %s  (%s)\n", value.Key, value.Value, value.Source)
+		}
+	}
+	return 0
+}
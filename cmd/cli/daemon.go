@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/daemon"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/pidfile"
+	"fmt"
+	"os"
+)
+
+// runDaemon handles "daemon install", "daemon uninstall" and "daemon status"
+func runDaemon(args []string) int {
+	if len(args) == 0 {
+		printDaemonUsage()
+		return 2
+	}
+
+	switch args[0] {
+	case "install":
+		return runDaemonInstall(args[1:])
+	case "uninstall":
+		return runDaemonUninstall(args[1:])
+	case "status":
+		return runDaemonStatus(args[1:])
+	default:
+		printDaemonUsage()
+		return 2
+	}
+}
+
+func printDaemonUsage() {
+	fmt.Println(i18n.T("cli.usage.header"))
+	fmt.Println(i18n.T("cli.daemon.usage_install"))
+	fmt.Println(i18n.T("cli.daemon.usage_install_desc"))
+	fmt.Println(i18n.T("cli.daemon.usage_uninstall"))
+	fmt.Println(i18n.T("cli.daemon.usage_status"))
+}
+
+func runDaemonInstall(args []string) int {
+	fs, cfgPath, output := newCommand("daemon install", i18n.T("cli.usage.daemon_install"))
+	logPath := fs.String("log", "", fmt.Sprintf(i18n.T("cli.flag.daemon_log"), daemon.DefaultLogPath()))
+	noStart := fs.Bool("no-start", false, i18n.T("cli.flag.daemon_no_start"))
+	dryRun := fs.Bool("dry-run", false, i18n.T("cli.flag.daemon_dry_run"))
+	fs.Parse(args)
+
+	if err := checkOutputFormat(*output); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+
+	path := *cfgPath
+	if path == "" {
+		path = config.GetConfigPath()
+	}
+
+	// 설치 전에 설정 검사 - 잘못된 설정으로 재시작이 반복되지 않도록
+	cfg, err := loadConfig(path)
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T("cli.config_error"), err)
+		return 1
+	}
+
+	opts, err := daemon.NewOptions(path, *logPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+	opts.Profile = command.profile
+
+	if *dryRun {
+		content, err := daemon.Render(opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return 1
+		}
+		fmt.Print(content)
+		return 0
+	}
+
+	unitPath, err := daemon.Install(opts, !*noStart)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+
+	if *output == outputJSON {
+		printJSON(map[string]any{
+			"unit_path": unitPath,
+			"config":    opts.ConfigPath,
+			"log":       opts.LogPath,
+			"started":   !*noStart,
+		})
+		return 0
+	}
+
+	fmt.Printf(i18n.T("cli.daemon.installed"), unitPath)
+	fmt.Printf(i18n.T("cli.daemon.config_file"), opts.ConfigPath)
+	if opts.LogPath == daemon.LogJournal {
+		fmt.Printf(i18n.T("cli.daemon.log_journal"), daemon.SystemdUnitName)
+	} else {
+		fmt.Printf(i18n.T("cli.daemon.log_file"), opts.LogPath)
+	}
+	if *noStart {
+		fmt.Println(i18n.T("cli.daemon.not_started"))
+	}
+	if manager, _ := daemon.Manager(); manager == "systemd" {
+		fmt.Println(i18n.T("cli.daemon.linger_hint"))
+	}
+	fmt.Println(i18n.T("cli.daemon.login_hint"))
+	return 0
+}
+
+func runDaemonUninstall(args []string) int {
+	fs, _, output := newCommand("daemon uninstall", "daemon uninstall")
+	fs.Parse(args)
+
+	if err := checkOutputFormat(*output); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+
+	unitPath, err := daemon.Uninstall()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+
+	if *output == outputJSON {
+		printJSON(map[string]any{"unit_path": unitPath, "removed": true})
+		return 0
+	}
+	fmt.Printf(i18n.T("cli.daemon.uninstalled"), unitPath)
+	return 0
+}
+
+// daemonStatusJSON is the output of "daemon status -output json"
+type daemonStatusJSON struct {
+	Service *daemon.Status `json:"service,omitempty"`
+	PIDFile string         `json:"pid_file"`
+	PID     int            `json:"pid,omitempty"`
+	Running bool           `json:"running"`
+}
+
+func runDaemonStatus(args []string) int {
+	fs, _, output := newCommand("daemon status", "daemon status")
+	fs.Parse(args)
+
+	if err := checkOutputFormat(*output); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+
+	out := daemonStatusJSON{PIDFile: pidfile.DefaultPath()}
+
+	// 서비스 관리자가 없는 OS에서도 PID 파일로 실행 여부는 확인 가능
+	if status, err := daemon.GetStatus(); err == nil {
+		out.Service = &status
+	}
+
+	pid, running, err := pidfile.Status(out.PIDFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️ %v\n", err)
+	}
+	out.Running = running
+	if running {
+		out.PID = pid
+	}
+
+	switch *output {
+	case outputJSON:
+		printJSON(out)
+	default:
+		if out.Service != nil {
+			if out.Service.Installed {
+				fmt.Printf(i18n.T("cli.daemon.service_installed"), out.Service.Manager, out.Service.UnitPath)
+			} else {
+				fmt.Printf(i18n.T("cli.daemon.service_missing"), out.Service.Manager)
+			}
+			if out.Service.Active {
+				fmt.Println(i18n.T("cli.daemon.service_running"))
+			} else if out.Service.Installed {
+				fmt.Printf(i18n.T("cli.daemon.service_stopped"), out.Service.Detail)
+			}
+		}
+		if out.Running {
+			fmt.Printf(i18n.T("cli.daemon.monitor_running"), out.PID)
+		} else {
+			fmt.Println(i18n.T("cli.daemon.monitor_none"))
+		}
+	}
+
+	if !out.Running {
+		return 3
+	}
+	return 0
+}
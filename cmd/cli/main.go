@@ -2,12 +2,22 @@ package main
 
 import (
 	"bmw-driving-center-alter/internal/browser"
+	"bmw-driving-center-alter/internal/claims"
 	"bmw-driving-center-alter/internal/config"
-	"bmw-driving-center-alter/internal/models"
-	"bmw-driving-center-alter/internal/notifier"
+	"bmw-driving-center-alter/internal/control"
+	"bmw-driving-center-alter/internal/history"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/logging"
+	"bmw-driving-center-alter/internal/monitor"
+	"bmw-driving-center-alter/internal/mute"
+	"bmw-driving-center-alter/internal/pidfile"
+	"bmw-driving-center-alter/internal/server"
+	"bmw-driving-center-alter/internal/telegram"
+	"bmw-driving-center-alter/internal/watch"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
-	"log"
 	"os"
 	"os/signal"
 	"strings"
@@ -15,277 +25,421 @@ import (
 	"time"
 )
 
-var (
-	configPath  string
-	headless    bool
-	showPrograms bool
-	interval    int
-)
+var logger = logging.Component("cli")
+
+func main() {
+	// 서브커맨드가 없으면 (플래그만 있으면) run으로 처리 - 기존 사용법 호환
+	command := "run"
+	args := os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
 
-func init() {
-	flag.StringVar(&configPath, "config", "", "설정 파일 경로 (비어있으면 자동 탐색)")
-	flag.BoolVar(&headless, "headless", true, "백귳b77c운드 모드 (브라우저 숨김)")
-	flag.BoolVar(&showPrograms, "list-programs", false, "사용 가능한 프로그램 목록 표시")
-	flag.IntVar(&interval, "interval", 0, "확인 간격(초) - 0이면 설정 파일 값 사용")
+	switch command {
+	case "run":
+		os.Exit(runRun(args))
+	case "check":
+		os.Exit(runCheck(args))
+	case "programs":
+		os.Exit(runPrograms(args))
+	case "status":
+		os.Exit(runStatus(args))
+	case "history":
+		os.Exit(runHistory(args))
+	case "stats":
+		os.Exit(runStats(args))
+	case "test-notify":
+		os.Exit(runTestNotify(args))
+	case "validate":
+		os.Exit(runValidate(args))
+	case "login":
+		os.Exit(runLogin(args))
+	case "replay":
+		os.Exit(runReplay(args))
+	case "session":
+		os.Exit(runSession(args))
+	case "watch":
+		os.Exit(runWatch(args))
+	case "daemon":
+		os.Exit(runDaemon(args))
+	case "config":
+		os.Exit(runConfig(args))
+	case "help":
+		printUsage()
+	default:
+		fmt.Fprintf(os.Stderr, i18n.T("cli.unknown_command"), command)
+		printUsage()
+		os.Exit(2)
+	}
 }
 
-func main() {
-	flag.Parse()
+func printUsage() {
+	fmt.Println(i18n.T("cli.usage.main"))
+	fmt.Println()
+	fmt.Println(i18n.T("cli.usage.commands"))
+	fmt.Println(i18n.T("cli.usage.cmd_run"))
+	fmt.Println(i18n.T("cli.usage.cmd_check"))
+	fmt.Println(i18n.T("cli.usage.cmd_programs"))
+	fmt.Println(i18n.T("cli.usage.cmd_status"))
+	fmt.Println(i18n.T("cli.usage.cmd_history"))
+	fmt.Println(i18n.T("cli.usage.cmd_stats"))
+	fmt.Println(i18n.T("cli.usage.cmd_test_notify"))
+	fmt.Println(i18n.T("cli.usage.cmd_validate"))
+	fmt.Println(i18n.T("cli.usage.cmd_login"))
+	fmt.Println(i18n.T("cli.usage.cmd_replay"))
+	fmt.Println(i18n.T("cli.usage.cmd_session"))
+	fmt.Println(i18n.T("cli.usage.cmd_watch"))
+	fmt.Println(i18n.T("cli.usage.cmd_daemon"))
+	fmt.Println(i18n.T("cli.usage.cmd_config"))
+	fmt.Println()
+	fmt.Println(i18n.T("cli.usage.common_flags"))
+	fmt.Println(i18n.T("cli.usage.command_help"))
+}
 
-	// 프로그램 목록 표시 모드
-	if showPrograms {
-		showAvailablePrograms()
-		return
+// configFlags are the flags that override config values, by config key.
+// 명령줄에서 지정한 플래그만 적용되므로 지정하지 않으면 설정 값(프로필, 환경 변수 포함)을 그대로 씁니다.
+var configFlags = map[string]string{
+	"interval":   "monitor.interval",
+	"headless":   "monitor.headless",
+	"log-level":  "logging.level",
+	"log-format": "logging.format",
+}
+
+// command holds the -profile flag and the flag set of the running subcommand, for loadConfig
+var command struct {
+	profile string
+	flags   *flag.FlagSet
+}
+
+// newCommand creates a flag set with the -config, -profile and -output flags shared by every subcommand
+func newCommand(name, usage string) (*flag.FlagSet, *string, *string) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	cfgPath := fs.String("config", "", i18n.T("cli.flag.config"))
+	fs.StringVar(&command.profile, "profile", "", i18n.T("cli.flag.profile"))
+	command.flags = fs
+	output := addOutputFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), i18n.T("cli.usage.command"), usage)
+		fs.PrintDefaults()
 	}
+	return fs, cfgPath, output
+}
 
-	// 설정 파일 로드
-	if configPath == "" {
-		configPath = config.GetConfigPath()
+// loadConfig loads the config file (empty path = auto-detect) merged with the profile,
+// environment variables and flags of the command
+func loadConfig(path string) (*config.Config, error) {
+	effective, err := loadEffective(path)
+	if err != nil {
+		return nil, err
 	}
-	log.Printf("설정 파일: %s", configPath)
-	
-	cfg, err := config.Load(configPath)
+	return effective.Config, nil
+}
+
+// loadEffective loads the merged config with the source of each value
+func loadEffective(path string) (*config.Effective, error) {
+	effective, err := config.LoadEffective(path, config.Layers{
+		Profile: command.profile,
+		Flags:   flagOverrides(command.flags),
+	})
 	if err != nil {
-		log.Fatalf("❌ 설정 파일 로드 실패: %v", err)
+		return nil, err
+	}
+	applyLanguage(effective.Config)
+	if len(effective.UnknownEnv) > 0 {
+		logger.Warnf(i18n.T("cli.unknown_env"), strings.Join(effective.UnknownEnv, ", "))
 	}
+	return effective, nil
+}
 
-	// CLI 플래그가 설정되면 config의 값을 덮어쓰기
-	if interval > 0 {
-		cfg.Monitor.Interval = interval
+// flagOverrides returns the config values given as flags on the command line
+func flagOverrides(fs *flag.FlagSet) []config.Override {
+	var overrides []config.Override
+	if fs == nil {
+		return nil
 	}
-	// headless 플래그가 false로 설정된 경우 config 덮어쓰기
-	// (기본값이 true이므로 false일 때만 사용자가 변경한 것)
-	if !headless {
-		cfg.Monitor.Headless = false
-	} else {
-		// 명시적으로 true를 원하거나 기본값 사용 시 config 값 유지
-		// config에 값이 없으면 true 사용
-		if cfg.Monitor.Headless == false {
-			// config에서 false로 설정된 경우 유지
-		} else {
-			cfg.Monitor.Headless = true
+	fs.Visit(func(f *flag.Flag) {
+		if key, ok := configFlags[f.Name]; ok {
+			overrides = append(overrides, config.Override{Key: key, Value: f.Value.String(), Source: "-" + f.Name})
 		}
+	})
+	return overrides
+}
+
+// applyLanguage switches messages to the language of the config.
+// 잘못된 값은 Validate에서 알려주므로 여기서는 OS 언어를 그대로 씁니다.
+func applyLanguage(cfg *config.Config) {
+	if err := i18n.SetLanguage(cfg.Language); err != nil {
+		logger.Warnf("%v", err)
+	}
+}
+
+// addLogFlags adds -log-level and -log-format, which override the logging section of the config
+func addLogFlags(fs *flag.FlagSet) {
+	fs.String("log-level", "", i18n.T("cli.flag.log_level"))
+	fs.String("log-format", "", i18n.T("cli.flag.log_format"))
+}
+
+// setupLogging starts console and log file output for commands that drive the browser
+func setupLogging(cfg *config.Config) error {
+	return logging.Setup(cfg.Logging)
+}
+
+func runRun(args []string) int {
+	fs, cfgPath, output := newCommand("run", i18n.T("cli.usage.run"))
+	fs.Bool("headless", false, i18n.T("cli.flag.headless"))
+	showPrograms := fs.Bool("list-programs", false, i18n.T("cli.flag.list"))
+	fs.Int("interval", 0, i18n.T("cli.flag.interval"))
+	useTUI := fs.Bool("tui", false, i18n.T("cli.flag.tui"))
+	takeover := fs.Bool("takeover", false, i18n.T("cli.flag.takeover"))
+	addLogFlags(fs)
+	fs.Parse(args)
+
+	if err := checkOutputFormat(*output); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+
+	// 프로그램 목록 표시 모드
+	if *showPrograms {
+		return runPrograms([]string{"-output", *output})
+	}
+
+	// 설정 파일 로드
+	if *cfgPath == "" {
+		*cfgPath = config.GetConfigPath()
+	}
+	logger.Infof(i18n.T("cli.config_file"), *cfgPath)
+
+	// 설정 파일 < 프로필 < 환경 변수 < 명령줄 플래그 순으로 합침
+	effective, err := loadEffective(*cfgPath)
+	if err != nil {
+		logger.Errorf(i18n.T("cli.config_load_failed"), err)
+		return 1
+	}
+	cfg := effective.Config
+	if effective.Profile != "" {
+		logger.Infof(i18n.T("cli.profile"), effective.Profile)
 	}
 
 	// 설정 확인
-	if cfg.Auth.Username == "" || cfg.Auth.Password == "" {
-		log.Fatal("❌ 로그인 정보가 설정되지 않았습니다. config.yaml 파일을 확인해주세요.")
+	if err := cfg.Validate(); err != nil {
+		logger.Errorf(i18n.T("cli.config_check"), err)
+		return 1
 	}
 
-	if len(cfg.Programs) == 0 {
-		log.Fatal("❌ 모니터링할 프로그램이 선택되지 않았습니다. config.yaml 파일을 확인해주세요.")
+	if err := setupLogging(cfg); err != nil {
+		logger.Errorf("❌ %v", err)
+		return 2
 	}
+	defer logging.Close()
 
-	// 시작 메시지
-	fmt.Println("========================================")
-	fmt.Println("   BMW 드라이빙 센터 예약 모니터 CLI")
-	fmt.Println("========================================")
-	fmt.Printf("📧 사용자: %s\n", cfg.Auth.Username)
-	fmt.Printf("⏱️  간격: %d초\n", cfg.Monitor.Interval)
-	fmt.Printf("🎯 프로그램: %d개 선택\n", len(cfg.Programs))
-	fmt.Println("----------------------------------------")
-	for _, prog := range cfg.Programs {
-		koreanName := prog.Name
-		if kName, exists := models.ProgramNameMap[prog.Name]; exists {
-			koreanName = kName
+	// 같은 Chrome 프로필을 쓰는 모니터가 동시에 실행되지 않도록 PID 파일 잠금
+	pidFile, err := control.AcquireOrTakeOver(pidfile.DefaultPath(), *takeover, logger.Infof)
+	if err != nil {
+		logger.Errorf("❌ %v", err)
+		var locked *pidfile.LockedError
+		if errors.As(err, &locked) {
+			logger.Info(i18n.T("cli.takeover_hint"))
 		}
-		fmt.Printf("  • %s\n", koreanName)
+		return 1
 	}
-	fmt.Println("========================================\n")
+	defer pidFile.Release()
 
 	// 시그널 핸들러 설정
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
+	// 다른 인스턴스의 인계 요청(-takeover)은 종료 신호와 같이 처리
+	controlServer, err := control.Listen(func() {
+		logger.Info(i18n.T("cli.takeover_requested"))
+		select {
+		case sigChan <- syscall.SIGTERM:
+		default:
+		}
+	})
+	if err != nil {
+		logger.Warnf(i18n.T("cli.takeover_unavailable"), err)
+	} else {
+		defer controlServer.Close()
+	}
+
+	// 대화형 터미널 UI 모드
+	if *useTUI {
+		if err := runTUI(cfg, *cfgPath, sigChan); err != nil {
+			logger.Errorf(i18n.T("cli.run_failed"), err)
+			return 1
+		}
+		fmt.Println(i18n.T("cli.bye"))
+		return 0
+	}
+
+	// 시작 메시지 (JSON 출력이면 stdout에는 이벤트만)
+	if *output != outputJSON {
+		printBanner(cfg)
+	}
+
 	// 모니터링 시작
 	stopChan := make(chan bool)
 	go func() {
 		<-sigChan
-		fmt.Println("\n\n⏹️  종료 신호 수신... 정리 중...")
+		logger.Info(i18n.T("cli.shutdown"))
 		stopChan <- true
 	}()
 
 	// 모니터링 실행
-	if err := runMonitoring(cfg, stopChan); err != nil {
-		log.Fatalf("❌ 모니터링 실행 실패: %v", err)
+	if err := runMonitoring(cfg, *cfgPath, *output, stopChan); err != nil {
+		logger.Errorf(i18n.T("cli.run_failed"), err)
+		return 1
 	}
 
-	fmt.Println("👋 프로그램을 종료합니다.")
+	logger.Info(i18n.T("cli.bye"))
+	return 0
 }
 
-func runMonitoring(cfg *config.Config, stopChan chan bool) error {
-	log.Println("🚀 모니터링 시작...")
-
-	// 브라우저 클라이언트 초기화
-	browserClient, err := browser.NewBrowserClientWithConfig(cfg)
-	if err != nil {
-		return fmt.Errorf("브라우저 초기화 실패: %w", err)
+func printBanner(cfg *config.Config) {
+	accounts := cfg.GetAccounts()
+	fmt.Println("========================================")
+	fmt.Println(i18n.T("cli.banner.title"))
+	fmt.Println("========================================")
+	fmt.Printf(i18n.T("cli.banner.interval"), cfg.Monitor.Interval)
+	fmt.Printf(i18n.T("cli.banner.accounts"), len(accounts))
+	for _, account := range accounts {
+		fmt.Println("----------------------------------------")
+		fmt.Printf(i18n.T("cli.status.user"), account.Name, account.Username)
+		fmt.Printf(i18n.T("cli.banner.programs"), len(account.Programs))
+		for _, prog := range account.Programs {
+			fmt.Printf("  • %s\n", i18n.ProgramName(prog.Name))
+		}
+		fmt.Printf(i18n.T("cli.banner.recipients"), strings.Join(cfg.RecipientsFor(account), ", "))
 	}
-	defer browserClient.Close()
+	fmt.Print("========================================\n\n")
+}
 
-	// 브라우저 시작
-	if cfg.Monitor.Headless {
-		log.Println("🤖 백그라운드 모드로 브라우저 시작...")
-	} else {
-		log.Println("👀 일반 모드로 브라우저 시작 (창이 표시됩니다)...")
-	}
+func runMonitoring(cfg *config.Config, cfgPath, output string, stopChan chan bool) error {
+	logger.Info(i18n.T("cli.monitoring_start"))
 
-	if err := browserClient.Start(cfg.Monitor.Headless); err != nil {
-		return fmt.Errorf("브라우저 시작 실패: %w", err)
+	engine, err := monitor.NewEngine(cfg)
+	if err != nil {
+		return err
 	}
-
-	// 로그인 상태 확인 및 로그인
-	log.Println("🔍 로그인 상태 확인 중...")
-	if !browserClient.CheckLoginStatus() {
-		log.Println("🔐 BMW 드라이빙 센터 로그인 시작...")
-		if err := browserClient.Login(cfg.Auth.Username, cfg.Auth.Password); err != nil {
-			return fmt.Errorf("로그인 실패: %w", err)
+	historyStore := history.NewStore("")
+	engine.SetHistory(historyStore)
+	engine.SetClaims(claims.NewStore(""))
+	engine.SetMutes(mute.NewStore(""))
+	engine.SetWatches(watch.NewStore(""))
+
+	encoder := json.NewEncoder(os.Stdout)
+	engine.OnEvent(func(event monitor.Event) {
+		switch output {
+		case outputJSON:
+			encoder.Encode(toEventJSON(event))
+		case outputTable:
+			if event.Type == monitor.EventCheck {
+				printResultTable([]*monitor.CheckResult{event.Result})
+				return
+			}
+			printEvent(cfg, event)
+		default:
+			printEvent(cfg, event)
 		}
-		log.Println("✅ 로그인 성공!")
-	} else {
-		log.Println("🎉 저장된 세션이 유효합니다")
-	}
-
-	// 이메일 알림 초기화
-	emailNotifier := notifier.NewEmailNotifier(cfg.Email)
-	lastNotified := make(map[string]time.Time)
+	})
+	defer engine.Close()
+	defer startServer(cfg, engine, cfgPath).Close()
+	defer startTelegram(cfg, engine, historyStore, cfgPath).Close()
 
-	// 모니터링 루프
-	ticker := time.NewTicker(time.Duration(cfg.Monitor.Interval) * time.Second)
-	defer ticker.Stop()
+	if err := engine.Start(); err != nil {
+		return err
+	}
 
-	// 첫 번째 확인
-	checkReservations(browserClient, emailNotifier, cfg.Programs, lastNotified)
+	engine.Run(stopChan)
+	return nil
+}
 
-	checkCount := 1
-	for {
-		select {
-		case <-stopChan:
-			return nil
-		case <-ticker.C:
-			checkCount++
-			log.Printf("🔄 [확인 #%d] 예약 상태 확인 중...", checkCount)
-			checkReservations(browserClient, emailNotifier, cfg.Programs, lastNotified)
-		}
+// startServer opens the local HTTP server (dashboard, calendar feed) if server.listen is set
+func startServer(cfg *config.Config, engine *monitor.Engine, cfgPath string) *server.Server {
+	srv, err := server.Start(cfg.Server, engine, cfgPath)
+	if err != nil {
+		logger.Warnf("⚠️ %v", err)
 	}
+	return srv
 }
 
-func checkReservations(browser *browser.BrowserClient, notifier *notifier.EmailNotifier, programs []models.Program, lastNotified map[string]time.Time) {
-	checkTime := time.Now()
-	log.Printf("📍 [%s] 예약 페이지 확인 중...", checkTime.Format("15:04:05"))
-
-	// 프로그램 이름 추출
-	var programNames []string
-	for _, program := range programs {
-		programNames = append(programNames, program.Name)
+// startTelegram starts the Telegram bot if telegram.token is set
+func startTelegram(cfg *config.Config, engine *monitor.Engine, store *history.Store, cfgPath string) *telegram.Bot {
+	bot, err := telegram.Start(cfg.Telegram, engine, store, cfgPath)
+	if err != nil {
+		logger.Warnf("⚠️ %v", err)
 	}
+	return bot
+}
 
-	// 예약 페이지 확인 (hCaptcha 감지 포함)
-	availability, captchaDetected, err := browser.CheckReservationPageWithCaptchaAlert(programNames)
-	if err != nil {
-		log.Printf("❌ 예약 페이지 확인 실패: %v", err)
-		return
+// printEvent prints engine events to the console
+func printEvent(cfg *config.Config, event monitor.Event) {
+	prefix := ""
+	if event.Account != "" {
+		prefix = fmt.Sprintf("[%s] ", event.Account)
 	}
-	
-	// hCaptcha가 감지되면 이메일 알림 전송
-	if captchaDetected {
-		log.Println("📨 CAPTCHA 감지 이메일 알림 전송 중...")
-		if err := notifier.SendCaptchaAlert(); err != nil {
-			log.Printf("❌ CAPTCHA 알림 전송 실패: %v", err)
-		} else {
-			log.Println("✅ CAPTCHA 알림 이메일 전송 완료!")
+
+	switch event.Type {
+	case monitor.EventCheck:
+		printCheckResult(event.Result)
+
+		// 다음 확인 시간
+		nextCheck := event.Result.CheckedAt.Add(time.Duration(cfg.Monitor.Interval) * time.Second)
+		fmt.Printf(i18n.T("cli.next_check"), nextCheck.Format("15:04:05"))
+		fmt.Println(strings.Repeat("-", 40))
+	case monitor.EventOpened:
+		fmt.Printf(i18n.T("cli.found"), prefix)
+		for _, name := range event.Result.NewlyOpened {
+			fmt.Printf("   🚗 %s\n", programLabel(name))
+			if link, ok := event.Result.Links[name]; ok {
+				fmt.Printf("      👉 %s\n", link)
+			}
 		}
+	default:
+		logger.Log(event.Level(), prefix+event.Message)
 	}
+}
 
-	// 결과 확인
-	var openPrograms []models.Program
-	var newlyOpened []string
+func printCheckResult(result *monitor.CheckResult) {
 	availableCount := 0
 	unavailableCount := 0
 
-	fmt.Println("\n📋 프로그램 상태:")
-	for programName, isAvailable := range availability {
-		koreanName := ""
-		if kName, exists := models.ProgramNameMap[programName]; exists {
-			koreanName = fmt.Sprintf(" (%s)", kName)
-		}
-
-		if isAvailable {
+	fmt.Printf(i18n.T("cli.program_status"), result.Account)
+	for _, programName := range result.Programs {
+		if result.Availability[programName] {
 			availableCount++
-			fmt.Printf("   ✅ %s%s - 예약 가능!\n", programName, koreanName)
-
-			// 최근 알림 확인
-			lastTime, exists := lastNotified[programName]
-			if !exists || time.Since(lastTime) > time.Hour {
-				for _, program := range programs {
-					if program.Name == programName {
-						openPrograms = append(openPrograms, program)
-						newlyOpened = append(newlyOpened, programName)
-						lastNotified[programName] = time.Now()
-						break
-					}
-				}
-			}
+			fmt.Printf(i18n.T("cli.program_available"), programLabel(programName))
 		} else {
 			unavailableCount++
-			fmt.Printf("   ⭕ %s%s - 예약 불가\n", programName, koreanName)
+			fmt.Printf(i18n.T("cli.program_unavailable"), programLabel(programName))
 		}
 	}
 
-	fmt.Printf("\n📊 결과: 가능 %d개 / 불가 %d개\n", availableCount, unavailableCount)
+	fmt.Printf(i18n.T("cli.result"), availableCount, unavailableCount)
+	fmt.Printf(i18n.T("cli.session_status"), formatSession(result.Session))
+}
 
-	// 알림 전송
-	if len(newlyOpened) > 0 {
-		fmt.Println("\n🎉🎉 예약 가능한 프로그램 발견! 🎉🎉")
-		for _, name := range newlyOpened {
-			if kName, exists := models.ProgramNameMap[name]; exists {
-				fmt.Printf("   🚗 %s (%s)\n", name, kName)
-			} else {
-				fmt.Printf("   🚗 %s\n", name)
+// printResultTable prints check results as one row per program
+func printResultTable(results []*monitor.CheckResult) {
+	t := newTable(i18n.T("cli.col.checked_at"), i18n.T("cli.col.account"), i18n.T("cli.col.program"), i18n.T("cli.col.status"))
+	for _, result := range results {
+		for _, programName := range result.Programs {
+			state := i18n.T("cli.state.unavailable")
+			if result.Availability[programName] {
+				state = i18n.T("cli.state.available")
 			}
-		}
-
-		// 이메일 알림
-		status := &models.ReservationStatus{
-			Programs:    openPrograms,
-			CheckedAt:   checkTime,
-			HasOpenings: true,
-		}
-
-		fmt.Println("📨 이메일 알림 전송 중...")
-		if err := notifier.SendNotification(status); err != nil {
-			log.Printf("❌ 알림 전송 실패: %v", err)
-		} else {
-			fmt.Println("✅ 이메일 알림 전송 완료!")
+			t.addRow(result.CheckedAt.Format("15:04:05"), result.Account, programLabel(programName), state)
 		}
 	}
-
-	// 다음 확인 시간
-	nextCheck := checkTime.Add(time.Duration(60) * time.Second)
-	fmt.Printf("\n⏱️  다음 확인: %s\n", nextCheck.Format("15:04:05"))
-	fmt.Println(strings.Repeat("-", 40))
+	t.print()
 }
 
-func showAvailablePrograms() {
-	fmt.Println("\n=== 사용 가능한 프로그램 목록 ===\n")
-	
-	for _, category := range models.AllPrograms {
-		fmt.Printf("【%s】\n", category.Name)
-		for _, program := range category.Programs {
-			koreanName := ""
-			if kName, exists := models.ProgramNameMap[program]; exists {
-				koreanName = fmt.Sprintf(" (%s)", kName)
-			}
-			fmt.Printf("  • %s%s\n", program, koreanName)
-		}
-		fmt.Println()
+// formatSession describes the session age and cookie expiry
+func formatSession(session browser.SessionInfo) string {
+	text := fmt.Sprintf(i18n.T("cli.session_age"), session.Age().Round(time.Minute))
+	if session.ExpiresAt.IsZero() {
+		return text + i18n.T("cli.session_expiry_unknown")
 	}
-	
-	fmt.Println("위 프로그램명을 config.yaml 파일의 programs 섹션에 추가하세요.")
-	fmt.Println("예시:")
-	fmt.Println("programs:")
-	fmt.Println("  - name: M Core")
-	fmt.Println("    keywords:")
-	fmt.Println("      - M Core")
-	fmt.Println("      - M 코어")
-}
\ No newline at end of file
+	return text + fmt.Sprintf(i18n.T("cli.session_expiry"), session.ExpiresAt.Format("2006-01-02 15:04"))
+}
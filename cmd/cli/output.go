@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bmw-driving-center-alter/internal/browser"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/models"
+	"bmw-driving-center-alter/internal/monitor"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/text/width"
+)
+
+// Output formats accepted by -output
+const (
+	outputText  = "text"
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// addOutputFlag adds the -output flag (also accepted as --output)
+func addOutputFlag(fs *flag.FlagSet) *string {
+	return fs.String("output", outputText, i18n.T("cli.flag.output"))
+}
+
+func checkOutputFormat(format string) error {
+	switch format {
+	case outputText, outputTable, outputJSON:
+		return nil
+	}
+	return fmt.Errorf(i18n.T("cli.unsupported_output"), format)
+}
+
+// printJSON writes v to stdout as indented JSON
+func printJSON(v any) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(v)
+}
+
+// resultJSON is the machine-readable form of a check result
+type resultJSON struct {
+	Account     string        `json:"account"`
+	CheckedAt   time.Time     `json:"checked_at"`
+	Programs    []programJSON `json:"programs"`
+	NewlyOpened []string      `json:"newly_opened"`
+	Captcha     bool          `json:"captcha"`
+	Session     *sessionJSON  `json:"session,omitempty"`
+}
+
+type programJSON struct {
+	Name       string `json:"name"`
+	KoreanName string `json:"korean_name,omitempty"`
+	Available  bool   `json:"available"`
+}
+
+type sessionJSON struct {
+	LoggedInAt time.Time  `json:"logged_in_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// eventJSON is the machine-readable form of an engine event (one per line in "run -output json")
+type eventJSON struct {
+	Time    time.Time   `json:"time"`
+	Account string      `json:"account,omitempty"`
+	Type    string      `json:"type"`
+	Message string      `json:"message,omitempty"`
+	Result  *resultJSON `json:"result,omitempty"`
+}
+
+func toResultJSON(result *monitor.CheckResult) *resultJSON {
+	out := &resultJSON{
+		Account:     result.Account,
+		CheckedAt:   result.CheckedAt,
+		Programs:    []programJSON{},
+		NewlyOpened: result.NewlyOpened,
+		Captcha:     result.CaptchaDetected,
+		Session:     toSessionJSON(result.Session),
+	}
+	if out.NewlyOpened == nil {
+		out.NewlyOpened = []string{}
+	}
+	for _, program := range result.Programs {
+		out.Programs = append(out.Programs, programJSON{
+			Name:       program,
+			KoreanName: models.ProgramNameMap[program],
+			Available:  result.Availability[program],
+		})
+	}
+	return out
+}
+
+func toSessionJSON(session browser.SessionInfo) *sessionJSON {
+	if session.LoggedInAt.IsZero() {
+		return nil
+	}
+	out := &sessionJSON{LoggedInAt: session.LoggedInAt}
+	if !session.ExpiresAt.IsZero() {
+		expiresAt := session.ExpiresAt
+		out.ExpiresAt = &expiresAt
+	}
+	return out
+}
+
+func toEventJSON(event monitor.Event) eventJSON {
+	out := eventJSON{
+		Time:    event.Time,
+		Account: event.Account,
+		Type:    string(event.Type),
+		Message: event.Message,
+	}
+	if event.Result != nil {
+		out.Result = toResultJSON(event.Result)
+	}
+	return out
+}
+
+// programLabel returns "Name (한글 이름)" when the name is localized
+func programLabel(name string) string {
+	return i18n.ProgramLabel(name)
+}
+
+// table prints rows aligned by terminal cell width (Korean text is double width)
+type table struct {
+	headers []string
+	rows    [][]string
+}
+
+func newTable(headers ...string) *table {
+	return &table{headers: headers}
+}
+
+func (t *table) addRow(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+func (t *table) print() {
+	widths := make([]int, len(t.headers))
+	for _, row := range append([][]string{t.headers}, t.rows...) {
+		for i, cell := range row {
+			if i < len(widths) && displayWidth(cell) > widths[i] {
+				widths[i] = displayWidth(cell)
+			}
+		}
+	}
+
+	printRow := func(row []string) {
+		var b strings.Builder
+		for i, cell := range row {
+			if i == len(row)-1 {
+				b.WriteString(cell)
+				break
+			}
+			b.WriteString(padRight(cell, widths[i]+2))
+		}
+		fmt.Println(strings.TrimRight(b.String(), " "))
+	}
+
+	printRow(t.headers)
+	var separator []string
+	for _, w := range widths {
+		separator = append(separator, strings.Repeat("-", w))
+	}
+	printRow(separator)
+	for _, row := range t.rows {
+		printRow(row)
+	}
+}
+
+// runeWidth returns how many terminal cells a rune occupies
+func runeWidth(r rune) int {
+	if r == 0x200D || (r >= 0xFE00 && r <= 0xFE0F) {
+		return 0 // ZWJ, 이모지 변형 선택자
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+func displayWidth(s string) int {
+	total := 0
+	for _, r := range s {
+		total += runeWidth(r)
+	}
+	return total
+}
+
+// truncate shortens s to at most n terminal cells
+func truncate(s string, n int) string {
+	if displayWidth(s) <= n {
+		return s
+	}
+	var b strings.Builder
+	used := 0
+	for _, r := range s {
+		w := runeWidth(r)
+		if used+w > n-1 {
+			break
+		}
+		b.WriteRune(r)
+		used += w
+	}
+	return b.String() + "…"
+}
+
+// padRight pads s with spaces to n terminal cells
+func padRight(s string, n int) string {
+	if pad := n - displayWidth(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bmw-driving-center-alter/internal/capture"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/models"
+	"bmw-driving-center-alter/internal/scraper"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// runReplay re-runs the current parser over stored captures and reports disagreements
+func runReplay(args []string) int {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	verbose := fs.Bool("v", false, i18n.T("cli.flag.replay_verbose"))
+	showSessions := fs.Bool("sessions", false, i18n.T("cli.flag.replay_sessions"))
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), i18n.T("cli.replay.usage"))
+		fmt.Fprintf(fs.Output(), i18n.T("cli.replay.default_dir"), capture.DefaultDir())
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	targets := fs.Args()
+	if len(targets) == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	var files []string
+	for _, target := range targets {
+		info, err := os.Stat(target)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return 2
+		}
+		if info.IsDir() {
+			dirFiles, err := capture.List(target)
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+				return 2
+			}
+			files = append(files, dirFiles...)
+		} else {
+			files = append(files, target)
+		}
+	}
+
+	if len(files) == 0 {
+		fmt.Println(i18n.T("cli.replay.none"))
+		return 0
+	}
+
+	matched, mismatched, failed := 0, 0, 0
+	for _, file := range files {
+		rec, err := capture.Load(file)
+		if err != nil {
+			failed++
+			fmt.Printf("❌ %s: %v\n", filepath.Base(file), err)
+			continue
+		}
+
+		if *showSessions {
+			printCaptureSessions(file, rec)
+		}
+
+		replayed, err := reparse(rec)
+		if err != nil {
+			failed++
+			fmt.Printf("❌ %s: %v\n", filepath.Base(file), err)
+			continue
+		}
+
+		diffs := diffResults(rec.Result, replayed)
+		if len(diffs) == 0 {
+			matched++
+			if *verbose {
+				fmt.Printf(i18n.T("cli.replay.match"), filepath.Base(file), rec.Source,
+					rec.FetchedAt.Local().Format("2006-01-02 15:04:05"))
+			}
+			continue
+		}
+
+		mismatched++
+		fmt.Printf(i18n.T("cli.replay.mismatch"), filepath.Base(file), rec.Source,
+			rec.FetchedAt.Local().Format("2006-01-02 15:04:05"))
+		fmt.Printf("   URL: %s\n", rec.URL)
+		for _, d := range diffs {
+			fmt.Printf(i18n.T("cli.replay.diff"), d.program, availabilityText(d.recorded), availabilityText(d.current))
+		}
+	}
+
+	fmt.Printf(i18n.T("cli.replay.summary"),
+		len(files), matched, mismatched, failed)
+
+	if mismatched > 0 || failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// printCaptureSessions prints the sessions the current parser finds in a capture
+func printCaptureSessions(file string, rec *capture.Record) {
+	programs := captureProgramList(rec)
+	sessions := scraper.ParseSessions(rec.Page, programs, rec.URL)
+
+	fmt.Printf(i18n.T("cli.replay.sessions"), filepath.Base(file), len(sessions))
+	for _, session := range sessions {
+		when := session.Start.Format("2006-01-02 15:04")
+		if session.AllDay {
+			when = session.Start.Format("2006-01-02") + i18n.T("cli.replay.no_time")
+		}
+		fmt.Printf("   • %s %s - %s\n", when, session.Program, availabilityText(session.Open))
+		if session.URL != rec.URL {
+			fmt.Printf("     %s\n", session.URL)
+		}
+	}
+
+	links := scraper.ParseProgramLinks(rec.Page, programs, rec.URL)
+	fmt.Printf(i18n.T("cli.replay.links"), len(links))
+	for _, program := range programs {
+		if link, ok := links[program.Name]; ok {
+			fmt.Printf("   • %s: %s\n", program.Name, link)
+		}
+	}
+}
+
+// reparse runs the current parser against the capture's stored page.
+// 브라우저와 HTTP 스크래퍼 모두 같은 이름/키워드 비교를 사용합니다.
+func reparse(rec *capture.Record) (map[string]bool, error) {
+	switch rec.Source {
+	case capture.SourceBrowser, capture.SourceScraper:
+		return scraper.ParseProgramAvailability(rec.Page, captureProgramList(rec)), nil
+	default:
+		return nil, fmt.Errorf(i18n.T("cli.replay.unknown_source"), rec.Source)
+	}
+}
+
+// captureProgramList returns the programs (names and keywords) the capture was checked for
+func captureProgramList(rec *capture.Record) []models.Program {
+	programs := make([]models.Program, 0, len(rec.Programs))
+	for _, program := range rec.Programs {
+		programs = append(programs, models.Program{Name: program.Name, Keywords: program.Keywords})
+	}
+	return programs
+}
+
+type resultDiff struct {
+	program  string
+	recorded bool
+	current  bool
+}
+
+// diffResults compares recorded and replayed results, sorted by program name
+func diffResults(recorded, current map[string]bool) []resultDiff {
+	names := make(map[string]bool)
+	for name := range recorded {
+		names[name] = true
+	}
+	for name := range current {
+		names[name] = true
+	}
+
+	var diffs []resultDiff
+	for name := range names {
+		if recorded[name] != current[name] {
+			diffs = append(diffs, resultDiff{program: name, recorded: recorded[name], current: current[name]})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].program < diffs[j].program })
+	return diffs
+}
+
+func availabilityText(available bool) string {
+	if available {
+		return i18n.T("cli.replay.available")
+	}
+	return i18n.T("cli.replay.unavailable")
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bmw-driving-center-alter/internal/browser"
+	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/session"
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// runSession handles "session export" and "session import"
+func runSession(args []string) int {
+	if len(args) == 0 {
+		printSessionUsage()
+		return 2
+	}
+
+	switch args[0] {
+	case "export":
+		return runSessionExport(args[1:])
+	case "import":
+		return runSessionImport(args[1:])
+	default:
+		printSessionUsage()
+		return 2
+	}
+}
+
+func printSessionUsage() {
+	fmt.Println(i18n.T("cli.usage.header"))
+	fmt.Println(i18n.T("cli.session.usage_export"))
+	fmt.Println(i18n.T("cli.session.usage_import"))
+	fmt.Printf(i18n.T("cli.session.usage_passphrase"), session.PassphraseEnv)
+}
+
+func runSessionExport(args []string) int {
+	fs := flag.NewFlagSet("session export", flag.ExitOnError)
+	cfgPath := fs.String("config", "", i18n.T("cli.flag.config"))
+	fs.StringVar(&command.profile, "profile", "", i18n.T("cli.flag.profile"))
+	accountName := fs.String("account", "", i18n.T("cli.flag.session_account"))
+	output := fs.String("o", "bmw-session.enc", i18n.T("cli.flag.session_output"))
+	showBrowser := fs.Bool("show-browser", false, i18n.T("cli.flag.show_browser_manual"))
+	fs.Parse(args)
+
+	cfg, account, err := loadAccount(*cfgPath, *accountName)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+
+	passphrase, err := readPassphrase(true)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+
+	client, err := browser.NewBrowserClientForAccount(cfg, account)
+	if err != nil {
+		fmt.Printf(i18n.T("cli.browser_init_failed"), err)
+		return 1
+	}
+	defer client.Close()
+
+	headless := cfg.Monitor.Headless && !*showBrowser
+	if err := client.Start(headless); err != nil {
+		fmt.Printf(i18n.T("cli.browser_start_failed"), err)
+		return 1
+	}
+
+	if !client.CheckLoginStatus() {
+		fmt.Printf(i18n.T("cli.session.logging_in"), account.Name)
+		if err := client.Login(account.Username, account.Password); err != nil {
+			fmt.Printf(i18n.T("cli.session.login_failed"), err)
+			return 1
+		}
+	}
+
+	snap, err := client.ExportSession()
+	if err != nil {
+		fmt.Printf(i18n.T("cli.session.export_failed"), err)
+		return 1
+	}
+	snap.Account = account.Name
+
+	if err := session.Export(*output, snap, passphrase); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+
+	fmt.Printf(i18n.T("cli.session.exported"), account.Name, *output, len(snap.Cookies))
+	fmt.Println(i18n.T("cli.session.exported_hint"))
+	return 0
+}
+
+func runSessionImport(args []string) int {
+	fs := flag.NewFlagSet("session import", flag.ExitOnError)
+	cfgPath := fs.String("config", "", i18n.T("cli.flag.config"))
+	fs.StringVar(&command.profile, "profile", "", i18n.T("cli.flag.profile"))
+	accountName := fs.String("account", "", i18n.T("cli.flag.session_import_account"))
+	verify := fs.Bool("verify", false, i18n.T("cli.flag.session_verify"))
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		printSessionUsage()
+		return 2
+	}
+
+	passphrase, err := readPassphrase(false)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+
+	snap, err := session.Import(fs.Arg(0), passphrase)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+
+	name := *accountName
+	if name == "" {
+		name = snap.Account
+	}
+	cfg, account, err := loadAccount(*cfgPath, name)
+	if err != nil && *accountName == "" {
+		// 파일에 기록된 계정이 이 컴퓨터 설정에 없으면 첫 번째 계정 사용
+		cfg, account, err = loadAccount(*cfgPath, "")
+	}
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+
+	stateDir := browser.AccountStateDir(account.Name)
+	if err := session.SavePending(stateDir, snap); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+
+	fmt.Printf(i18n.T("cli.session.imported"),
+		account.Name, snap.ExportedAt.Local().Format("2006-01-02 15:04"), len(snap.Cookies))
+	fmt.Println(i18n.T("cli.session.imported_hint"))
+
+	if !*verify {
+		return 0
+	}
+
+	client, err := browser.NewBrowserClientForAccount(cfg, account)
+	if err != nil {
+		fmt.Printf(i18n.T("cli.browser_init_failed"), err)
+		return 1
+	}
+	defer client.Close()
+
+	if err := client.Start(true); err != nil {
+		fmt.Printf(i18n.T("cli.browser_start_failed"), err)
+		return 1
+	}
+	if _, err := client.ApplyPendingSession(); err != nil {
+		fmt.Printf(i18n.T("cli.session.apply_failed"), err)
+		return 1
+	}
+	if !client.CheckLoginStatus() {
+		fmt.Println(i18n.T("cli.session.invalid"))
+		return 1
+	}
+
+	fmt.Println(i18n.T("cli.session.valid"))
+	return 0
+}
+
+// loadAccount loads the config and finds an account by name (empty name = first account)
+func loadAccount(path, name string) (*config.Config, config.AccountConfig, error) {
+	cfg, err := loadConfig(path)
+	if err != nil {
+		return nil, config.AccountConfig{}, err
+	}
+
+	accounts := cfg.GetAccounts()
+	if name == "" {
+		return cfg, accounts[0], nil
+	}
+	for _, account := range accounts {
+		if account.Name == name {
+			return cfg, account, nil
+		}
+	}
+	return nil, config.AccountConfig{}, fmt.Errorf(i18n.T("cli.account_not_found"), name)
+}
+
+// readPassphrase reads the session file passphrase from the environment or stdin
+func readPassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv(session.PassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Print(i18n.T("cli.session.passphrase"))
+	passphrase, err := reader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf(i18n.T("cli.session.passphrase_failed"), err)
+	}
+	passphrase = strings.TrimRight(passphrase, "\r\n")
+
+	if confirm {
+		fmt.Print(i18n.T("cli.session.passphrase_confirm"))
+		again, err := reader.ReadString('\n')
+		if err != nil {
+			return "", fmt.Errorf(i18n.T("cli.session.passphrase_failed"), err)
+		}
+		if strings.TrimRight(again, "\r\n") != passphrase {
+			return "", errors.New(i18n.T("cli.session.passphrase_mismatch"))
+		}
+	}
+
+	return passphrase, nil
+}
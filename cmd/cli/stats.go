@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bmw-driving-center-alter/internal/analytics"
+	"bmw-driving-center-alter/internal/history"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/models"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// statsJSON is one program in "stats -output json" (시간은 초 단위)
+type statsJSON struct {
+	Program    string           `json:"program"`
+	KoreanName string           `json:"korean_name,omitempty"`
+	Checks     int              `json:"checks"`
+	From       time.Time        `json:"from"`
+	To         time.Time        `json:"to"`
+	Openings   []openingJSON    `json:"openings"`
+	ByWeekday  [7]int           `json:"by_weekday"` // 일요일부터
+	ByHour     [24]int          `json:"by_hour"`
+	SellOut    distributionJSON `json:"sell_out_seconds"`
+	LeadTime   distributionJSON `json:"lead_time_seconds"`
+	Windows    []windowJSON     `json:"windows"`
+	Summary    string           `json:"summary"`
+}
+
+type openingJSON struct {
+	Account string    `json:"account"`
+	Time    time.Time `json:"time"`
+	SoldOut int64     `json:"sold_out_seconds,omitempty"`
+}
+
+type distributionJSON struct {
+	Count  int   `json:"count"`
+	Min    int64 `json:"min"`
+	P25    int64 `json:"p25"`
+	Median int64 `json:"median"`
+	P75    int64 `json:"p75"`
+	Max    int64 `json:"max"`
+}
+
+type windowJSON struct {
+	Weekday  string `json:"weekday"`
+	Hour     int    `json:"hour"`
+	Openings int    `json:"openings"`
+}
+
+func toStatsJSON(s analytics.ProgramStats) statsJSON {
+	out := statsJSON{
+		Program:   s.Program,
+		Checks:    s.Checks,
+		From:      s.From,
+		To:        s.To,
+		Openings:  []openingJSON{},
+		ByWeekday: s.ByWeekday,
+		ByHour:    s.ByHour,
+		SellOut:   toDistributionJSON(s.SellOut),
+		LeadTime:  toDistributionJSON(s.LeadTime),
+		Windows:   []windowJSON{},
+		Summary:   s.Summary(),
+	}
+	if kName, exists := models.ProgramNameMap[s.Program]; exists {
+		out.KoreanName = kName
+	}
+	for _, opening := range s.Openings {
+		out.Openings = append(out.Openings, openingJSON{
+			Account: opening.Account,
+			Time:    opening.Time,
+			SoldOut: int64(opening.SoldOut.Seconds()),
+		})
+	}
+	for _, w := range s.Windows {
+		out.Windows = append(out.Windows, windowJSON{Weekday: w.Weekday.String(), Hour: w.Hour, Openings: w.Openings})
+	}
+	return out
+}
+
+func toDistributionJSON(d analytics.Distribution) distributionJSON {
+	return distributionJSON{
+		Count:  d.Count,
+		Min:    int64(d.Min.Seconds()),
+		P25:    int64(d.P25.Seconds()),
+		Median: int64(d.Median.Seconds()),
+		P75:    int64(d.P75.Seconds()),
+		Max:    int64(d.Max.Seconds()),
+	}
+}
+
+func runStats(args []string) int {
+	fs, _, output := newCommand("stats", i18n.T("cli.usage.stats"))
+	accountName := fs.String("account", "", i18n.T("cli.flag.stats_account"))
+	program := fs.String("program", "", i18n.T("cli.flag.stats_program"))
+	days := fs.Int("days", 90, i18n.T("cli.flag.stats_days"))
+	fs.Parse(args)
+
+	if err := checkOutputFormat(*output); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+	if *days <= 0 {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.stats.days_invalid"))
+		return 2
+	}
+
+	store := history.NewStore("")
+	entries, err := store.Read(history.Filter{
+		Account: *accountName,
+		Since:   time.Now().AddDate(0, 0, -*days),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+
+	var stats []analytics.ProgramStats
+	for _, s := range analytics.Analyze(entries, analytics.Options{}) {
+		if *program == "" || s.Program == *program {
+			stats = append(stats, s)
+		}
+	}
+
+	switch *output {
+	case outputJSON:
+		out := []statsJSON{}
+		for _, s := range stats {
+			out = append(out, toStatsJSON(s))
+		}
+		printJSON(out)
+	case outputTable:
+		t := newTable(i18n.T("cli.col.program"), i18n.T("cli.col.checks"), i18n.T("cli.col.openings"), i18n.T("cli.col.windows"), i18n.T("cli.col.sell_out"), i18n.T("cli.col.lead_time"))
+		for _, s := range stats {
+			t.addRow(programLabel(s.Program), fmt.Sprintf(i18n.T("cli.times"), s.Checks), fmt.Sprintf(i18n.T("cli.times"), len(s.Openings)),
+				formatWindows(s.Windows), formatMedian(s.SellOut, ""), formatMedian(s.LeadTime, i18n.T("cli.stats.before")))
+		}
+		t.print()
+	default:
+		if len(stats) == 0 {
+			fmt.Printf(i18n.T("cli.stats.empty"), *days, store.Path())
+			return 0
+		}
+		for i, s := range stats {
+			if i > 0 {
+				fmt.Println()
+			}
+			printStats(s)
+		}
+	}
+	return 0
+}
+
+// printStats prints one program's opening pattern with text bar charts
+func printStats(s analytics.ProgramStats) {
+	fmt.Printf("📊 %s\n", programLabel(s.Program))
+	fmt.Printf(i18n.T("cli.stats.range"), s.From.Format("2006-01-02"), s.To.Format("2006-01-02"), s.Checks, len(s.Openings))
+	fmt.Printf("   %s\n", s.Summary())
+	if len(s.Openings) == 0 {
+		return
+	}
+
+	fmt.Println(i18n.T("cli.stats.by_weekday"))
+	for day, count := range s.ByWeekday {
+		fmt.Printf("     %s %s %d\n", analytics.WeekdayName(time.Weekday(day)), bar(count, maxOf(s.ByWeekday[:])), count)
+	}
+	fmt.Println(i18n.T("cli.stats.by_hour"))
+	for hour, count := range s.ByHour {
+		if count > 0 {
+			fmt.Printf(i18n.T("cli.stats.hour_row"), hour, bar(count, maxOf(s.ByHour[:])), count)
+		}
+	}
+	if s.SellOut.Count > 0 {
+		fmt.Printf(i18n.T("cli.stats.sell_out"),
+			analytics.FormatDuration(s.SellOut.Min), analytics.FormatDuration(s.SellOut.Median), analytics.FormatDuration(s.SellOut.Max), s.SellOut.Count)
+	}
+	if s.LeadTime.Count > 0 {
+		fmt.Printf(i18n.T("cli.stats.lead_time"),
+			analytics.FormatDuration(s.LeadTime.Min), analytics.FormatDuration(s.LeadTime.Median), analytics.FormatDuration(s.LeadTime.Max), s.LeadTime.Count)
+	}
+}
+
+// bar draws a bar of up to 20 cells scaled to the largest count
+func bar(count, largest int) string {
+	if largest == 0 {
+		return ""
+	}
+	cells := count * 20 / largest
+	if count > 0 && cells == 0 {
+		cells = 1
+	}
+	return strings.Repeat("█", cells) + strings.Repeat("·", 20-cells)
+}
+
+func maxOf(counts []int) int {
+	largest := 0
+	for _, count := range counts {
+		largest = max(largest, count)
+	}
+	return largest
+}
+
+func formatWindows(windows []analytics.Window) string {
+	if len(windows) == 0 {
+		return "-"
+	}
+	var names []string
+	for _, w := range windows {
+		names = append(names, fmt.Sprintf(i18n.T("cli.stats.window"), w, w.Openings))
+	}
+	return strings.Join(names, ", ")
+}
+
+func formatMedian(d analytics.Distribution, suffix string) string {
+	if d.Count == 0 {
+		return "-"
+	}
+	return analytics.FormatDuration(d.Median) + suffix
+}
@@ -0,0 +1,514 @@
+package main
+
+import (
+	"bmw-driving-center-alter/internal/claims"
+	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/history"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/logging"
+	"bmw-driving-center-alter/internal/monitor"
+	"bmw-driving-center-alter/internal/mute"
+	"bmw-driving-center-alter/internal/watch"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// maxEventLines is how many event lines the TUI keeps for scrolling
+const maxEventLines = 500
+
+// ANSI escape sequences
+const (
+	ansiAltScreenOn  = "\x1b[?1049h"
+	ansiAltScreenOff = "\x1b[?1049l"
+	ansiHideCursor   = "\x1b[?25l"
+	ansiShowCursor   = "\x1b[?25h"
+	ansiHome         = "\x1b[H"
+	ansiClearLine    = "\x1b[K"
+	ansiClearBelow   = "\x1b[J"
+	ansiReset        = "\x1b[0m"
+	ansiBold         = "\x1b[1m"
+	ansiDim          = "\x1b[2m"
+	ansiReverse      = "\x1b[7m"
+	ansiGreen        = "\x1b[32m"
+	ansiYellow       = "\x1b[33m"
+	ansiRed          = "\x1b[31m"
+)
+
+// Key codes returned by readKey
+const (
+	keyNone = iota
+	keyUp
+	keyDown
+	keyPageUp
+	keyPageDown
+	keyChar
+)
+
+// tui is a full-screen terminal front-end for the monitoring engine
+type tui struct {
+	cfg          *config.Config
+	engine       *monitor.Engine
+	multiAccount bool
+
+	mu       sync.Mutex
+	events   []string
+	scroll   int // 이벤트 창을 맨 아래에서 위로 스크롤한 줄 수
+	selected int
+	running  bool
+	partial  string // log 출력 중 아직 줄바꿈이 오지 않은 부분
+
+	redraw chan struct{}
+}
+
+func newTUI(cfg *config.Config, engine *monitor.Engine) *tui {
+	return &tui{
+		cfg:          cfg,
+		engine:       engine,
+		multiAccount: len(cfg.GetAccounts()) > 1,
+		redraw:       make(chan struct{}, 1),
+	}
+}
+
+// runTUI runs the monitor with the interactive terminal UI until the user quits
+func runTUI(cfg *config.Config, cfgPath string, sigChan <-chan os.Signal) error {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.New(i18n.T("cli.tui.no_terminal"))
+	}
+
+	engine, err := monitor.NewEngine(cfg)
+	if err != nil {
+		return err
+	}
+	historyStore := history.NewStore("")
+	engine.SetHistory(historyStore)
+	engine.SetClaims(claims.NewStore(""))
+	engine.SetMutes(mute.NewStore(""))
+	engine.SetWatches(watch.NewStore(""))
+	ui := newTUI(cfg, engine)
+	engine.OnEvent(ui.handleEvent)
+	defer startServer(cfg, engine, cfgPath).Close()
+	defer startTelegram(cfg, engine, historyStore, cfgPath).Close()
+
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf(i18n.T("cli.tui.terminal_failed"), err)
+	}
+	os.Stdout.WriteString(ansiAltScreenOn + ansiHideCursor)
+
+	// 브라우저 등 다른 패키지의 로그도 이벤트 창으로
+	restoreConsole := logging.SetConsole(ui, false)
+
+	stopChan := make(chan bool, 1)
+	done := make(chan error, 1)
+	go func() {
+		ui.addEvent(i18n.T("cli.monitoring_start"))
+		if err := engine.Start(); err != nil {
+			done <- err
+			return
+		}
+		ui.setRunning(true)
+		engine.Run(stopChan)
+		done <- nil
+	}()
+
+	keys := make(chan []byte)
+	go readKeys(keys)
+
+	runErr := ui.loop(keys, sigChan, done)
+
+	os.Stdout.WriteString(ansiShowCursor + ansiAltScreenOff)
+	term.Restore(fd, oldState)
+	restoreConsole()
+
+	if runErr == nil {
+		fmt.Println(i18n.T("cli.tui.stopping"))
+		stopChan <- true
+		runErr = <-done
+	}
+	engine.Close()
+	return runErr
+}
+
+// loop handles input and redraws until the user quits or the engine stops
+func (t *tui) loop(keys <-chan []byte, sigChan <-chan os.Signal, done <-chan error) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	t.draw()
+	for {
+		select {
+		case err := <-done:
+			if err != nil {
+				return err
+			}
+			return nil
+		case <-sigChan:
+			return nil
+		case buf := <-keys:
+			if quit := t.handleKey(buf); quit {
+				return nil
+			}
+		case <-t.redraw:
+		case <-ticker.C:
+		}
+		t.draw()
+	}
+}
+
+// readKeys forwards raw stdin reads until stdin is closed
+func readKeys(keys chan<- []byte) {
+	buf := make([]byte, 16)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return
+		}
+		key := make([]byte, n)
+		copy(key, buf[:n])
+		keys <- key
+	}
+}
+
+// parseKey decodes a raw key press
+func parseKey(buf []byte) (int, byte) {
+	switch string(buf) {
+	case "\x1b[A", "\x1bOA":
+		return keyUp, 0
+	case "\x1b[B", "\x1bOB":
+		return keyDown, 0
+	case "\x1b[5~":
+		return keyPageUp, 0
+	case "\x1b[6~":
+		return keyPageDown, 0
+	}
+	if len(buf) == 1 {
+		return keyChar, buf[0]
+	}
+	return keyNone, 0
+}
+
+// handleKey runs the action bound to a key and reports whether to quit
+func (t *tui) handleKey(buf []byte) bool {
+	key, ch := parseKey(buf)
+	switch key {
+	case keyUp:
+		t.moveSelection(-1)
+	case keyDown:
+		t.moveSelection(1)
+	case keyPageUp:
+		t.scrollEvents(t.eventRows() / 2)
+	case keyPageDown:
+		t.scrollEvents(-t.eventRows() / 2)
+	case keyChar:
+		switch ch {
+		case 'q', 'Q', 3: // 3 = Ctrl+C
+			return true
+		case 'k':
+			t.moveSelection(-1)
+		case 'j':
+			t.moveSelection(1)
+		case 'c', 'C':
+			if !t.isRunning() {
+				t.addEvent(i18n.T("cli.tui.still_logging_in"))
+				break
+			}
+			t.addEvent(i18n.T("cli.tui.check_requested"))
+			t.engine.TriggerCheck()
+		case 'p', 'P':
+			t.engine.SetPaused(!t.engine.Paused())
+		case 't', 'T', ' ':
+			t.toggleSelected()
+		case 'n', 'N':
+			go t.engine.SendTestNotification()
+		}
+	}
+	return false
+}
+
+func (t *tui) moveSelection(delta int) {
+	count := len(t.engine.ProgramStates())
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.selected += delta
+	if t.selected >= count {
+		t.selected = count - 1
+	}
+	if t.selected < 0 {
+		t.selected = 0
+	}
+}
+
+func (t *tui) scrollEvents(delta int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.scroll += delta
+	if t.scroll > len(t.events)-1 {
+		t.scroll = len(t.events) - 1
+	}
+	if t.scroll < 0 {
+		t.scroll = 0
+	}
+}
+
+func (t *tui) toggleSelected() {
+	states := t.engine.ProgramStates()
+
+	t.mu.Lock()
+	index := t.selected
+	t.mu.Unlock()
+	if index < 0 || index >= len(states) {
+		return
+	}
+
+	state := states[index]
+	if err := t.engine.SetProgramEnabled(state.Account, state.Program, state.Disabled); err != nil {
+		t.addEvent(fmt.Sprintf("❌ %v", err))
+		return
+	}
+	if state.Disabled {
+		t.addEvent(fmt.Sprintf(i18n.T("cli.tui.resumed"), state.Account, state.Program))
+	} else {
+		t.addEvent(fmt.Sprintf(i18n.T("cli.tui.disabled"), state.Account, state.Program))
+	}
+}
+
+func (t *tui) setRunning(running bool) {
+	t.mu.Lock()
+	t.running = running
+	t.mu.Unlock()
+	t.requestRedraw()
+}
+
+func (t *tui) isRunning() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.running
+}
+
+func (t *tui) requestRedraw() {
+	select {
+	case t.redraw <- struct{}{}:
+	default:
+	}
+}
+
+// addEvent appends a timestamped line to the event pane
+func (t *tui) addEvent(message string) {
+	t.addEventAt(time.Now(), message)
+}
+
+func (t *tui) addEventAt(at time.Time, message string) {
+	t.mu.Lock()
+	for _, line := range strings.Split(strings.TrimRight(message, "\n"), "\n") {
+		t.events = append(t.events, at.Format("15:04:05")+" "+line)
+		if t.scroll > 0 {
+			// 스크롤 중이면 보고 있던 위치 유지
+			t.scroll++
+		}
+	}
+	if len(t.events) > maxEventLines {
+		t.events = t.events[len(t.events)-maxEventLines:]
+	}
+	t.mu.Unlock()
+	t.requestRedraw()
+}
+
+// Write receives standard log output and shows it in the event pane
+func (t *tui) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	text := t.partial + string(p)
+	lastNewline := strings.LastIndex(text, "\n")
+	if lastNewline < 0 {
+		t.partial = text
+		t.mu.Unlock()
+		return len(p), nil
+	}
+	t.partial = text[lastNewline+1:]
+	t.mu.Unlock()
+
+	for _, line := range strings.Split(text[:lastNewline], "\n") {
+		if strings.TrimSpace(line) != "" {
+			t.addEvent(line)
+		}
+	}
+	return len(p), nil
+}
+
+// handleEvent turns engine events into event pane lines
+func (t *tui) handleEvent(event monitor.Event) {
+	prefix := ""
+	if event.Account != "" && t.multiAccount {
+		prefix = fmt.Sprintf("[%s] ", event.Account)
+	}
+
+	switch event.Type {
+	case monitor.EventCheck:
+		availableCount := 0
+		for _, program := range event.Result.Programs {
+			if event.Result.Availability[program] {
+				availableCount++
+			}
+		}
+		t.addEventAt(event.Time, fmt.Sprintf(i18n.T("cli.tui.result"), prefix, availableCount, len(event.Result.Programs)-availableCount))
+	default:
+		t.addEventAt(event.Time, prefix+event.Message)
+	}
+}
+
+// eventRows returns how many event lines fit on the screen
+func (t *tui) eventRows() int {
+	_, height := terminalSize()
+	rows := height - len(t.engine.ProgramStates()) - 6
+	if rows < 3 {
+		rows = 3
+	}
+	return rows
+}
+
+func terminalSize() (int, int) {
+	w, h, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || w <= 0 || h <= 0 {
+		return 80, 24
+	}
+	return w, h
+}
+
+// draw repaints the whole screen
+func (t *tui) draw() {
+	screenWidth, screenHeight := terminalSize()
+	states := t.engine.ProgramStates()
+	multiAccount := t.multiAccount
+
+	t.mu.Lock()
+	if t.selected >= len(states) {
+		t.selected = len(states) - 1
+	}
+	selected := t.selected
+	running := t.running
+	scroll := t.scroll
+	events := t.events
+	t.mu.Unlock()
+
+	var b strings.Builder
+	line := func(text string) {
+		b.WriteString(text)
+		b.WriteString(ansiReset + ansiClearLine + "\r\n")
+	}
+
+	// 상단 상태 줄
+	status := i18n.T("cli.tui.logging_in")
+	switch {
+	case !running:
+	case t.engine.Paused():
+		status = ansiYellow + i18n.T("cli.tui.paused") + ansiReset
+	default:
+		status = ansiGreen + i18n.T("cli.tui.running") + ansiReset
+		if next := t.engine.NextCheck(); !next.IsZero() {
+			status += fmt.Sprintf(i18n.T("cli.tui.next_check"), next.Format("15:04:05"), formatDuration(time.Until(next)))
+		}
+	}
+	line(ansiBold + i18n.T("cli.tui.title") + ansiReset + "  " + status)
+	line("")
+
+	// 프로그램 표
+	programWidth := screenWidth - 36
+	if multiAccount {
+		programWidth -= 14
+	}
+	if programWidth < 20 {
+		programWidth = 20
+	}
+	header := "   "
+	if multiAccount {
+		header += padRight(i18n.T("cli.col.account"), 14)
+	}
+	header += padRight(i18n.T("cli.col.program"), programWidth) + padRight(i18n.T("cli.col.status"), 16) + i18n.T("cli.col.changed")
+	line(ansiDim + header)
+
+	for i, state := range states {
+		name := i18n.ProgramLabel(state.Program)
+
+		stateText, color := i18n.T("cli.state.unknown"), ansiDim
+		switch {
+		case state.Disabled:
+			stateText, color = i18n.T("cli.tui.excluded"), ansiDim
+		case !state.Known:
+		case state.Available:
+			stateText, color = i18n.T("cli.state.available"), ansiGreen
+		default:
+			stateText, color = i18n.T("cli.state.unavailable"), ""
+		}
+
+		changed := "-"
+		if !state.LastChange.IsZero() {
+			changed = formatDuration(time.Since(state.LastChange))
+		}
+
+		row := "   "
+		if i == selected {
+			row = " > "
+		}
+		if multiAccount {
+			row += padRight(truncate(state.Account, 13), 14)
+		}
+		row += padRight(truncate(name, programWidth-1), programWidth)
+		row += color + padRight(stateText, 16) + ansiReset
+		if i == selected {
+			row = ansiReverse + row + ansiReverse
+		}
+		line(row + changed)
+	}
+	line("")
+
+	// 이벤트 창
+	title := i18n.T("cli.tui.events")
+	if scroll > 0 {
+		title = fmt.Sprintf(i18n.T("cli.tui.events_scrolled"), scroll)
+	}
+	line(ansiDim + "──" + title + strings.Repeat("─", max(0, screenWidth-displayWidth(title)-2)))
+
+	rows := screenHeight - len(states) - 6
+	if rows < 3 {
+		rows = 3
+	}
+	end := max(0, len(events)-scroll)
+	start := max(0, end-rows)
+	for i := start; i < end; i++ {
+		text := events[i]
+		if strings.Contains(text, "❌") {
+			text = ansiRed + text
+		}
+		line(truncate(text, screenWidth-1))
+	}
+	for i := end - start; i < rows; i++ {
+		line("")
+	}
+
+	// 도움말 (마지막 줄은 줄바꿈 없이)
+	b.WriteString(ansiDim + i18n.T("cli.tui.help") + ansiReset + ansiClearBelow)
+
+	os.Stdout.WriteString(ansiHome + b.String())
+}
+
+// formatDuration formats a duration in short Korean units
+func formatDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf(i18n.T("cli.duration.seconds"), int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf(i18n.T("cli.duration.minutes"), int(d.Minutes()))
+	default:
+		return fmt.Sprintf(i18n.T("cli.duration.hours_minutes"), int(d.Hours()), int(d.Minutes())%60)
+	}
+}
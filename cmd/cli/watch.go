@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/scraper"
+	"bmw-driving-center-alter/internal/watch"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// watchJSON is one watched session in "watch list -output json"
+type watchJSON struct {
+	ID string `json:"id"`
+	watch.Watch
+}
+
+// runWatch handles "watch add", "watch list" and "watch remove".
+// 감시 목록은 파일에 저장되므로 실행 중인 모니터도 다음 확인부터 바로 반영합니다.
+func runWatch(args []string) int {
+	if len(args) == 0 {
+		printWatchUsage()
+		return 2
+	}
+
+	switch args[0] {
+	case "add":
+		return runWatchAdd(args[1:])
+	case "list":
+		return runWatchList(args[1:])
+	case "remove":
+		return runWatchRemove(args[1:])
+	default:
+		printWatchUsage()
+		return 2
+	}
+}
+
+func printWatchUsage() {
+	fmt.Println(i18n.T("cli.usage.header"))
+	fmt.Println(i18n.T("cli.watch.usage_add"))
+	fmt.Println(i18n.T("cli.watch.usage_list"))
+	fmt.Println(i18n.T("cli.watch.usage_remove"))
+	fmt.Println(i18n.T("cli.watch.usage_note"))
+}
+
+func runWatchAdd(args []string) int {
+	fs, cfgPath, output := newCommand("watch add", i18n.T("cli.usage.watch_add"))
+	accountName := fs.String("account", "", i18n.T("cli.flag.watch_account"))
+	program := fs.String("program", "", i18n.T("cli.flag.watch_program"))
+	date := fs.String("date", "", i18n.T("cli.flag.watch_date"))
+	clock := fs.String("time", "", i18n.T("cli.flag.watch_time"))
+	fs.Parse(args)
+
+	if err := checkOutputFormat(*output); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+	if *program == "" || *date == "" {
+		fs.Usage()
+		return 2
+	}
+	start, allDay, err := watch.ParseStart(*date, *clock)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+
+	cfg, err := loadCommandConfig(*cfgPath, *accountName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+	name, err := findWatchedProgram(cfg, *program)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+
+	w := watch.Watch{Account: *accountName, Program: name, Start: start, AllDay: allDay, By: "cli", Created: time.Now()}
+	if w.Expired(time.Now()) {
+		fmt.Fprintf(os.Stderr, i18n.T("cli.watch.past"), w)
+		return 1
+	}
+	if err := watch.NewStore("").Add(w); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+
+	if *output == outputJSON {
+		printJSON(watchJSON{ID: w.ID(), Watch: w})
+		return 0
+	}
+	fmt.Printf(i18n.T("cli.watch.added"), w, w.ID())
+	return 0
+}
+
+func runWatchList(args []string) int {
+	fs, _, output := newCommand("watch list", i18n.T("cli.usage.watch_list"))
+	fs.Parse(args)
+
+	if err := checkOutputFormat(*output); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+	watches, err := watch.NewStore("").List()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+
+	switch *output {
+	case outputJSON:
+		out := []watchJSON{}
+		for _, w := range watches {
+			out = append(out, watchJSON{ID: w.ID(), Watch: w})
+		}
+		printJSON(out)
+	case outputTable:
+		t := newTable("ID", i18n.T("cli.col.program"), i18n.T("cli.col.session"), i18n.T("cli.col.account"), i18n.T("cli.col.added"))
+		for _, w := range watches {
+			t.addRow(w.ID(), programLabel(w.Program), formatWatchStart(w), orAll(w.Account), w.Created.Format("01-02 15:04"))
+		}
+		t.print()
+	default:
+		if len(watches) == 0 {
+			fmt.Println(i18n.T("cli.watch.empty"))
+			return 0
+		}
+		for _, w := range watches {
+			line := fmt.Sprintf("%s  %s  %s", w.ID(), programLabel(w.Program), formatWatchStart(w))
+			if w.Account != "" {
+				line += fmt.Sprintf("  [%s]", w.Account)
+			}
+			fmt.Println(line)
+		}
+	}
+	return 0
+}
+
+func runWatchRemove(args []string) int {
+	fs, _, _ := newCommand("watch remove", "watch remove <ID>")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	removed, err := watch.NewStore("").Remove(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+	if !removed {
+		fmt.Fprintf(os.Stderr, i18n.T("cli.watch.not_found"), fs.Arg(0))
+		return 1
+	}
+	fmt.Printf(i18n.T("cli.watch.removed"), fs.Arg(0))
+	return 0
+}
+
+// findWatchedProgram returns the configured name of a program one of the accounts watches
+func findWatchedProgram(cfg *config.Config, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	for _, account := range cfg.GetAccounts() {
+		for _, program := range account.Programs {
+			if scraper.NamesProgram(name, program.Name) {
+				return program.Name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf(i18n.T("cli.watch.not_watched_program"), name)
+}
+
+func formatWatchStart(w watch.Watch) string {
+	if w.AllDay {
+		return w.Start.Format(i18n.T("cli.watch.all_day_format"))
+	}
+	return i18n.FormatTime(w.Start, "2006-01-02 (Mon) 15:04")
+}
+
+func orAll(account string) string {
+	if account == "" {
+		return i18n.T("cli.watch.all_accounts")
+	}
+	return account
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/models"
+	"bmw-driving-center-alter/internal/monitor"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// bookingEntry is an open session (or an open program without parsed sessions) with its booking page
+type bookingEntry struct {
+	Account string
+	Label   string
+	URL     string
+}
+
+// buildBookingList lists what can be booked now, each with an "open in browser" button
+func (g *GUI) buildBookingList() fyne.CanvasObject {
+	g.bookingList = widget.NewList(
+		func() int { return len(g.bookingEntries) },
+		func() fyne.CanvasObject {
+			return container.NewBorder(nil, nil, nil, widget.NewButton(i18n.T("gui.booking.open_browser"), nil), widget.NewLabel(""))
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			entry := g.bookingEntries[id]
+			row := item.(*fyne.Container)
+			row.Objects[0].(*widget.Label).SetText(entry.Label)
+			row.Objects[1].(*widget.Button).OnTapped = func() { g.openBooking(entry) }
+		},
+	)
+	return g.bookingList
+}
+
+// refreshBookings reloads the open sessions and their booking links from the engine
+func (g *GUI) refreshBookings() {
+	engine := g.engine
+	if engine == nil {
+		return
+	}
+
+	multiAccount := len(g.runningConfig().Accounts) > 0
+	var entries []bookingEntry
+	for _, state := range engine.ProgramStates() {
+		if !state.Available || state.Disabled {
+			continue
+		}
+		link := state.BookingURL
+		if link == "" {
+			link = models.ReservationPageURL
+		}
+		prefix := ""
+		if multiAccount {
+			prefix = fmt.Sprintf("[%s] ", state.Account)
+		}
+
+		found := false
+		for _, session := range state.Sessions {
+			if !session.Open {
+				continue
+			}
+			found = true
+			label := fmt.Sprintf("%s%s %s", prefix, programDisplayName(session.Program), i18n.FormatTime(session.Start, "01-02 (Mon) 15:04"))
+			if seats := session.SeatStatus(); seats != "" {
+				label += " · " + seats
+			}
+			sessionURL := session.URL
+			if sessionURL == "" || sessionURL == models.ReservationPageURL {
+				sessionURL = link
+			}
+			entries = append(entries, bookingEntry{Account: state.Account, Label: label, URL: sessionURL})
+		}
+		if !found {
+			entries = append(entries, bookingEntry{Account: state.Account, Label: prefix + programDisplayName(state.Program), URL: link})
+		}
+	}
+
+	fyne.Do(func() {
+		g.bookingEntries = entries
+		if g.bookingList != nil {
+			g.bookingList.Refresh()
+		}
+	})
+}
+
+// openBooking shows the booking page in the monitored (logged-in) browser window.
+// 백그라운드 모드라 창이 없으면 기본 브라우저로 엽니다 (로그인이 필요할 수 있음).
+func (g *GUI) openBooking(entry bookingEntry) {
+	go func() {
+		engine := g.engine
+		err := errors.New(i18n.T("gui.booking.not_monitoring"))
+		if engine != nil {
+			err = engine.OpenInBrowser(entry.Account, entry.URL)
+		}
+		if err == nil {
+			return
+		}
+
+		if engine == nil || errors.Is(err, monitor.ErrBrowserHidden) {
+			guiLog.Infof(i18n.T("gui.booking.opening_default_browser"), err)
+			if pageURL, parseErr := url.Parse(entry.URL); parseErr == nil {
+				fyne.Do(func() { g.app.OpenURL(pageURL) })
+			}
+			return
+		}
+		fyne.Do(func() { dialog.ShowError(err, g.window) })
+	}()
+}
@@ -1,12 +1,27 @@
 package main
 
 import (
-	"bmw-driving-center-alter/internal/browser"
+	"bmw-driving-center-alter/internal/analytics"
+	"bmw-driving-center-alter/internal/claims"
 	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/control"
+	"bmw-driving-center-alter/internal/history"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/logging"
 	"bmw-driving-center-alter/internal/models"
+	"bmw-driving-center-alter/internal/monitor"
+	"bmw-driving-center-alter/internal/mute"
 	"bmw-driving-center-alter/internal/notifier"
+	"bmw-driving-center-alter/internal/pidfile"
+	"bmw-driving-center-alter/internal/server"
+	"bmw-driving-center-alter/internal/telegram"
+	"bmw-driving-center-alter/internal/watch"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"log/slog"
+	"net/url"
 	"strings"
 	"time"
 
@@ -19,11 +34,87 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
+// logRingSize is the number of log records kept for the log views
+const logRingSize = 1000
+
+// logLevels are the choices of the log tab level filter, by message id
+var logLevels = []struct {
+	id    string
+	level slog.Level
+}{
+	{"gui.level.debug", slog.LevelDebug},
+	{"gui.level.info", slog.LevelInfo},
+	{"gui.level.warn", slog.LevelWarn},
+	{"gui.level.error", slog.LevelError},
+}
+
+// logLevelOptions returns the level filter choices in the current language
+func logLevelOptions() []string {
+	options := make([]string, len(logLevels))
+	for i, level := range logLevels {
+		options[i] = i18n.T(level.id)
+	}
+	return options
+}
+
+// logLevelValue returns the level of a filter choice
+func logLevelValue(option string) slog.Level {
+	for _, level := range logLevels {
+		if i18n.T(level.id) == option {
+			return level.level
+		}
+	}
+	return slog.LevelInfo
+}
+
+// languages are the choices of the language setting, by message id
+var languages = []struct {
+	id    string
+	value string
+}{
+	{"gui.language.auto", i18n.Auto},
+	{"gui.language.ko", i18n.Korean},
+	{"gui.language.en", i18n.English},
+}
+
+// languageOptions returns the language choices in the current language
+func languageOptions() []string {
+	options := make([]string, len(languages))
+	for i, language := range languages {
+		options[i] = i18n.T(language.id)
+	}
+	return options
+}
+
+// languageValue returns the config value of a language choice
+func languageValue(option string) string {
+	for _, language := range languages {
+		if i18n.T(language.id) == option {
+			return language.value
+		}
+	}
+	return i18n.Auto
+}
+
+// languageOption returns the choice for a config value ("" is auto)
+func languageOption(value string) string {
+	for _, language := range languages {
+		if language.value == strings.ToLower(value) {
+			return i18n.T(language.id)
+		}
+	}
+	return i18n.T(languages[0].id)
+}
+
+var guiLog = logging.Component("gui")
+
 type GUI struct {
 	app            fyne.App
 	window         fyne.Window
-	config         *config.Config
+	config         *config.Config // 설정 화면에서 고치고 저장하는 설정 파일의 값
 	configPath     string
+	profile        string // -profile: 모니터링할 때 설정 파일 위에 덮어쓸 프로필
+	running        *config.Config // 모니터링 중 실제로 적용된 설정 (프로필, 환경 변수 포함)
 	
 	// UI components
 	usernameEntry  *widget.Entry
@@ -44,13 +135,31 @@ type GUI struct {
 	selectedProgramsLabel  *widget.Label
 	programs              []models.Program
 	statusLabel           *widget.Label
-	logOutput             *widget.Entry
-	activityLog           *widget.Entry
+	logRing               *logging.Ring
+	logList               *widget.List
+	logEntries            []logging.Entry // 로그 탭에 표시 중인 (필터된) 기록
+	logLevel              slog.Level
+	logSearch             string
+	activityLog           *widget.List
+	activityEntries       []logging.Entry // 모니터링 탭에 표시 중인 기록
+	alertList             *widget.List
+	alertEntries          []monitor.AlertStatus // 최근 알림과 예약 담당
+	bookingList           *widget.List
+	bookingEntries        []bookingEntry // 예약 가능 회차와 예약 링크
 	headlessCheck         *widget.Check
+	languageSelect        *widget.Select
+	statsSelect           *widget.Select
+	statsSummary          *widget.Label
+	weekdayChart          *fyne.Container
+	hourChart             *fyne.Container
+	statsData             []analytics.ProgramStats
+	watchProgramSelect    *widget.Select
+	watchList             *widget.List
+	watchEntries          []watch.Watch // 감시 중인 회차
 	
 	isMonitoring   binding.Bool
 	stopChan       chan bool
-	browserClient  *browser.BrowserClient
+	engine         *monitor.Engine
 }
 
 func main() {
@@ -58,29 +167,51 @@ func main() {
 		programs: []models.Program{},
 	}
 	gui.isMonitoring = binding.NewBool()
+	profile := flag.String("profile", "", i18n.T("cli.flag.profile"))
+	flag.Parse()
 	
 	// 설정 파일 경로 자동 탐색
 	configPath := config.GetConfigPath()
-	log.Printf("설정 파일 경로: %s", configPath)
+	log.Printf(i18n.T("gui.config_path"), configPath)
 	
 	// Load config
 	cfg, err := config.Load(configPath)
 	if err != nil {
-		log.Printf("설정 파일 로드 실패, 기본 설정 생성: %v", err)
+		log.Printf(i18n.T("gui.config_load_failed"), err)
 		// 기본 설정 생성
 		cfg = createDefaultConfig()
 		// 설정 파일 저장
 		if err := saveDefaultConfig(configPath, cfg); err != nil {
-			log.Printf("기본 설정 파일 저장 실패: %v", err)
+			log.Printf(i18n.T("gui.config_save_default_failed"), err)
 		}
 	}
 	gui.config = cfg
 	gui.configPath = configPath
+	gui.profile = *profile
+	
+	// 언어와 로그 설정은 프로필과 환경 변수까지 적용한 값으로
+	layered, err := gui.effectiveConfig()
+	if err != nil {
+		log.Printf("⚠️ %v", err)
+		layered = cfg
+	}
+	if err := i18n.SetLanguage(layered.Language); err != nil {
+		log.Printf("⚠️ %v", err)
+	}
+	
+	// 로그는 콘솔, 로그 파일, 화면용 링 버퍼에 함께 기록
+	gui.logRing = logging.NewRing(logRingSize)
+	gui.logLevel = slog.LevelInfo
+	if err := logging.Setup(layered.Logging, gui.logRing); err != nil {
+		logging.Setup(config.LoggingConfig{}, gui.logRing)
+		guiLog.Warnf(i18n.T("gui.logging_config_error"), err)
+	}
+	defer logging.Close()
 	
 	// Create app
 	gui.app = app.New()
 	gui.app.Settings().SetTheme(&myTheme{})
-	gui.window = gui.app.NewWindow("BMW 드라이빙 센터 모니터")
+	gui.window = gui.app.NewWindow(i18n.T("gui.window_title"))
 	gui.window.Resize(fyne.NewSize(900, 700))
 	
 	// Build UI
@@ -95,7 +226,7 @@ func main() {
 		// 모니터링 중이면 중단
 		isMonitoring, _ := gui.isMonitoring.Get()
 		if isMonitoring {
-			log.Println("종료 시 모니터링 중단...")
+			log.Println(i18n.T("gui.stopping_on_exit"))
 			gui.stopMonitoring()
 			time.Sleep(2 * time.Second) // 브라우저 종료 대기
 		}
@@ -107,10 +238,12 @@ func main() {
 func (g *GUI) buildUI() fyne.CanvasObject {
 	// Create tabs
 	tabs := container.NewAppTabs(
-		container.NewTabItem("모니터링", g.buildMonitorTab()),
-		container.NewTabItem("설정", g.buildSettingsTab()),
-		container.NewTabItem("프로그램 목록", g.buildProgramsTab()),
-		container.NewTabItem("로그", g.buildLogTab()),
+		container.NewTabItem(i18n.T("gui.tab.monitoring"), g.buildMonitorTab()),
+		container.NewTabItem(i18n.T("gui.tab.settings"), g.buildSettingsTab()),
+		container.NewTabItem(i18n.T("gui.tab.programs"), g.buildProgramsTab()),
+		container.NewTabItem(i18n.T("gui.tab.watch"), g.buildWatchTab()),
+		container.NewTabItem(i18n.T("gui.tab.stats"), g.buildStatsTab()),
+		container.NewTabItem(i18n.T("gui.tab.log"), g.buildLogTab()),
 	)
 	
 	return tabs
@@ -118,21 +251,21 @@ func (g *GUI) buildUI() fyne.CanvasObject {
 
 func (g *GUI) buildMonitorTab() fyne.CanvasObject {
 	// Status display
-	g.statusLabel = widget.NewLabel("대기 중 (Idle)")
+	g.statusLabel = widget.NewLabel(i18n.T("gui.status.idle"))
 	g.statusLabel.TextStyle.Bold = true
 	
 	// Control buttons
-	startBtn := widget.NewButton("모니터링 시작", func() {
+	startBtn := widget.NewButton(i18n.T("gui.button.start"), func() {
 		g.startMonitoring()
 	})
 	startBtn.Importance = widget.HighImportance
 	
-	stopBtn := widget.NewButton("모니터링 중지", func() {
+	stopBtn := widget.NewButton(i18n.T("gui.button.stop"), func() {
 		g.stopMonitoring()
 	})
 	stopBtn.Importance = widget.DangerImportance
 	
-	testBtn := widget.NewButton("이메일 테스트", func() {
+	testBtn := widget.NewButton(i18n.T("gui.button.test_email"), func() {
 		g.testEmail()
 	})
 	
@@ -149,7 +282,7 @@ func (g *GUI) buildMonitorTab() fyne.CanvasObject {
 	}))
 	
 	// Quick status view
-	statusCard := widget.NewCard("상태", "", 
+	statusCard := widget.NewCard(i18n.T("gui.card.status"), "", 
 		container.NewVBox(
 			g.statusLabel,
 			widget.NewSeparator(),
@@ -161,40 +294,103 @@ func (g *GUI) buildMonitorTab() fyne.CanvasObject {
 		),
 	)
 	
-	// Recent activity log
-	g.activityLog = widget.NewMultiLineEntry()
-	g.activityLog.SetPlaceHolder("모니터링 활동이 여기에 표시됩니다...")
-	// Make it read-only
-	g.activityLog.OnChanged = func(s string) {
-		// Prevent user editing
-	}
+	// Recent activity log (GUI 메시지만, 최근 기록은 링 버퍼에서)
+	g.activityLog = widget.NewList(
+		func() int { return len(g.activityEntries) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			setLogLabel(item.(*widget.Label), g.activityEntries[id], false)
+		},
+	)
+	
+	activityCard := widget.NewCard(i18n.T("gui.card.recent"), "", g.activityLog)
 	
-	activityCard := widget.NewCard("최근 활동", "", 
-		container.NewScroll(g.activityLog),
+	// 보낸 알림의 확인/예약 담당 현황 (항목을 누르면 알림 페이지 열기)
+	g.alertList = widget.NewList(
+		func() int { return len(g.alertEntries) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			item.(*widget.Label).SetText(formatAlert(g.alertEntries[id]))
+		},
 	)
+	g.alertList.OnSelected = func(id widget.ListItemID) {
+		g.alertList.Unselect(id)
+		base := g.runningConfig().Server.BaseURL()
+		if base == "" || id >= len(g.alertEntries) {
+			return
+		}
+		if alertURL, err := url.Parse(notifier.AlertURL(base, g.alertEntries[id].ID)); err == nil {
+			g.app.OpenURL(alertURL)
+		}
+	}
+	alertCard := widget.NewCard(i18n.T("gui.card.alerts"), "", g.alertList)
+	
+	// 지금 예약 가능한 회차 - 로그인된 모니터 브라우저에서 바로 예약 페이지 열기
+	bookingCard := widget.NewCard(i18n.T("gui.card.sessions"), "", g.buildBookingList())
+	
+	split := container.NewVSplit(activityCard, container.NewHSplit(bookingCard, alertCard))
+	split.Offset = 0.6
 	
 	return container.NewBorder(
 		statusCard,
 		nil,
 		nil,
 		nil,
-		activityCard,
+		split,
 	)
 }
 
+// refreshAlerts reloads the recent alerts and claims from the engine
+func (g *GUI) refreshAlerts() {
+	engine := g.engine
+	if engine == nil {
+		return
+	}
+	alerts := engine.Alerts()
+	fyne.Do(func() {
+		g.alertEntries = alerts
+		if g.alertList != nil {
+			g.alertList.Refresh()
+		}
+	})
+}
+
+// formatAlert describes an alert and who acknowledged or claimed it
+func formatAlert(alert monitor.AlertStatus) string {
+	text := alert.SentAt.Format("01-02 15:04") + " " + strings.Join(alert.Programs, ", ")
+	
+	var claimed []string
+	for _, session := range alert.Sessions {
+		if claim, ok := alert.Claims[session.ID()]; ok {
+			claimed = append(claimed, fmt.Sprintf("%s (%s)", claim.By, session.Start.Format("01/02 15:04")))
+		}
+	}
+	switch {
+	case len(claimed) > 0:
+		text += i18n.T("gui.alert.claimed") + strings.Join(claimed, ", ")
+	case alert.Acknowledged:
+		text += i18n.T("gui.alert.acked") + alert.AckedBy
+	case alert.Escalations > 0:
+		text += fmt.Sprintf(i18n.T("gui.alert.escalated"), alert.Escalations)
+	default:
+		text += i18n.T("gui.alert.pending")
+	}
+	return text
+}
+
 func (g *GUI) buildSettingsTab() fyne.CanvasObject {
 	// Login settings
 	g.usernameEntry = widget.NewEntry()
 	g.usernameEntry.SetPlaceHolder("BMW ID")
 	
 	g.passwordEntry = widget.NewPasswordEntry()
-	g.passwordEntry.SetPlaceHolder("비밀번호")
+	g.passwordEntry.SetPlaceHolder(i18n.T("gui.placeholder.password"))
 	
-	loginCard := widget.NewCard("로그인 정보", "", 
+	loginCard := widget.NewCard(i18n.T("gui.card.login"), "", 
 		container.New(layout.NewFormLayout(),
-			widget.NewLabel("사용자명:"),
+			widget.NewLabel(i18n.T("gui.label.username")),
 			g.usernameEntry,
-			widget.NewLabel("비밀번호:"),
+			widget.NewLabel(i18n.T("gui.label.password")),
 			g.passwordEntry,
 		),
 	)
@@ -203,29 +399,35 @@ func (g *GUI) buildSettingsTab() fyne.CanvasObject {
 	g.intervalEntry = widget.NewEntry()
 	g.intervalEntry.SetPlaceHolder("300")
 	
-	g.headlessCheck = widget.NewCheck("백그라운드 모드 (브라우저 숨김)", nil)
+	g.headlessCheck = widget.NewCheck(i18n.T("gui.check.headless"), nil)
 	g.headlessCheck.SetChecked(true) // Default to headless
 	
 	// Captcha solver settings
 	g.captchaServiceSelect = widget.NewSelect(
-		[]string{"수동 해결", "SolveCaptcha", "2captcha"},
+		[]string{i18n.T("gui.captcha.manual"), "SolveCaptcha", "2captcha"},
 		nil,
 	)
-	g.captchaServiceSelect.SetSelected("수동 해결")
+	g.captchaServiceSelect.SetSelected(i18n.T("gui.captcha.manual"))
 	
 	g.captchaAPIKeyEntry = widget.NewEntry()
-	g.captchaAPIKeyEntry.SetPlaceHolder("API 키 입력 (선택사항)")
+	g.captchaAPIKeyEntry.SetPlaceHolder(i18n.T("gui.placeholder.api_key"))
+	
+	// 언어는 다시 시작하면 적용
+	g.languageSelect = widget.NewSelect(languageOptions(), nil)
+	g.languageSelect.SetSelected(languageOption(""))
 	
-	monitorCard := widget.NewCard("모니터링 설정", "",
+	monitorCard := widget.NewCard(i18n.T("gui.card.monitoring"), "",
 		container.New(layout.NewFormLayout(),
-			widget.NewLabel("확인 간격(초):"),
+			widget.NewLabel(i18n.T("gui.label.interval")),
 			g.intervalEntry,
-			widget.NewLabel("브라우저 모드:"),
+			widget.NewLabel(i18n.T("gui.label.browser_mode")),
 			g.headlessCheck,
-			widget.NewLabel("hCaptcha 해결:"),
+			widget.NewLabel(i18n.T("gui.label.captcha")),
 			g.captchaServiceSelect,
-			widget.NewLabel("Captcha API 키:"),
+			widget.NewLabel(i18n.T("gui.label.captcha_key")),
 			g.captchaAPIKeyEntry,
+			widget.NewLabel(i18n.T("gui.label.language")),
+			g.languageSelect,
 		),
 	)
 	
@@ -248,25 +450,25 @@ func (g *GUI) buildSettingsTab() fyne.CanvasObject {
 	g.smtpPassEntry = widget.NewPasswordEntry()
 	g.smtpPassEntry.SetPlaceHolder("App Password")
 	
-	emailCard := widget.NewCard("이메일 설정", "",
+	emailCard := widget.NewCard(i18n.T("gui.card.email"), "",
 		container.New(layout.NewFormLayout(),
-			widget.NewLabel("보내는 사람:"),
+			widget.NewLabel(i18n.T("gui.label.from")),
 			g.emailFromEntry,
-			widget.NewLabel("받는 사람:"),
+			widget.NewLabel(i18n.T("gui.label.to")),
 			g.emailToEntry,
-			widget.NewLabel("SMTP 서버:"),
+			widget.NewLabel(i18n.T("gui.label.smtp_host")),
 			g.smtpHostEntry,
-			widget.NewLabel("SMTP 포트:"),
+			widget.NewLabel(i18n.T("gui.label.smtp_port")),
 			g.smtpPortEntry,
-			widget.NewLabel("SMTP 사용자:"),
+			widget.NewLabel(i18n.T("gui.label.smtp_user")),
 			g.smtpUserEntry,
-			widget.NewLabel("SMTP 비밀번호:"),
+			widget.NewLabel(i18n.T("gui.label.smtp_password")),
 			g.smtpPassEntry,
 		),
 	)
 	
 	// Save button
-	saveBtn := widget.NewButton("설정 저장", func() {
+	saveBtn := widget.NewButton(i18n.T("gui.button.save"), func() {
 		g.saveConfig()
 	})
 	saveBtn.Importance = widget.HighImportance
@@ -294,10 +496,10 @@ func (g *GUI) buildProgramsTab() fyne.CanvasObject {
 		
 		// Add checkboxes for each program in category
 		for _, programName := range category.Programs {
-			// Get Korean name if available
+			// Show the localized name first if there is one
 			displayName := programName
-			if koreanName, exists := models.ProgramNameMap[programName]; exists {
-				displayName = fmt.Sprintf("%s (%s)", koreanName, programName)
+			if localName := i18n.ProgramName(programName); localName != programName {
+				displayName = fmt.Sprintf("%s (%s)", localName, programName)
 			}
 			
 			// Create checkbox for this program
@@ -327,7 +529,7 @@ func (g *GUI) buildProgramsTab() fyne.CanvasObject {
 	g.programCheckboxes = programCheckboxes
 	
 	// Selected programs summary
-	g.selectedProgramsLabel = widget.NewLabel("선택된 프로그램: 0개")
+	g.selectedProgramsLabel = widget.NewLabel(i18n.T("gui.programs.selected_zero"))
 	updateSelectedCount := func() {
 		count := 0
 		for _, cb := range programCheckboxes {
@@ -335,12 +537,12 @@ func (g *GUI) buildProgramsTab() fyne.CanvasObject {
 				count++
 			}
 		}
-		g.selectedProgramsLabel.SetText(fmt.Sprintf("선택된 프로그램: %d개", count))
+		g.selectedProgramsLabel.SetText(fmt.Sprintf(i18n.T("gui.programs.selected"), count))
 	}
 	updateSelectedCount()
 	
 	// Buttons for select all / deselect all
-	selectAllBtn := widget.NewButton("모두 선택", func() {
+	selectAllBtn := widget.NewButton(i18n.T("gui.button.select_all"), func() {
 		for _, cb := range programCheckboxes {
 			cb.SetChecked(true)
 		}
@@ -348,7 +550,7 @@ func (g *GUI) buildProgramsTab() fyne.CanvasObject {
 		g.updateSelectedPrograms()
 	})
 	
-	deselectAllBtn := widget.NewButton("모두 해제", func() {
+	deselectAllBtn := widget.NewButton(i18n.T("gui.button.clear_all"), func() {
 		for _, cb := range programCheckboxes {
 			cb.SetChecked(false)
 		}
@@ -368,7 +570,7 @@ func (g *GUI) buildProgramsTab() fyne.CanvasObject {
 	scrollablePrograms.SetMinSize(fyne.NewSize(600, 400))
 	
 	return container.NewBorder(
-		widget.NewCard("프로그램 선택", "모니터링할 프로그램을 선택하세요", controlButtons),
+		widget.NewCard(i18n.T("gui.card.programs"), i18n.T("gui.card.programs_hint"), controlButtons),
 		nil,
 		nil,
 		nil,
@@ -377,31 +579,113 @@ func (g *GUI) buildProgramsTab() fyne.CanvasObject {
 }
 
 func (g *GUI) buildLogTab() fyne.CanvasObject {
-	g.logOutput = widget.NewMultiLineEntry()
-	g.logOutput.SetPlaceHolder("로그가 여기에 표시됩니다...")
-	// Make read-only without disabling (to keep text color visible)
-	g.logOutput.OnChanged = func(s string) {
-		// Allow the change but prevent user editing
-		if s != g.logOutput.Text {
-			g.logOutput.OnChanged = nil
-			g.logOutput.SetText(s)
-			g.logOutput.OnChanged = func(string) {}
-		}
+	g.logList = widget.NewList(
+		func() int { return len(g.logEntries) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			setLogLabel(item.(*widget.Label), g.logEntries[id], true)
+		},
+	)
+	
+	levelSelect := widget.NewSelect(logLevelOptions(), func(selected string) {
+		g.logLevel = logLevelValue(selected)
+		g.refreshLogViews()
+	})
+	levelSelect.SetSelected(i18n.T("gui.level.info"))
+	
+	searchEntry := widget.NewEntry()
+	searchEntry.SetPlaceHolder(i18n.T("gui.placeholder.search"))
+	searchEntry.OnChanged = func(text string) {
+		g.logSearch = strings.ToLower(strings.TrimSpace(text))
+		g.refreshLogViews()
 	}
 	
-	clearBtn := widget.NewButton("로그 지우기", func() {
-		g.logOutput.SetText("")
+	clearBtn := widget.NewButton(i18n.T("gui.button.clear_log"), func() {
+		g.logRing.Clear()
+		g.refreshLogViews()
 	})
 	
-	return container.NewBorder(
-		nil,
+	filterBar := container.NewBorder(nil, nil,
+		container.NewHBox(widget.NewLabel(i18n.T("gui.label.level")), levelSelect),
 		clearBtn,
+		searchEntry,
+	)
+	
+	// 새 기록이 들어오면 메인 스레드에서 화면 갱신
+	g.logRing.OnAppend(func(logging.Entry) {
+		fyne.Do(g.refreshLogViews)
+	})
+	
+	footer := widget.NewLabel(fmt.Sprintf(i18n.T("gui.log.limit"), logRingSize))
+	if path := logging.FilePath(); path != "" {
+		footer.SetText(fmt.Sprintf(i18n.T("gui.log.limit_file"), logRingSize, path))
+	}
+	
+	return container.NewBorder(
+		filterBar,
+		footer,
 		nil,
 		nil,
-		container.NewScroll(g.logOutput),
+		g.logList,
 	)
 }
 
+// refreshLogViews re-applies the level filter and search to the ring buffer (UI thread)
+func (g *GUI) refreshLogViews() {
+	if g.logRing == nil {
+		return
+	}
+	entries := g.logRing.Entries()
+	
+	g.logEntries = g.logEntries[:0]
+	g.activityEntries = g.activityEntries[:0]
+	for _, entry := range entries {
+		if entry.Component == "gui" && entry.Level >= slog.LevelInfo {
+			g.activityEntries = append(g.activityEntries, entry)
+		}
+		if entry.Level < g.logLevel {
+			continue
+		}
+		if g.logSearch != "" && !strings.Contains(strings.ToLower(entry.Message), g.logSearch) {
+			continue
+		}
+		g.logEntries = append(g.logEntries, entry)
+	}
+	
+	if g.logList != nil {
+		g.logList.Refresh()
+		g.logList.ScrollToBottom()
+	}
+	if g.activityLog != nil {
+		g.activityLog.Refresh()
+		g.activityLog.ScrollToBottom()
+	}
+}
+
+// setLogLabel shows one log record, coloured by level
+func setLogLabel(label *widget.Label, entry logging.Entry, detailed bool) {
+	text := entry.Message
+	if detailed {
+		if entry.Component != "" {
+			text = "[" + entry.Component + "] " + text
+		}
+		text = fmt.Sprintf("%s %-5s %s", entry.Time.Format("2006-01-02 15:04:05"), entry.Level, text)
+	}
+	label.SetText(text)
+	
+	switch {
+	case entry.Level >= slog.LevelError:
+		label.Importance = widget.DangerImportance
+	case entry.Level >= slog.LevelWarn:
+		label.Importance = widget.WarningImportance
+	case entry.Level < slog.LevelInfo:
+		label.Importance = widget.LowImportance
+	default:
+		label.Importance = widget.MediumImportance
+	}
+	label.Refresh()
+}
+
 func (g *GUI) loadConfigToUI() {
 	if g.config == nil {
 		return
@@ -423,12 +707,13 @@ func (g *GUI) loadConfigToUI() {
 		case "2captcha":
 			g.captchaServiceSelect.SetSelected("2captcha")
 		default:
-			g.captchaServiceSelect.SetSelected("수동 해결")
+			g.captchaServiceSelect.SetSelected(i18n.T("gui.captcha.manual"))
 		}
 	} else {
-		g.captchaServiceSelect.SetSelected("수동 해결")
+		g.captchaServiceSelect.SetSelected(i18n.T("gui.captcha.manual"))
 	}
 	g.captchaAPIKeyEntry.SetText(g.config.CaptchaSolver.APIKey)
+	g.languageSelect.SetSelected(languageOption(g.config.Language))
 	
 	// Load email settings
 	g.emailFromEntry.SetText(g.config.Email.From)
@@ -461,7 +746,7 @@ func (g *GUI) loadConfigToUI() {
 // updateSelectedProgramsLabel updates the selected programs count label
 func (g *GUI) updateSelectedProgramsLabel() {
 	if g.selectedProgramsLabel != nil {
-		g.selectedProgramsLabel.SetText(fmt.Sprintf("선택된 프로그램: %d개", len(g.programs)))
+		g.selectedProgramsLabel.SetText(fmt.Sprintf(i18n.T("gui.programs.selected"), len(g.programs)))
 	}
 }
 
@@ -476,16 +761,24 @@ func (g *GUI) updateSelectedPrograms() {
 				keywords = append(keywords, koreanName)
 			}
 			
-			g.programs = append(g.programs, models.Program{
+			program := models.Program{
 				Name:     programName,
 				Keywords: keywords,
-			})
+			}
+			// 설정 파일에서 지정한 회차 필터와 알림 중요도는 유지
+			for _, existing := range g.config.Programs {
+				if existing.Name == programName {
+					program.Filter = existing.Filter
+					program.Severity = existing.Severity
+				}
+			}
+			g.programs = append(g.programs, program)
 		}
 	}
 	
 	// Update label
 	if g.selectedProgramsLabel != nil {
-		g.selectedProgramsLabel.SetText(fmt.Sprintf("선택된 프로그램: %d개", len(g.programs)))
+		g.selectedProgramsLabel.SetText(fmt.Sprintf(i18n.T("gui.programs.selected"), len(g.programs)))
 	}
 }
 
@@ -513,6 +806,13 @@ func (g *GUI) saveConfig() {
 	}
 	g.config.CaptchaSolver.APIKey = g.captchaAPIKeyEntry.Text
 	
+	language := languageValue(g.languageSelect.Selected)
+	if language == i18n.Auto {
+		language = "" // 기본값은 파일에 쓰지 않음
+	}
+	languageChanged := language != g.config.Language
+	g.config.Language = language
+	
 	g.config.Email.From = g.emailFromEntry.Text
 	g.config.Email.To = []string{g.emailToEntry.Text}
 	g.config.Email.SMTP.Host = g.smtpHostEntry.Text
@@ -535,14 +835,43 @@ func (g *GUI) saveConfig() {
 		return
 	}
 	
-	dialog.ShowInformation("성공", "설정이 저장되었습니다.", g.window)
-	g.addLog("설정 저장 완료")
+	if g.watchProgramSelect != nil {
+		g.refreshWatchPrograms()
+	}
+	
+	saved := i18n.T("gui.dialog.saved")
+	if languageChanged {
+		saved += "\n" + i18n.T("gui.dialog.language_restart")
+	}
+	dialog.ShowInformation(i18n.T("gui.dialog.success"), saved, g.window)
+	g.addLog(i18n.T("gui.saved"))
+}
+
+// effectiveConfig merges the profile and BMW_MONITOR_* environment variables over the
+// config file (config show -effective와 같은 순서). 설정 화면과 저장에는 g.config만 사용합니다.
+func (g *GUI) effectiveConfig() (*config.Config, error) {
+	effective, err := config.LoadEffective(g.configPath, config.Layers{Profile: g.profile})
+	if err != nil {
+		return nil, err
+	}
+	if len(effective.UnknownEnv) > 0 {
+		guiLog.Warnf(i18n.T("gui.unknown_env"), strings.Join(effective.UnknownEnv, ", "))
+	}
+	return effective.Config, nil
+}
+
+// runningConfig returns the config the monitor runs with, or the saved one before it starts
+func (g *GUI) runningConfig() *config.Config {
+	if g.running != nil {
+		return g.running
+	}
+	return g.config
 }
 
 func (g *GUI) startMonitoring() {
 	isMonitoring, _ := g.isMonitoring.Get()
 	if isMonitoring {
-		g.addLog("이미 모니터링 중입니다")
+		g.addLog(i18n.T("gui.already_monitoring"))
 		return
 	}
 	
@@ -550,8 +879,8 @@ func (g *GUI) startMonitoring() {
 	g.saveConfig()
 	
 	g.isMonitoring.Set(true)
-	g.statusLabel.SetText("모니터링 중... (Monitoring)")
-	g.addLog("모니터링 시작")
+	g.statusLabel.SetText(i18n.T("gui.status.monitoring"))
+	g.addLog(i18n.T("gui.monitoring_started"))
 	
 	// 중단 채널 생성
 	g.stopChan = make(chan bool, 1)
@@ -561,257 +890,245 @@ func (g *GUI) startMonitoring() {
 }
 
 func (g *GUI) stopMonitoring() {
-	g.addLog("🛑 모니터링 중지 요청...")
+	g.addLog(i18n.T("gui.stop_requested"))
 	g.isMonitoring.Set(false)
 	
 	// 중단 신호 전송
 	if g.stopChan != nil {
 		select {
 		case g.stopChan <- true:
-			g.addLog("✅ 중단 신호 전송")
+			g.addLog(i18n.T("gui.stop_signal_sent"))
 		default:
 			// 이미 중단 신호가 있음
 		}
 	}
 	
 	// 브라우저 강제 종료
-	if g.browserClient != nil {
-		g.addLog("🌐 브라우저 강제 종료 중...")
-		g.browserClient.Close()
-		g.browserClient = nil
-		g.addLog("✅ 브라우저 종료 완료")
+	if g.engine != nil {
+		g.addLog(i18n.T("gui.browser_killing"))
+		g.engine.Close()
+		g.engine = nil
+		g.addLog(i18n.T("gui.browser_closed"))
 	}
 	
-	g.statusLabel.SetText("중지됨 (Stopped)")
-	g.addLog("⏹️ 모니터링 완전 중지")
+	g.statusLabel.SetText(i18n.T("gui.status.stopped"))
+	g.addLog(i18n.T("gui.stopped"))
+}
+
+// confirmTakeover asks whether to stop the instance holding the lock and take over
+func (g *GUI) confirmTakeover(pid int) bool {
+	answer := make(chan bool, 1)
+	message := fmt.Sprintf(i18n.T("gui.dialog.running_message"), pid)
+	dialog.ShowConfirm(i18n.T("gui.dialog.running_title"), message, func(ok bool) {
+		answer <- ok
+	}, g.window)
+	return <-answer
 }
 
 func (g *GUI) runMonitoring() {
 	// 모든 UI 업데이트를 addLog를 통해 수행
 	defer func() {
 		if r := recover(); r != nil {
-			g.addLog(fmt.Sprintf("❌ 모니터링 오류: %v", r))
+			g.addLog(fmt.Sprintf(i18n.T("gui.monitoring_error"), r))
 			g.stopMonitoring()
 		}
 	}()
 	
-	g.addLog("===== 모니터링 시작 =====")
-	g.addLog(fmt.Sprintf("⚙️ 설정: 간격 %d초, 프로그램 %d개 선택", g.config.Monitor.Interval, len(g.programs)))
+	g.addLog(i18n.T("gui.monitoring_banner"))
 	
-	// Initialize browser client
-	g.addLog("🌐 Playwright 브라우저 클라이언트 초기화 중...")
-	var err error
-	g.browserClient, err = browser.NewBrowserClientWithConfig(g.config)
+	// 방금 저장한 설정 파일에 프로필과 환경 변수를 덮어쓴 값으로 실행
+	cfg, err := g.effectiveConfig()
 	if err != nil {
-		g.addLog(fmt.Sprintf("❌ 브라우저 초기화 실패: %v", err))
+		g.addLog(fmt.Sprintf(i18n.T("gui.config_error"), err))
+		g.addLog(i18n.T("gui.config_error_hint"))
 		g.stopMonitoring()
 		return
 	}
-	defer func() {
-		if g.browserClient != nil {
-			g.addLog("🔚 브라우저 정리 중...")
-			g.browserClient.Close()
-			g.browserClient = nil
-		}
-	}()
+	g.running = cfg
+	if g.profile != "" {
+		g.addLog(fmt.Sprintf(i18n.T("gui.profile"), g.profile))
+	}
+	g.addLog(fmt.Sprintf(i18n.T("gui.settings_summary"), cfg.Monitor.Interval, len(cfg.Programs)))
+	if len(cfg.Accounts) > 0 {
+		g.addLog(fmt.Sprintf(i18n.T("gui.multi_account"), len(cfg.Accounts)))
+	}
 	
-	// Start browser with user preference
-	headless := g.headlessCheck != nil && g.headlessCheck.Checked
-	if headless {
-		g.addLog("🤖 백그라운드 모드로 Chromium 브라우저 시작...")
-	} else {
-		g.addLog("👀 일반 모드로 Chromium 브라우저 시작 (창이 표시됩니다)...")
+	// 모니터링 엔진 초기화 (설정 검증 포함)
+	engine, err := monitor.NewEngine(cfg)
+	if err != nil {
+		g.addLog(fmt.Sprintf(i18n.T("gui.config_error"), err))
+		g.addLog(i18n.T("gui.config_error_hint"))
+		g.stopMonitoring()
+		return
 	}
 	
-	if err := g.browserClient.Start(headless); err != nil {
-		g.addLog(fmt.Sprintf("❌ 브라우저 시작 실패: %v", err))
+	// CLI/서비스와 같은 Chrome 프로필을 동시에 쓰지 않도록 PID 파일 잠금
+	pidFile, err := pidfile.Acquire(pidfile.DefaultPath())
+	var locked *pidfile.LockedError
+	if errors.As(err, &locked) && locked.PID > 0 && g.confirmTakeover(locked.PID) {
+		pidFile, err = control.AcquireOrTakeOver(pidfile.DefaultPath(), true, func(format string, args ...any) {
+			g.addLog(fmt.Sprintf(format, args...))
+		})
+	}
+	if err != nil {
+		g.addLog(fmt.Sprintf("❌ %v", err))
+		g.addLog(i18n.T("gui.running_hint"))
 		g.stopMonitoring()
 		return
 	}
-	g.addLog("✅ 브라우저 시작 완료")
+	defer pidFile.Release()
 	
-	// Login
-	// 로그인 상태 확인 및 로그인
-	g.addLog("🔍 로그인 상태 확인 중...")
-	if !g.browserClient.CheckLoginStatus() {
-		g.addLog("🔐 BMW 드라이빙 센터 OAuth2 로그인 시작...")
-		g.addLog(fmt.Sprintf("   사용자: %s", g.config.Auth.Username))
-		
-		if err := g.browserClient.Login(g.config.Auth.Username, g.config.Auth.Password); err != nil {
-			g.addLog(fmt.Sprintf("❌ 로그인 실패: %v", err))
-			g.addLog("   로그인 정보를 확인해주세요")
-			g.stopMonitoring()
-			return
-		}
-		g.addLog("✅ 로그인 성공! 세션 활성화됨")
-		// CAPTCHA는 이제 Login 메서드 내부에서 자동으로 처리됨
+	// 다른 인스턴스가 인계를 요청하면 모니터링 중지
+	controlServer, err := control.Listen(func() {
+		g.addLog(i18n.T("gui.takeover_requested"))
+		g.stopMonitoring()
+	})
+	if err != nil {
+		g.addLog(fmt.Sprintf("⚠️ %v", err))
 	} else {
-		g.addLog("🎉 저장된 세션이 유효합니다")
+		defer controlServer.Close()
 	}
 	
-	// Initialize email notifier
-	g.addLog("📧 이메일 알림 서비스 초기화...")
-	g.addLog(fmt.Sprintf("   SMTP 서버: %s:%d", g.config.Email.SMTP.Host, g.config.Email.SMTP.Port))
-	g.addLog(fmt.Sprintf("   수신자: %s", strings.Join(g.config.Email.To, ", ")))
-	emailNotifier := notifier.NewEmailNotifier(g.config.Email)
-	lastNotified := make(map[string]time.Time)
-	
-	// Monitoring loop
-	g.addLog(fmt.Sprintf("⏰ %d초 간격으로 모니터링 시작...", g.config.Monitor.Interval))
-	ticker := time.NewTicker(time.Duration(g.config.Monitor.Interval) * time.Second)
-	defer ticker.Stop()
-	
-	// Initial check
-	g.addLog("🔍 첫 번째 예약 확인 시작...")
-	g.checkReservations(g.browserClient, emailNotifier, lastNotified)
+	historyStore := history.NewStore("")
+	engine.SetHistory(historyStore)
+	engine.SetClaims(claims.NewStore(""))
+	engine.SetMutes(mute.NewStore(""))
+	engine.SetWatches(watch.NewStore(""))
+	engine.OnEvent(g.handleEngineEvent)
+	g.engine = engine
 	
-	checkCount := 1
-	for {
-		select {
-		case <-g.stopChan:
-			g.addLog("⏹️ 사용자 요청으로 모니터링 중지")
-			return
-		case <-ticker.C:
-			isMonitoring, _ := g.isMonitoring.Get()
-			if !isMonitoring {
-				g.addLog("⏹️ 모니터링 상태 변경 감지")
-				return
-			}
-			checkCount++
-			g.addLog(fmt.Sprintf("🔄 [확인 #%d] 다시 확인 중...", checkCount))
-			g.checkReservations(g.browserClient, emailNotifier, lastNotified)
-		}
+	// 달력 피드 등 로컬 HTTP 서버 (server.listen 설정 시)
+	httpServer, err := server.Start(cfg.Server, engine, g.configPath)
+	if err != nil {
+		g.addLog(fmt.Sprintf("⚠️ %v", err))
 	}
-}
-
-func (g *GUI) checkReservations(browser *browser.BrowserClient, notifier *notifier.EmailNotifier, lastNotified map[string]time.Time) {
-	checkTime := time.Now()
-	g.addLog(fmt.Sprintf("📍 [%s] 예약 페이지 접속 중...", checkTime.Format("15:04:05")))
-	g.addLog(fmt.Sprintf("   URL: %s", g.config.Monitor.ReservationURL))
+	defer httpServer.Close()
 	
-	// Get program names to check
-	var programNames []string
-	for _, program := range g.programs {
-		programNames = append(programNames, program.Name)
+	// 텔레그램 봇 (telegram.token 설정 시)
+	bot, err := telegram.Start(cfg.Telegram, engine, historyStore, g.configPath)
+	if err != nil {
+		g.addLog(fmt.Sprintf("⚠️ %v", err))
 	}
+	defer bot.Close()
+	defer func() {
+		if g.engine != nil {
+			g.addLog(i18n.T("gui.browser_cleanup"))
+			g.engine.Close()
+			g.engine = nil
+		}
+		// 브라우저가 닫혔으므로 예약 가능 회차 목록도 비움
+		fyne.Do(func() {
+			g.bookingEntries = nil
+			g.bookingList.Refresh()
+		})
+	}()
 	
-	if len(programNames) == 0 {
-		g.addLog("⚠️ 확인할 프로그램이 선택되지 않았습니다")
-		g.addLog("   프로그램 목록 탭에서 원하는 프로그램을 선택해주세요")
+	// 브라우저 시작 및 로그인 (CAPTCHA는 Login 메서드 내부에서 자동으로 처리됨)
+	g.addLog(i18n.T("gui.browser_starting"))
+	if err := engine.Start(); err != nil {
+		g.addLog(fmt.Sprintf(i18n.T("gui.start_failed"), err))
+		g.addLog(i18n.T("gui.start_failed_hint"))
+		g.stopMonitoring()
 		return
 	}
 	
-	g.addLog(fmt.Sprintf("🔎 %d개 프로그램 확인 중...", len(programNames)))
+	g.addLog(i18n.T("gui.email_init"))
+	g.addLog(fmt.Sprintf(i18n.T("gui.smtp_server"), cfg.Email.SMTP.Host, cfg.Email.SMTP.Port))
 	
-	// Check reservation page with hCaptcha detection
-	availability, captchaDetected, err := browser.CheckReservationPageWithCaptchaAlert(programNames)
-	if err != nil {
-		g.addLog(fmt.Sprintf("❌ 예약 페이지 확인 실패: %v", err))
-		g.addLog("   네트워크 연결 또는 사이트 상태를 확인해주세요")
-		return
+	// Monitoring loop
+	g.addLog(fmt.Sprintf(i18n.T("gui.monitoring_interval"), cfg.Monitor.Interval))
+	engine.Run(g.stopChan)
+	g.addLog(i18n.T("gui.stopped_by_user"))
+}
+
+// handleEngineEvent shows monitoring engine events in the log views
+func (g *GUI) handleEngineEvent(event monitor.Event) {
+	prefix := ""
+	if event.Account != "" && len(g.runningConfig().Accounts) > 0 {
+		prefix = fmt.Sprintf("[%s] ", event.Account)
 	}
 	
-	// hCaptcha가 감지되면 이메일 알림 전송
-	if captchaDetected {
-		g.addLog("🚨 CAPTCHA 감지됨! 이메일 알림 전송 중...")
-		if err := notifier.SendCaptchaAlert(); err != nil {
-			g.addLog(fmt.Sprintf("❌ CAPTCHA 알림 전송 실패: %v", err))
-		} else {
-			g.addLog("✅ CAPTCHA 알림 이메일 전송 완료!")
+	switch event.Type {
+	case monitor.EventCheck:
+		g.logCheckResult(prefix, event.Result)
+		g.refreshBookings()
+		g.refreshWatches()
+	case monitor.EventOpened:
+		g.addLog("━━━━━━━━━━━━━━━━━━━━━━")
+		g.addLog(prefix + i18n.T("gui.found"))
+		for _, name := range event.Result.NewlyOpened {
+			g.addLog(fmt.Sprintf("   🚗 %s", i18n.ProgramLabel(name)))
+			if link, ok := event.Result.Links[name]; ok {
+				g.addLog(fmt.Sprintf("      👉 %s", link))
+			}
 		}
+		g.addLog(i18n.T("gui.found_hint"))
+		g.addLog("━━━━━━━━━━━━━━━━━━━━━━")
+	default:
+		guiLog.Log(event.Level(), prefix+event.Message)
 	}
 	
-	// Check for newly opened programs
-	var openPrograms []models.Program
-	var newlyOpened []string
+	if event.Type != monitor.EventCheck {
+		g.refreshAlerts()
+	}
+}
+
+func (g *GUI) logCheckResult(prefix string, result *monitor.CheckResult) {
 	availableCount := 0
 	unavailableCount := 0
 	
-	g.addLog("📋 프로그램 상태:")
-	for programName, isAvailable := range availability {
+	g.addLog(prefix + i18n.T("gui.program_status"))
+	for _, programName := range result.Programs {
+		isAvailable := result.Availability[programName]
 		koreanName := ""
-		if kName, exists := models.ProgramNameMap[programName]; exists {
-			koreanName = fmt.Sprintf(" (%s)", kName)
+		if localName := i18n.ProgramName(programName); localName != programName {
+			koreanName = fmt.Sprintf(" (%s)", localName)
 		}
 		
 		if isAvailable {
 			availableCount++
-			g.addLog(fmt.Sprintf("   ✅ %s%s - 예약 가능!", programName, koreanName))
-			
-			// Check if we haven't notified recently
-			lastTime, exists := lastNotified[programName]
-			if !exists || time.Since(lastTime) > time.Hour {
-				for _, program := range g.programs {
-					if program.Name == programName {
-						openPrograms = append(openPrograms, program)
-						newlyOpened = append(newlyOpened, programName)
-						lastNotified[programName] = time.Now()
-						break
-					}
-				}
-			}
+			g.addLog(fmt.Sprintf(i18n.T("gui.program_available"), programName, koreanName))
 		} else {
 			unavailableCount++
-			g.addLog(fmt.Sprintf("   ⭕ %s%s - 예약 불가", programName, koreanName))
+			g.addLog(fmt.Sprintf(i18n.T("gui.program_unavailable"), programName, koreanName))
 		}
 	}
 	
-	g.addLog(fmt.Sprintf("📊 결과: 가능 %d개 / 불가 %d개", availableCount, unavailableCount))
-	
-	if len(newlyOpened) > 0 {
-		g.addLog("━━━━━━━━━━━━━━━━━━━━━━")
-		g.addLog("🎉🎉 예약 가능한 프로그램 발견! 🎉🎉")
-		for _, name := range newlyOpened {
-			if kName, exists := models.ProgramNameMap[name]; exists {
-				g.addLog(fmt.Sprintf("   🚗 %s (%s)", name, kName))
-			} else {
-				g.addLog(fmt.Sprintf("   🚗 %s", name))
-			}
-		}
-		g.addLog("━━━━━━━━━━━━━━━━━━━━━━")
-		
-		// Create reservation status for notification
-		status := &models.ReservationStatus{
-			Programs:    openPrograms,
-			CheckedAt:   checkTime,
-			HasOpenings: true,
-		}
-		
-		// Send notification
-		g.addLog("📨 이메일 알림 전송 중...")
-		if err := notifier.SendNotification(status); err != nil {
-			g.addLog(fmt.Sprintf("❌ 알림 전송 실패: %v", err))
-			g.addLog("   이메일 설정을 확인해주세요")
-		} else {
-			g.addLog("✅ 이메일 알림 전송 완료!")
-			g.addLog(fmt.Sprintf("   수신자: %s", strings.Join(g.config.Email.To, ", ")))
+	g.addLog(fmt.Sprintf(i18n.T("gui.result"), availableCount, unavailableCount))
+	if !result.Session.LoggedInAt.IsZero() {
+		sessionText := fmt.Sprintf(i18n.T("gui.session_age"), result.Session.Age().Round(time.Minute))
+		if !result.Session.ExpiresAt.IsZero() {
+			sessionText += fmt.Sprintf(i18n.T("gui.session_expiry"), result.Session.ExpiresAt.Format("01-02 15:04"))
 		}
-	} else if availableCount > 0 {
-		g.addLog("ℹ️ 예약 가능한 프로그램이 있지만 이미 알림을 보냈습니다 (1시간 이내)")
+		g.addLog(sessionText)
+	}
+	
+	if len(result.NewlyOpened) == 0 && availableCount > 0 {
+		g.addLog(i18n.T("gui.already_notified"))
 	}
 	
 	// Calculate next check time
-	nextCheck := checkTime.Add(time.Duration(g.config.Monitor.Interval) * time.Second)
-	g.addLog(fmt.Sprintf("⏱️ 다음 확인: %s", nextCheck.Format("15:04:05")))
+	nextCheck := result.CheckedAt.Add(time.Duration(g.config.Monitor.Interval) * time.Second)
+	g.addLog(fmt.Sprintf(i18n.T("gui.next_check"), nextCheck.Format("15:04:05")))
 	g.addLog("─────────────────────────")
 }
 
 func (g *GUI) testEmail() {
-	g.addLog("📧 이메일 테스트 시작...")
+	g.addLog(i18n.T("gui.test_email_start"))
 	
 	// 현재 설정 저장
 	g.saveConfig()
 	
 	// 이메일 설정 확인
 	if g.config.Email.From == "" || len(g.config.Email.To) == 0 || g.config.Email.To[0] == "" {
-		g.addLog("❌ 이메일 주소가 설정되지 않았습니다")
-		dialog.ShowError(fmt.Errorf("보내는 사람과 받는 사람 이메일을 설정해주세요"), g.window)
+		g.addLog(i18n.T("gui.email_missing"))
+		dialog.ShowError(errors.New(i18n.T("gui.email_missing_error")), g.window)
 		return
 	}
 	
 	if g.config.Email.SMTP.Host == "" || g.config.Email.SMTP.Port == 0 {
-		g.addLog("❌ SMTP 서버 설정이 없습니다")
-		dialog.ShowError(fmt.Errorf("SMTP 서버 정보를 설정해주세요"), g.window)
+		g.addLog(i18n.T("gui.smtp_missing"))
+		dialog.ShowError(errors.New(i18n.T("gui.smtp_missing_error")), g.window)
 		return
 	}
 	
@@ -820,57 +1137,38 @@ func (g *GUI) testEmail() {
 	
 	// 테스트 상태 생성
 	testStatus := &models.ReservationStatus{
-		Programs: []models.Program{
-			{Name: "TEST PROGRAM", Keywords: []string{"테스트"}},
+		Programs: []models.ProgramStatus{
+			{Program: models.Program{Name: "TEST PROGRAM", Keywords: []string{"테스트"}}, IsOpen: true},
 		},
 		CheckedAt:   time.Now(),
 		HasOpenings: true,
 	}
 	
 	// 이메일 전송
-	g.addLog(fmt.Sprintf("📨 테스트 이메일 전송 중..."))
+	g.addLog(i18n.T("gui.test_email_sending"))
 	g.addLog(fmt.Sprintf("   From: %s", g.config.Email.From))
 	g.addLog(fmt.Sprintf("   To: %s", strings.Join(g.config.Email.To, ", ")))
 	g.addLog(fmt.Sprintf("   SMTP: %s:%d", g.config.Email.SMTP.Host, g.config.Email.SMTP.Port))
 	
 	if err := emailNotifier.SendNotification(testStatus); err != nil {
-		g.addLog(fmt.Sprintf("❌ 이메일 전송 실패: %v", err))
+		g.addLog(fmt.Sprintf(i18n.T("gui.email_failed"), err))
 		dialog.ShowError(err, g.window)
 		return
 	}
 	
-	g.addLog("✅ 테스트 이메일 전송 완료!")
-	dialog.ShowInformation("성공", "테스트 이메일이 전송되었습니다.\n받은 편지함을 확인해주세요.", g.window)
+	g.addLog(i18n.T("gui.test_email_sent"))
+	dialog.ShowInformation(i18n.T("gui.dialog.success"), i18n.T("gui.dialog.test_email_sent"), g.window)
 }
 
+// addLog writes a GUI message to the log; the level follows the message's leading emoji
 func (g *GUI) addLog(message string) {
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	logMessage := fmt.Sprintf("[%s] %s\n", timestamp, message)
-	cleanMessage := message + "\n"
-	
-	// Fyne UI 업데이트는 반드시 메인 스레드에서 실행
-	if g.app != nil && g.window != nil {
-		// fyne.Do()를 사용하여 메인 스레드에서 UI 업데이트 실행
-		fyne.Do(func() {
-			// Add to main log tab
-			if g.logOutput != nil {
-				current := g.logOutput.Text
-				g.logOutput.SetText(current + logMessage)
-				// 스크롤을 맨 아래로
-				g.logOutput.CursorRow = len(strings.Split(g.logOutput.Text, "\n")) - 1
-			}
-			
-			// Also add to activity log on monitor tab
-			if g.activityLog != nil {
-				current := g.activityLog.Text
-				g.activityLog.SetText(current + cleanMessage)
-				// 스크롤을 맨 아래로
-				g.activityLog.CursorRow = len(strings.Split(g.activityLog.Text, "\n")) - 1
-			}
-			
-			// Refresh canvas after updates
-			g.window.Canvas().Content().Refresh()
-		})
+	switch {
+	case strings.HasPrefix(message, "❌"):
+		guiLog.Error(message)
+	case strings.HasPrefix(message, "⚠️"), strings.HasPrefix(message, "🚨"):
+		guiLog.Warn(message)
+	default:
+		guiLog.Info(message)
 	}
 }
 
@@ -905,7 +1203,7 @@ func createDefaultConfig() *config.Config {
 		Email: config.EmailConfig{
 			From: "",
 			To:   []string{},
-			Subject: "BMW 드라이빙 센터 예약 오픈 알림",
+			Subject: i18n.T("gui.default_subject"),
 			SMTP: config.SMTPConfig{
 				Host:     "smtp.gmail.com",
 				Port:     587,
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bmw-driving-center-alter/internal/analytics"
+	"bmw-driving-center-alter/internal/history"
+	"bmw-driving-center-alter/internal/i18n"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// statsDays is the history period analyzed by the statistics tab
+const statsDays = 90
+
+// chartHeight is the height of the tallest bar in the statistics charts
+const chartHeight = 120
+
+// buildStatsTab shows when each program usually opened, from the check history
+func (g *GUI) buildStatsTab() fyne.CanvasObject {
+	g.statsSummary = widget.NewLabel("")
+	g.statsSummary.Wrapping = fyne.TextWrapWord
+	g.weekdayChart = container.NewStack()
+	g.hourChart = container.NewStack()
+
+	g.statsSelect = widget.NewSelect(nil, func(string) { g.showStats() })
+	g.statsSelect.PlaceHolder = i18n.T("gui.stats.select_program")
+
+	refreshBtn := widget.NewButton(i18n.T("gui.button.refresh"), g.refreshStats)
+
+	header := container.NewBorder(nil, nil,
+		widget.NewLabel(i18n.T("gui.label.program")),
+		refreshBtn,
+		g.statsSelect,
+	)
+	charts := container.NewVBox(
+		g.statsSummary,
+		widget.NewCard(i18n.T("gui.card.by_weekday"), "", g.weekdayChart),
+		widget.NewCard(i18n.T("gui.card.by_hour"), "", g.hourChart),
+		widget.NewLabel(fmt.Sprintf(i18n.T("gui.stats.basis"), statsDays)),
+	)
+
+	g.refreshStats()
+	return container.NewBorder(header, nil, nil, nil, container.NewVScroll(charts))
+}
+
+// refreshStats analyzes the check history in the background and updates the tab
+func (g *GUI) refreshStats() {
+	go func() {
+		entries, err := history.NewStore("").Read(history.Filter{Since: time.Now().AddDate(0, 0, -statsDays)})
+		stats := analytics.Analyze(entries, analytics.Options{})
+		fyne.Do(func() {
+			if err != nil {
+				g.statsData = nil
+				g.statsSelect.SetOptions(nil)
+				g.statsSummary.SetText(fmt.Sprintf(i18n.T("gui.stats.read_failed"), err))
+				return
+			}
+
+			g.statsData = stats
+			var options []string
+			for _, s := range stats {
+				options = append(options, programDisplayName(s.Program))
+			}
+			selected := g.statsSelect.Selected
+			g.statsSelect.SetOptions(options)
+			switch {
+			case len(options) == 0:
+				g.statsSelect.ClearSelected()
+				g.showStats()
+			case selected == "" || g.selectedStats() == nil:
+				g.statsSelect.SetSelected(options[0])
+			default:
+				g.showStats()
+			}
+		})
+	}()
+}
+
+// selectedStats returns the statistics of the selected program, or nil
+func (g *GUI) selectedStats() *analytics.ProgramStats {
+	for i, s := range g.statsData {
+		if programDisplayName(s.Program) == g.statsSelect.Selected {
+			return &g.statsData[i]
+		}
+	}
+	return nil
+}
+
+// showStats draws the charts of the selected program (UI thread)
+func (g *GUI) showStats() {
+	s := g.selectedStats()
+	if s == nil {
+		g.statsSummary.SetText(i18n.T("gui.stats.empty"))
+		g.weekdayChart.Objects = nil
+		g.hourChart.Objects = nil
+		g.weekdayChart.Refresh()
+		g.hourChart.Refresh()
+		return
+	}
+
+	g.statsSummary.SetText(fmt.Sprintf(i18n.T("gui.stats.range"),
+		s.From.Format("2006-01-02"), s.To.Format("2006-01-02"), s.Checks, len(s.Openings), s.Summary()))
+
+	var days []string
+	for day := range s.ByWeekday {
+		days = append(days, analytics.WeekdayName(time.Weekday(day)))
+	}
+	var hours []string
+	for hour := range s.ByHour {
+		hours = append(hours, fmt.Sprintf("%d", hour))
+	}
+	g.weekdayChart.Objects = []fyne.CanvasObject{barChart(days, s.ByWeekday[:])}
+	g.hourChart.Objects = []fyne.CanvasObject{barChart(hours, s.ByHour[:])}
+	g.weekdayChart.Refresh()
+	g.hourChart.Refresh()
+}
+
+// barChart draws a simple vertical bar chart with a label under each bar
+func barChart(labels []string, counts []int) fyne.CanvasObject {
+	largest := 0
+	for _, count := range counts {
+		largest = max(largest, count)
+	}
+
+	columns := container.NewGridWithColumns(len(counts))
+	for i, count := range counts {
+		height := float32(0)
+		if largest > 0 {
+			height = float32(count) / float32(largest) * chartHeight
+		}
+		bar := canvas.NewRectangle(theme.Color(theme.ColorNamePrimary))
+		bar.SetMinSize(fyne.NewSize(4, height))
+
+		value := ""
+		if count > 0 {
+			value = fmt.Sprintf("%d", count)
+		}
+		columns.Add(container.NewVBox(
+			layout.NewSpacer(),
+			widget.NewLabelWithStyle(value, fyne.TextAlignCenter, fyne.TextStyle{}),
+			bar,
+			widget.NewLabelWithStyle(labels[i], fyne.TextAlignCenter, fyne.TextStyle{}),
+		))
+	}
+	return columns
+}
+
+// programDisplayName returns "Name (한글 이름)" when the name is localized
+func programDisplayName(name string) string {
+	return i18n.ProgramLabel(name)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/watch"
+	"errors"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// buildWatchTab lets the user watch specific sessions (program + date + time) for a free seat.
+// 감시 목록은 파일에 저장되므로 모니터링 중이 아니어도 추가/삭제할 수 있습니다.
+func (g *GUI) buildWatchTab() fyne.CanvasObject {
+	g.watchProgramSelect = widget.NewSelect(nil, nil)
+	g.watchProgramSelect.PlaceHolder = i18n.T("gui.card.programs")
+	g.refreshWatchPrograms()
+
+	dateEntry := widget.NewEntry()
+	dateEntry.SetPlaceHolder(time.Now().AddDate(0, 0, 7).Format("2006-01-02"))
+	timeEntry := widget.NewEntry()
+	timeEntry.SetPlaceHolder(i18n.T("gui.watch.time_placeholder"))
+
+	addBtn := widget.NewButton(i18n.T("gui.watch.add"), func() {
+		if err := g.addWatch(dateEntry.Text, timeEntry.Text); err != nil {
+			dialog.ShowError(err, g.window)
+			return
+		}
+		dateEntry.SetText("")
+		timeEntry.SetText("")
+	})
+	addBtn.Importance = widget.HighImportance
+
+	form := widget.NewForm(
+		widget.NewFormItem(i18n.T("gui.watch.program"), g.watchProgramSelect),
+		widget.NewFormItem(i18n.T("gui.watch.date"), dateEntry),
+		widget.NewFormItem(i18n.T("gui.watch.time"), timeEntry),
+	)
+
+	g.watchList = widget.NewList(
+		func() int { return len(g.watchEntries) },
+		func() fyne.CanvasObject {
+			return container.NewBorder(nil, nil, nil, widget.NewButton(i18n.T("gui.watch.delete"), nil), widget.NewLabel(""))
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			w := g.watchEntries[id]
+			row := item.(*fyne.Container)
+			label := fmt.Sprintf("👀 %s %s", programDisplayName(w.Program), formatWatchStart(w))
+			if w.Account != "" {
+				label += fmt.Sprintf(" [%s]", w.Account)
+			}
+			row.Objects[0].(*widget.Label).SetText(label)
+			row.Objects[1].(*widget.Button).OnTapped = func() { g.removeWatch(w) }
+		},
+	)
+	g.refreshWatches()
+
+	header := container.NewVBox(
+		widget.NewLabel(i18n.T("gui.watch.hint")),
+		form,
+		container.NewHBox(addBtn),
+	)
+	return container.NewBorder(header, nil, nil, nil, widget.NewCard(i18n.T("gui.card.watches"), "", g.watchList))
+}
+
+// refreshWatchPrograms fills the program choices with the programs the accounts watch
+func (g *GUI) refreshWatchPrograms() {
+	seen := make(map[string]bool)
+	var options []string
+	for _, account := range g.config.GetAccounts() {
+		for _, program := range account.Programs {
+			name := programDisplayName(program.Name)
+			if !seen[name] {
+				seen[name] = true
+				options = append(options, name)
+			}
+		}
+	}
+	g.watchProgramSelect.SetOptions(options)
+}
+
+// selectedWatchProgram returns the configured name of the selected program
+func (g *GUI) selectedWatchProgram() string {
+	for _, account := range g.config.GetAccounts() {
+		for _, program := range account.Programs {
+			if programDisplayName(program.Name) == g.watchProgramSelect.Selected {
+				return program.Name
+			}
+		}
+	}
+	return ""
+}
+
+// addWatch saves a watch of the selected program's session
+func (g *GUI) addWatch(date, clock string) error {
+	program := g.selectedWatchProgram()
+	if program == "" {
+		return errors.New(i18n.T("gui.watch.select_program"))
+	}
+	start, allDay, err := watch.ParseStart(date, clock)
+	if err != nil {
+		return err
+	}
+
+	if engine := g.engine; engine != nil {
+		if _, err := engine.AddWatch("", program, start, allDay, "gui"); err != nil {
+			return err
+		}
+	} else {
+		w := watch.Watch{Program: program, Start: start, AllDay: allDay, By: "gui", Created: time.Now()}
+		if w.Expired(time.Now()) {
+			return fmt.Errorf(i18n.T("gui.watch.past"), w)
+		}
+		if err := watch.NewStore("").Add(w); err != nil {
+			return err
+		}
+		guiLog.Infof(i18n.T("gui.watch.added"), w)
+	}
+	g.refreshWatches()
+	return nil
+}
+
+// removeWatch deletes a watch from the list
+func (g *GUI) removeWatch(w watch.Watch) {
+	var err error
+	if engine := g.engine; engine != nil {
+		_, err = engine.RemoveWatch(w.ID())
+	} else {
+		_, err = watch.NewStore("").Remove(w.ID())
+	}
+	if err != nil {
+		dialog.ShowError(err, g.window)
+		return
+	}
+	g.refreshWatches()
+}
+
+// refreshWatches reloads the watched sessions (지난 회차는 이때 목록에서 빠짐)
+func (g *GUI) refreshWatches() {
+	go func() {
+		watches, err := watch.NewStore("").List()
+		if err != nil {
+			guiLog.Warnf("⚠️ %v", err)
+		}
+		fyne.Do(func() {
+			g.watchEntries = watches
+			if g.watchList != nil {
+				g.watchList.Refresh()
+			}
+		})
+	}()
+}
+
+func formatWatchStart(w watch.Watch) string {
+	if w.AllDay {
+		return w.Start.Format(i18n.T("gui.watch.all_day_format"))
+	}
+	return i18n.FormatTime(w.Start, "01-02 (Mon) 15:04")
+}
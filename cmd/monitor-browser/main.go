@@ -3,6 +3,8 @@ package main
 import (
 	"bmw-driving-center-alter/internal/browser"
 	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/logging"
 	"bmw-driving-center-alter/internal/models"
 	"bmw-driving-center-alter/internal/notifier"
 	"flag"
@@ -11,6 +13,7 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -18,43 +21,60 @@ import (
 
 func main() {
 	// Parse command line flags
-	configPath := flag.String("config", filepath.Join("configs", "config.yaml"), "설정 파일 경로")
-	headless := flag.Bool("headless", true, "헤드리스 모드 (백그라운드 실행)")
-	testLogin := flag.Bool("test-login", false, "로그인 테스트만 수행")
+	configPath := flag.String("config", filepath.Join("configs", "config.yaml"), i18n.T("cmd_monitor_browser.flag.config"))
+	headless := flag.Bool("headless", false, i18n.T("cmd_monitor_browser.flag.headless"))
+	testLogin := flag.Bool("test-login", false, i18n.T("cmd_monitor_browser.flag.test_login"))
+	profile := flag.String("profile", "", i18n.T("cli.flag.profile"))
 	flag.Parse()
 
-	// Load configuration
-	cfg, err := config.Load(*configPath)
+	// Load configuration (설정 파일 < 프로필 < 환경 변수 < -headless)
+	layers := config.Layers{Profile: *profile}
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "headless" {
+			layers.Flags = append(layers.Flags, config.Override{Key: "monitor.headless", Value: strconv.FormatBool(*headless), Source: "-headless"})
+		}
+	})
+	effective, err := config.LoadEffective(*configPath, layers)
 	if err != nil {
-		log.Fatalf("설정 파일 로드 실패: %v", err)
+		log.Fatalf(i18n.T("cmd_monitor_browser.config_load_failed"), err)
+	}
+	cfg := effective.Config
+	if err := i18n.SetLanguage(cfg.Language); err != nil {
+		log.Printf("⚠️ %v", err)
+	}
+
+	// 로그 레벨/파일 설정 (log 패키지 출력도 같은 곳으로)
+	if err := logging.Setup(cfg.Logging); err != nil {
+		log.Fatalf("%v", err)
 	}
+	defer logging.Close()
 
-	log.Println("🚗 BMW 드라이빙 센터 예약 모니터링 시작 (브라우저 모드)")
-	log.Printf("확인 간격: %d초", cfg.Monitor.Interval)
+	log.Println(i18n.T("cmd_monitor_browser.start"))
+	log.Printf(i18n.T("cmd_monitor_browser.interval"), cfg.Monitor.Interval)
 
 	// Initialize browser client
 	browserClient, err := browser.NewBrowserClient()
 	if err != nil {
-		log.Fatalf("브라우저 클라이언트 초기화 실패: %v", err)
+		log.Fatalf(i18n.T("cmd_monitor_browser.browser_init_failed"), err)
 	}
 	defer browserClient.Close()
 
 	// Start browser
-	log.Printf("브라우저 시작 중... (headless=%v)", *headless)
-	if err := browserClient.Start(*headless); err != nil {
-		log.Fatalf("브라우저 시작 실패: %v", err)
+	log.Printf(i18n.T("cmd_monitor_browser.browser_starting"), cfg.Monitor.Headless)
+	if err := browserClient.Start(cfg.Monitor.Headless); err != nil {
+		log.Fatalf(i18n.T("cmd_monitor_browser.browser_start_failed"), err)
 	}
 
 	// Login
-	log.Println("로그인 시도 중...")
+	log.Println(i18n.T("cmd_monitor_browser.logging_in"))
 	if err := browserClient.Login(cfg.Auth.Username, cfg.Auth.Password); err != nil {
-		log.Fatalf("로그인 실패: %v", err)
+		log.Fatalf(i18n.T("cmd_monitor_browser.login_failed"), err)
 	}
-	log.Println("✅ 로그인 성공!")
+	log.Println(i18n.T("cmd_monitor_browser.login_ok"))
 
 	// If test login only, exit here
 	if *testLogin {
-		log.Println("로그인 테스트 완료")
+		log.Println(i18n.T("cmd_monitor_browser.login_test_done"))
 		return
 	}
 
@@ -85,7 +105,7 @@ func main() {
 		case <-ticker.C:
 			monitor.check()
 		case <-sigChan:
-			log.Println("모니터링 종료...")
+			log.Println(i18n.T("cmd_monitor_browser.stopping"))
 			return
 		}
 	}
@@ -99,23 +119,17 @@ type BrowserMonitor struct {
 }
 
 func (m *BrowserMonitor) check() {
-	log.Println("예약 페이지 확인 중...")
-
-	// Get program names to check
-	var programNames []string
-	for _, program := range m.config.Programs {
-		programNames = append(programNames, program.Name)
-	}
+	log.Println(i18n.T("cmd_monitor_browser.checking"))
 
-	// Check reservation page
-	availability, err := m.browser.CheckReservationPage(programNames)
+	// Check reservation page (names and keywords)
+	availability, err := m.browser.CheckReservationPage(m.config.Programs)
 	if err != nil {
-		log.Printf("예약 페이지 확인 실패: %v", err)
+		log.Printf(i18n.T("cmd_monitor_browser.check_failed"), err)
 		return
 	}
 
 	// Check for newly opened programs
-	var openPrograms []models.Program
+	var openPrograms []models.ProgramStatus
 	var newlyOpened []string
 
 	for programName, isAvailable := range availability {
@@ -126,7 +140,7 @@ func (m *BrowserMonitor) check() {
 					// Check if we haven't notified recently (within 1 hour)
 					lastTime, exists := m.lastNotified[programName]
 					if !exists || time.Since(lastTime) > time.Hour {
-						openPrograms = append(openPrograms, program)
+						openPrograms = append(openPrograms, models.ProgramStatus{Program: program, IsOpen: true, LastChecked: time.Now()})
 						newlyOpened = append(newlyOpened, programName)
 						m.lastNotified[programName] = time.Now()
 					}
@@ -137,7 +151,7 @@ func (m *BrowserMonitor) check() {
 	}
 
 	if len(newlyOpened) > 0 {
-		log.Printf("🎉 예약 가능한 프로그램 발견: %s", strings.Join(newlyOpened, ", "))
+		log.Printf(i18n.T("cmd_monitor_browser.found"), strings.Join(newlyOpened, ", "))
 
 		// Create reservation status for notification
 		status := &models.ReservationStatus{
@@ -148,17 +162,17 @@ func (m *BrowserMonitor) check() {
 
 		// Send notification
 		if err := m.notifier.SendNotification(status); err != nil {
-			log.Printf("알림 전송 실패: %v", err)
+			log.Printf(i18n.T("cmd_monitor_browser.notify_failed"), err)
 		} else {
-			log.Println("✅ 이메일 알림 전송 완료")
+			log.Println(i18n.T("cmd_monitor_browser.notified"))
 		}
 	} else {
 		// Log status for all programs
-		log.Println("프로그램 상태:")
+		log.Println(i18n.T("cmd_monitor_browser.program_status"))
 		for name, available := range availability {
-			status := "❌ 예약 불가"
+			status := i18n.T("cmd_monitor_browser.unavailable")
 			if available {
-				status = "✅ 예약 가능"
+				status = i18n.T("cmd_monitor_browser.available")
 			}
 			fmt.Printf("  %s: %s\n", name, status)
 		}
@@ -2,9 +2,13 @@ package main
 
 import (
 	"bmw-driving-center-alter/internal/auth"
+	"bmw-driving-center-alter/internal/capture"
 	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/logging"
 	"bmw-driving-center-alter/internal/notifier"
 	"bmw-driving-center-alter/internal/scraper"
+	"bmw-driving-center-alter/internal/session"
 	"flag"
 	"fmt"
 	"log"
@@ -17,19 +21,31 @@ import (
 
 func main() {
 	// Parse command line flags
-	configPath := flag.String("config", filepath.Join("configs", "config.yaml"), "설정 파일 경로 (Config file path)")
-	testEmail := flag.Bool("test-email", false, "이메일 설정 테스트 (Test email configuration)")
-	showPrograms := flag.Bool("list-programs", false, "프로그램 목록 확인 (List available programs)")
+	configPath := flag.String("config", filepath.Join("configs", "config.yaml"), i18n.T("cmd_monitor.flag.config"))
+	testEmail := flag.Bool("test-email", false, i18n.T("cmd_monitor.flag.test_email"))
+	showPrograms := flag.Bool("list-programs", false, i18n.T("cmd_monitor.flag.list"))
+	sessionFile := flag.String("session", "", i18n.T("cmd_monitor.flag.session")+session.PassphraseEnv+")")
+	profile := flag.String("profile", "", i18n.T("cli.flag.profile"))
 	flag.Parse()
 
-	// Load configuration
-	cfg, err := config.Load(*configPath)
+	// Load configuration (설정 파일 < 프로필 < 환경 변수)
+	effective, err := config.LoadEffective(*configPath, config.Layers{Profile: *profile})
 	if err != nil {
-		log.Fatalf("설정 파일 로드 실패 (Failed to load config): %v", err)
+		log.Fatalf(i18n.T("cmd_monitor.config_load_failed"), err)
 	}
+	cfg := effective.Config
+	if err := i18n.SetLanguage(cfg.Language); err != nil {
+		log.Printf("⚠️ %v", err)
+	}
+
+	// 로그 레벨/파일 설정 (log 패키지 출력도 같은 곳으로)
+	if err := logging.Setup(cfg.Logging); err != nil {
+		log.Fatalf("%v", err)
+	}
+	defer logging.Close()
 
-	log.Println("BMW 드라이빙 센터 예약 모니터링 시작 (Starting BMW Driving Center Reservation Monitor)")
-	log.Printf("확인 간격: %d초 (Check interval: %d seconds)", cfg.Monitor.Interval, cfg.Monitor.Interval)
+	log.Println(i18n.T("cmd_monitor.start"))
+	log.Printf(i18n.T("cmd_monitor.interval"), cfg.Monitor.Interval)
 
 	// Initialize components
 	authClient, err := auth.NewAuthClient(auth.LoginCredentials{
@@ -37,38 +53,59 @@ func main() {
 		Password: cfg.Auth.Password,
 	})
 	if err != nil {
-		log.Fatalf("인증 클라이언트 초기화 실패 (Failed to initialize auth client): %v", err)
+		log.Fatalf(i18n.T("cmd_monitor.auth_init_failed"), err)
 	}
 
 	webScraper := scraper.New(cfg.Monitor.ReservationURL, cfg.Monitor.ProgramListURL)
+	if cfg.Capture.Enabled {
+		recorder, err := capture.NewRecorder(cfg.Capture)
+		if err != nil {
+			log.Printf(i18n.T("cmd_monitor.capture_init_failed"), err)
+		} else {
+			log.Printf(i18n.T("cmd_monitor.capture_enabled"), recorder.Dir())
+			webScraper.SetRecorder(recorder)
+		}
+	}
 	emailNotifier := notifier.NewEmailNotifier(cfg.Email)
 
+	// Load exported browser session before the first check
+	if *sessionFile != "" {
+		snap, err := session.Import(*sessionFile, os.Getenv(session.PassphraseEnv))
+		if err != nil {
+			log.Fatalf(i18n.T("cmd_monitor.session_import_failed"), err)
+		}
+		if err := authClient.ImportSession(snap); err != nil {
+			log.Fatalf(i18n.T("cmd_monitor.session_apply_failed"), err)
+		}
+		webScraper.SetClient(authClient.HTTPClient())
+	}
+
 	// Test email if requested
 	if *testEmail {
-		log.Println("이메일 테스트 중... (Testing email...)")
+		log.Println(i18n.T("cmd_monitor.testing_email"))
 		if err := emailNotifier.TestConnection(); err != nil {
-			log.Printf("이메일 테스트 실패 (Email test failed): %v", err)
+			log.Printf(i18n.T("cmd_monitor.email_test_failed"), err)
 		} else {
-			log.Println("이메일 테스트 성공! (Email test successful!)")
+			log.Println(i18n.T("cmd_monitor.email_test_ok"))
 		}
 		return
 	}
 
 	// List programs if requested
 	if *showPrograms {
-		log.Println("프로그램 목록 가져오는 중... (Fetching program list...)")
+		log.Println(i18n.T("cmd_monitor.fetching_programs"))
 		
 		// Login first
 		if err := authClient.Login(); err != nil {
-			log.Printf("로그인 실패 (Login failed): %v", err)
+			log.Printf(i18n.T("cmd_monitor.login_failed"), err)
 			return
 		}
 		
 		programs, err := webScraper.FetchProgramList()
 		if err != nil {
-			log.Printf("프로그램 목록 가져오기 실패 (Failed to fetch programs): %v", err)
+			log.Printf(i18n.T("cmd_monitor.fetch_programs_failed"), err)
 		} else {
-			fmt.Println("\n사용 가능한 프로그램 (Available Programs):")
+			fmt.Println(i18n.T("cmd_monitor.available_programs"))
 			for _, prog := range programs {
 				fmt.Printf("  - %s\n", prog)
 			}
@@ -101,7 +138,7 @@ func main() {
 		case <-ticker.C:
 			monitor.check()
 		case <-sigChan:
-			log.Println("모니터링 종료 (Stopping monitor)")
+			log.Println(i18n.T("cmd_monitor.stopping"))
 			return
 		}
 	}
@@ -116,21 +153,21 @@ type Monitor struct {
 }
 
 func (m *Monitor) check() {
-	log.Println("예약 페이지 확인 중... (Checking reservation page...)")
+	log.Println(i18n.T("cmd_monitor.checking"))
 	
 	// Login if needed
 	if !m.authClient.IsLoggedIn() {
 		if err := m.authClient.Login(); err != nil {
-			log.Printf("로그인 실패 (Login failed): %v", err)
+			log.Printf(i18n.T("cmd_monitor.login_failed"), err)
 			return
 		}
-		log.Println("로그인 성공 (Login successful)")
+		log.Println(i18n.T("cmd_monitor.login_ok"))
 	}
 
 	// Check reservation status
 	status, err := m.scraper.CheckReservationStatus(m.config.Programs)
 	if err != nil {
-		log.Printf("예약 상태 확인 실패 (Failed to check reservation status): %v", err)
+		log.Printf(i18n.T("cmd_monitor.check_failed"), err)
 		return
 	}
 
@@ -148,15 +185,15 @@ func (m *Monitor) check() {
 	}
 
 	if len(newlyOpened) > 0 {
-		log.Printf("🎉 예약 가능한 프로그램 발견! (Found available programs!): %v", newlyOpened)
+		log.Printf(i18n.T("cmd_monitor.found"), newlyOpened)
 		
 		// Send notification
 		if err := m.notifier.SendNotification(status); err != nil {
-			log.Printf("알림 전송 실패 (Failed to send notification): %v", err)
+			log.Printf(i18n.T("cmd_monitor.notify_failed"), err)
 		} else {
-			log.Println("✅ 이메일 알림 전송 완료 (Email notification sent)")
+			log.Println(i18n.T("cmd_monitor.notified"))
 		}
 	} else {
-		log.Println("현재 예약 가능한 프로그램 없음 (No programs available)")
+		log.Println(i18n.T("cmd_monitor.none_available"))
 	}
 }
\ No newline at end of file
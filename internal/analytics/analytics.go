@@ -0,0 +1,317 @@
+package analytics
+
+import (
+	"bmw-driving-center-alter/internal/history"
+	"bmw-driving-center-alter/internal/i18n"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMaxGap is the longest pause between checks that still counts as continuous observation.
+	// 이보다 오래 기록이 없으면 그 사이에 열리고 닫혔는지 알 수 없으므로 계산에서 뺍니다.
+	DefaultMaxGap = time.Hour
+
+	// duplicateWindow merges openings of the same program seen by several accounts
+	duplicateWindow = 30 * time.Minute
+
+	// minWindowOpenings is the fewest openings for a weekday/hour to be called typical
+	minWindowOpenings = 2
+
+	maxWindows = 3
+)
+
+// WeekdayName returns the short name of a weekday in the current language
+func WeekdayName(day time.Weekday) string {
+	return i18n.Weekday(day)
+}
+
+// Opening is one time a program was seen becoming bookable
+type Opening struct {
+	Account string
+	Program string
+	Time    time.Time     // 예약 가능으로 처음 확인된 시각
+	SoldOut time.Duration // 마감으로 확인될 때까지 걸린 시간 (0이면 모름)
+}
+
+// Distribution summarizes a set of durations
+type Distribution struct {
+	Count  int
+	Min    time.Duration
+	P25    time.Duration
+	Median time.Duration
+	P75    time.Duration
+	Max    time.Duration
+}
+
+// Window is a weekday and hour in which a program opened
+type Window struct {
+	Weekday  time.Weekday
+	Hour     int
+	Openings int
+}
+
+// String formats the window like "화 10시"
+func (w Window) String() string {
+	return fmt.Sprintf(i18n.T("analytics.window"), WeekdayName(w.Weekday), w.Hour)
+}
+
+// ProgramStats is what the check history says about one program
+type ProgramStats struct {
+	Program   string
+	Checks    int
+	From, To  time.Time // 분석한 기록의 기간
+	Openings  []Opening
+	ByWeekday [7]int
+	ByHour    [24]int
+	Heatmap   [7][24]int   // 요일 x 시간대별 열린 횟수
+	SellOut   Distribution // 열린 뒤 마감까지 걸린 시간
+	LeadTime  Distribution // 회차가 처음 보였을 때부터 회차 날짜까지 (얼마나 미리 공개되는지)
+	Windows   []Window     // 가장 자주 열린 요일/시간대 (많은 순)
+}
+
+// Options control the analysis
+type Options struct {
+	Location *time.Location // 요일/시간대 기준 (nil이면 time.Local)
+	MaxGap   time.Duration  // 0이면 DefaultMaxGap
+}
+
+// Analyze computes opening patterns per program from check history (oldest entry first)
+func Analyze(entries []history.Entry, opts Options) []ProgramStats {
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	if opts.MaxGap <= 0 {
+		opts.MaxGap = DefaultMaxGap
+	}
+
+	type observation struct {
+		time      time.Time
+		available bool
+	}
+	type series struct {
+		account      string
+		program      string
+		observations []observation
+	}
+
+	var order []string
+	stats := make(map[string]*ProgramStats)
+	seriesByKey := make(map[string]*series)
+	var seriesOrder []string
+	firstSeen := make(map[string]map[time.Time]time.Time) // 프로그램 -> 회차 시작 -> 처음 보인 시각
+	firstChecked := make(map[string]time.Time)
+
+	for _, entry := range entries {
+		for _, result := range entry.Programs {
+			s, exists := stats[result.Name]
+			if !exists {
+				s = &ProgramStats{Program: result.Name, From: entry.Time}
+				stats[result.Name] = s
+				order = append(order, result.Name)
+				firstSeen[result.Name] = make(map[time.Time]time.Time)
+				firstChecked[result.Name] = entry.Time
+			}
+			s.Checks++
+			s.To = entry.Time
+
+			key := entry.Account + "\x00" + result.Name
+			if _, exists := seriesByKey[key]; !exists {
+				seriesByKey[key] = &series{account: entry.Account, program: result.Name}
+				seriesOrder = append(seriesOrder, key)
+			}
+			seriesByKey[key].observations = append(seriesByKey[key].observations, observation{entry.Time, result.Available})
+
+			for _, start := range result.Sessions {
+				if _, seen := firstSeen[result.Name][start]; !seen {
+					firstSeen[result.Name][start] = entry.Time
+				}
+			}
+		}
+	}
+
+	// 계정별로 마감 -> 예약 가능 전환을 찾음
+	var openings []Opening
+	for _, key := range seriesOrder {
+		s := seriesByKey[key]
+		obs := s.observations
+		for i := 1; i < len(obs); i++ {
+			if !obs[i].available || obs[i-1].available || obs[i].time.Sub(obs[i-1].time) > opts.MaxGap {
+				continue
+			}
+			opening := Opening{Account: s.account, Program: s.program, Time: obs[i].time}
+			for j := i + 1; j < len(obs); j++ {
+				if obs[j].time.Sub(obs[j-1].time) > opts.MaxGap {
+					break
+				}
+				if !obs[j].available {
+					opening.SoldOut = obs[j].time.Sub(opening.Time)
+					break
+				}
+			}
+			openings = append(openings, opening)
+		}
+	}
+	sort.SliceStable(openings, func(i, j int) bool { return openings[i].Time.Before(openings[j].Time) })
+
+	for _, opening := range openings {
+		s := stats[opening.Program]
+		// 여러 계정이 같은 오픈을 본 경우 한 번만
+		if n := len(s.Openings); n > 0 && opening.Time.Sub(s.Openings[n-1].Time) < duplicateWindow {
+			if s.Openings[n-1].SoldOut == 0 {
+				s.Openings[n-1].SoldOut = opening.SoldOut
+			}
+			continue
+		}
+		s.Openings = append(s.Openings, opening)
+	}
+
+	result := make([]ProgramStats, 0, len(order))
+	for _, name := range order {
+		s := stats[name]
+		var sellOut []time.Duration
+		for _, opening := range s.Openings {
+			local := opening.Time.In(opts.Location)
+			s.ByWeekday[local.Weekday()]++
+			s.ByHour[local.Hour()]++
+			s.Heatmap[local.Weekday()][local.Hour()]++
+			if opening.SoldOut > 0 {
+				sellOut = append(sellOut, opening.SoldOut)
+			}
+		}
+		s.SellOut = distribution(sellOut)
+
+		// 기록을 시작할 때 이미 보이던 회차는 언제 공개됐는지 모르므로 제외
+		var leads []time.Duration
+		for start, seen := range firstSeen[name] {
+			if seen.After(firstChecked[name]) && start.After(seen) {
+				leads = append(leads, start.Sub(seen))
+			}
+		}
+		s.LeadTime = distribution(leads)
+		s.Windows = topWindows(s.Heatmap, minWindowOpenings, maxWindows)
+		result = append(result, *s)
+	}
+	return result
+}
+
+// topWindows returns the weekday/hours with at least min openings, most first
+func topWindows(heatmap [7][24]int, min, limit int) []Window {
+	var windows []Window
+	for day := range heatmap {
+		for hour, count := range heatmap[day] {
+			if count >= min {
+				windows = append(windows, Window{Weekday: time.Weekday(day), Hour: hour, Openings: count})
+			}
+		}
+	}
+	sort.SliceStable(windows, func(i, j int) bool { return windows[i].Openings > windows[j].Openings })
+	if limit > 0 && len(windows) > limit {
+		windows = windows[:limit]
+	}
+	return windows
+}
+
+// distribution computes percentiles of the durations
+func distribution(values []time.Duration) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	percentile := func(p float64) time.Duration {
+		return values[int(p*float64(len(values)-1)+0.5)]
+	}
+	return Distribution{
+		Count:  len(values),
+		Min:    values[0],
+		P25:    percentile(0.25),
+		Median: percentile(0.5),
+		P75:    percentile(0.75),
+		Max:    values[len(values)-1],
+	}
+}
+
+// Summary describes the program's pattern in one line,
+// 예: "주로 화 10시에 열림 (12회 중 5회) · 보통 7분 만에 마감 · 회차 약 30일 전 공개"
+func (s ProgramStats) Summary() string {
+	if len(s.Openings) == 0 {
+		return i18n.T("analytics.no_openings")
+	}
+
+	var parts []string
+	if len(s.Windows) > 0 {
+		w := s.Windows[0]
+		parts = append(parts, fmt.Sprintf(i18n.T("analytics.usually_opens"), w, len(s.Openings), w.Openings))
+	} else {
+		parts = append(parts, fmt.Sprintf(i18n.T("analytics.opened_times"), len(s.Openings)))
+	}
+	if s.SellOut.Count > 0 {
+		parts = append(parts, fmt.Sprintf(i18n.T("analytics.sells_out_in"), FormatDuration(s.SellOut.Median)))
+	}
+	if s.LeadTime.Count > 0 {
+		parts = append(parts, fmt.Sprintf(i18n.T("analytics.published_before"), FormatDuration(s.LeadTime.Median)))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// FormatDuration formats a duration roughly, in Korean units (예: 7분, 3시간 20분, 30일)
+func FormatDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf(i18n.T("analytics.duration.seconds"), int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf(i18n.T("analytics.duration.minutes"), int(d.Minutes()))
+	case d < 48*time.Hour:
+		hours := int(d.Hours())
+		if minutes := int(d.Minutes()) % 60; minutes > 0 {
+			return fmt.Sprintf(i18n.T("analytics.duration.hours_minutes"), hours, minutes)
+		}
+		return fmt.Sprintf(i18n.T("analytics.duration.hours"), hours)
+	default:
+		return fmt.Sprintf(i18n.T("analytics.duration.days"), int(d.Hours()/24+0.5))
+	}
+}
+
+// LearnedWindows returns every weekday/hour in which the programs together opened at least minOpenings times
+func LearnedWindows(stats []ProgramStats, minOpenings int) []Window {
+	var heatmap [7][24]int
+	for _, s := range stats {
+		for day := range s.Heatmap {
+			for hour, count := range s.Heatmap[day] {
+				heatmap[day][hour] += count
+			}
+		}
+	}
+	return topWindows(heatmap, max(minOpenings, 1), 0)
+}
+
+// InWindow reports whether t falls in one of the windows, or less than lead before one starts
+func InWindow(windows []Window, t time.Time, lead time.Duration) bool {
+	for _, probe := range []time.Time{t, t.Add(lead)} {
+		for _, w := range windows {
+			if probe.Weekday() == w.Weekday && probe.Hour() == w.Hour {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// UntilWindow returns how long until the next window starts (0 inside a window, -1 without windows)
+func UntilWindow(windows []Window, t time.Time) time.Duration {
+	next := time.Duration(-1)
+	for _, w := range windows {
+		days := (int(w.Weekday) - int(t.Weekday()) + 7) % 7
+		start := time.Date(t.Year(), t.Month(), t.Day()+days, w.Hour, 0, 0, 0, t.Location())
+		if !start.Add(time.Hour).After(t) {
+			start = start.AddDate(0, 0, 7)
+		}
+		wait := max(start.Sub(t), 0)
+		if next < 0 || wait < next {
+			next = wait
+		}
+	}
+	return next
+}
@@ -1,16 +1,23 @@
 package auth
 
 import (
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/logging"
+	"bmw-driving-center-alter/internal/session"
 	"bytes"
+	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"net/http/cookiejar"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 )
 
+var logger = logging.Component("auth")
+
 // LoginCredentials holds login information
 type LoginCredentials struct {
 	Username string `yaml:"username" json:"username"`
@@ -29,7 +36,7 @@ type AuthClient struct {
 func NewAuthClient(credentials LoginCredentials) (*AuthClient, error) {
 	jar, err := cookiejar.New(nil)
 	if err != nil {
-		return nil, fmt.Errorf("쿠키 저장소 생성 실패 (failed to create cookie jar): %w", err)
+		return nil, fmt.Errorf(i18n.T("auth.cookie_jar_failed"), err)
 	}
 
 	return &AuthClient{
@@ -51,14 +58,14 @@ func (a *AuthClient) Login() error {
 	// 실제로 복잡한 OAuth 플로우를 구현하는 대신,
 	// 로그인이 필요한 페이지에 접근 시 자동 리다이렉트를 활용합니다
 
-	log.Printf("BMW 드라이빙 센터 OAuth2 로그인 프로세스 시작...")
+	logger.Info(i18n.T("auth.oauth_start"))
 
 	// OAuth2 로그인 URL로 이동
 	oauthURL := a.baseURL + "/oauth2/authorization/gcdm?language=ko"
 
 	resp, err := a.client.Get(oauthURL)
 	if err != nil {
-		return fmt.Errorf("OAuth 로그인 페이지 접근 실패: %w", err)
+		return fmt.Errorf(i18n.T("auth.oauth_page_failed"), err)
 	}
 	defer resp.Body.Close()
 
@@ -67,7 +74,7 @@ func (a *AuthClient) Login() error {
 	// 2. 또는 세션 쿠키를 직접 설정
 
 	// 현재는 임시로 성공했다고 가정
-	log.Printf("OAuth2 로그인은 브라우저 자동화가 필요합니다. 현재 구현을 위해서는 Selenium 사용을 권장합니다.")
+	logger.Info(i18n.T("auth.oauth_needs_browser"))
 
 	// 실제 구현을 위한 주석:
 	// BMW 드라이빙 센터는 BMW 그룹의 통합 인증 시스템(GCDM)을 사용합니다
@@ -80,7 +87,7 @@ func (a *AuthClient) Login() error {
 	// 이 과정은 JavaScript 실행이 필요할 수 있어 headless browser가 필요할 수 있습니다
 
 	a.isLoggedIn = false
-	return fmt.Errorf("OAuth2 로그인은 현재 지원되지 않습니다. Selenium이나 수동 쿠키 설정이 필요합니다")
+	return errors.New(i18n.T("auth.oauth_unsupported"))
 }
 
 // extractCSRFToken extracts CSRF token from HTML
@@ -175,6 +182,50 @@ func (a *AuthClient) needsRelogin(resp *http.Response) bool {
 	return false
 }
 
+// ImportSession loads cookies exported from a logged-in browser into the HTTP client
+func (a *AuthClient) ImportSession(snap *session.Snapshot) error {
+	imported := 0
+	for _, origin := range []string{a.baseURL, "https://customer.bmwgroup.com"} {
+		originURL, err := url.Parse(origin)
+		if err != nil {
+			return fmt.Errorf(i18n.T("auth.invalid_url"), err)
+		}
+
+		var cookies []*http.Cookie
+		for _, cookie := range snap.Cookies {
+			if !cookie.MatchesHost(originURL.Hostname()) {
+				continue
+			}
+			httpCookie := &http.Cookie{
+				Name:   cookie.Name,
+				Value:  cookie.Value,
+				Domain: cookie.Domain,
+				Path:   cookie.Path,
+				Secure: cookie.Secure,
+			}
+			if cookie.Expiry != 0 {
+				httpCookie.Expires = time.Unix(cookie.Expiry, 0)
+			}
+			cookies = append(cookies, httpCookie)
+		}
+		a.client.Jar.SetCookies(originURL, cookies)
+		imported += len(cookies)
+	}
+
+	if imported == 0 {
+		return errors.New(i18n.T("auth.no_cookies"))
+	}
+
+	logger.Infof(i18n.T("auth.cookies_imported"), imported)
+	a.isLoggedIn = true
+	return nil
+}
+
+// HTTPClient returns the underlying HTTP client with its cookie jar
+func (a *AuthClient) HTTPClient() *http.Client {
+	return a.client
+}
+
 // IsLoggedIn returns login status
 func (a *AuthClient) IsLoggedIn() bool {
 	return a.isLoggedIn
@@ -1,13 +1,22 @@
 package browser
 
 import (
+	"bmw-driving-center-alter/internal/capture"
 	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/logging"
+	"bmw-driving-center-alter/internal/models"
+	"bmw-driving-center-alter/internal/pidfile"
+	"bmw-driving-center-alter/internal/scraper"
+	"bmw-driving-center-alter/internal/session"
 	"bmw-driving-center-alter/internal/solver"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -19,15 +28,50 @@ import (
 	"github.com/tebeka/selenium/chrome"
 )
 
+var logger = logging.Component("browser")
+
+// loginDomain is the BMW customer account (GCDM) login domain
+const loginDomain = "customer.bmwgroup.com"
+
+// profileDirName is the Chrome user data directory inside the state directory
+const profileDirName = "chrome-profile"
+
+// lockFileName is the PID lock file that keeps other processes out of the state directory
+const lockFileName = "instance.lock"
+
+// ErrSessionExpired is returned when a check is redirected to the login page
+var ErrSessionExpired = i18n.NewError("browser.session_expired")
+
+// SessionInfo describes the current login session
+type SessionInfo struct {
+	LoggedInAt time.Time // 로그인 또는 세션 확인 시각
+	ExpiresAt  time.Time // 세션 쿠키 중 가장 빠른 만료 시각 (브라우저 세션 쿠키만 있으면 zero)
+}
+
+// Age returns how long the session has been in use
+func (s SessionInfo) Age() time.Duration {
+	if s.LoggedInAt.IsZero() {
+		return 0
+	}
+	return time.Since(s.LoggedInAt)
+}
+
 // BrowserClient handles browser-based authentication and scraping using Selenium
 type BrowserClient struct {
 	driver           selenium.WebDriver
 	service          *selenium.Service
 	baseURL          string
 	stateDir         string
+	driverPort       int // 0이면 빈 포트 자동 선택
+	lock             *pidfile.File
 	isLoggedIn       bool
+	loggedInAt       time.Time
 	captchaSolver    solver.HCaptchaSolver
 	autoSolveCaptcha bool
+	recorder         *capture.Recorder
+	sessions         []models.Session // 마지막 확인에서 파싱한 회차
+	programLinks     map[string]string // 마지막 확인에서 찾은 프로그램별 예약 링크
+	headless         bool
 }
 
 // NewBrowserClient creates a new browser client with Selenium
@@ -37,14 +81,35 @@ func NewBrowserClient() (*BrowserClient, error) {
 
 // NewBrowserClientWithConfig creates a new browser client with configuration
 func NewBrowserClientWithConfig(cfg *config.Config) (*BrowserClient, error) {
-	// 세션 저장 디렉토리 설정
+	return newBrowserClient(cfg, AccountStateDir(config.DefaultAccountName))
+}
+
+// NewBrowserClientForAccount creates a browser client with an isolated profile for the given account
+func NewBrowserClientForAccount(cfg *config.Config, account config.AccountConfig) (*BrowserClient, error) {
+	return newBrowserClient(cfg, AccountStateDir(account.Name))
+}
+
+// AccountStateDir returns the browser state directory for an account.
+// 기본 계정은 기존 위치(~/.bmw-driving-center/browser-state)를 그대로 사용합니다.
+func AccountStateDir(name string) string {
 	homeDir, _ := os.UserHomeDir()
-	stateDir := filepath.Join(homeDir, ".bmw-driving-center", "browser-state")
-	
+	if name == "" || name == config.DefaultAccountName {
+		return filepath.Join(homeDir, ".bmw-driving-center", "browser-state")
+	}
+	return filepath.Join(homeDir, ".bmw-driving-center", "accounts", name, "browser-state")
+}
+
+// HasSavedProfile reports whether the account has a saved Chrome profile (previous login)
+func HasSavedProfile(name string) bool {
+	entries, err := os.ReadDir(filepath.Join(AccountStateDir(name), profileDirName))
+	return err == nil && len(entries) > 0
+}
+
+func newBrowserClient(cfg *config.Config, stateDir string) (*BrowserClient, error) {
 	// 디렉토리 생성
 	err := os.MkdirAll(stateDir, 0755)
 	if err != nil {
-		log.Printf("⚠️ 세션 디렉토리 생성 실패: %v", err)
+		logger.Warnf(i18n.T("browser.session_dir_failed"), err)
 	}
 
 	client := &BrowserClient{
@@ -80,31 +145,48 @@ func NewBrowserClientWithConfig(cfg *config.Config) (*BrowserClient, error) {
 	if apiKey != "" {
 		switch service {
 		case "solvecaptcha":
-			log.Println("🤖 SolveCaptcha 자동 hCaptcha 해결 활성화")
+			logger.Info(i18n.T("browser.solvecaptcha_enabled"))
 			client.captchaSolver = solver.NewSolveCaptchaSolver(apiKey)
 			client.autoSolveCaptcha = true
 		case "2captcha":
-			log.Println("🤖 2captcha 자동 hCaptcha 해결 활성화")
+			logger.Info(i18n.T("browser.twocaptcha_enabled"))
 			client.captchaSolver = solver.NewTwoCaptchaSolver(apiKey)
 			client.autoSolveCaptcha = true
 		default:
-			log.Printf("⚠️ 알 수 없는 captcha solver 서비스: %s", service)
+			logger.Warnf(i18n.T("browser.unknown_solver"), service)
 			client.captchaSolver = solver.NewManualSolver()
 		}
 	} else {
-		log.Println("🔑 Captcha solver API 키 없음 - 수동 hCaptcha 해결 모드")
-		log.Println("💡 자동 해결을 원하면 config.yaml에 설정하거나:")
-		log.Println("   - SolveCaptcha: export SOLVECAPTCHA_API_KEY=your_api_key")
-		log.Println("   - 2captcha: export TWOCAPTCHA_API_KEY=your_api_key")
+		logger.Info(i18n.T("browser.manual_captcha"))
+		logger.Info(i18n.T("browser.solver_hint"))
+		logger.Info("   - SolveCaptcha: export SOLVECAPTCHA_API_KEY=your_api_key")
+		logger.Info("   - 2captcha: export TWOCAPTCHA_API_KEY=your_api_key")
 		client.captchaSolver = solver.NewManualSolver()
 	}
 	
+	// 페이지 캡처 모드 (오프라인 디버깅용)
+	if cfg != nil && cfg.Capture.Enabled {
+		recorder, err := capture.NewRecorder(cfg.Capture)
+		if err != nil {
+			logger.Warnf(i18n.T("browser.capture_init_failed"), err)
+		} else {
+			logger.Infof(i18n.T("browser.capture_enabled"), recorder.Dir())
+			client.recorder = recorder
+		}
+	}
+	
 	return client, nil
 }
 
+// SetDriverPort sets a fixed ChromeDriver service port (기본값 0: 빈 포트 자동 선택)
+func (b *BrowserClient) SetDriverPort(port int) {
+	b.driverPort = port
+}
+
 // downloadChromeDriver downloads the latest ChromeDriver if needed
 func (b *BrowserClient) downloadChromeDriver() (string, error) {
-	driverDir := filepath.Join(b.stateDir, "drivers")
+	// 드라이버는 모든 계정이 공유
+	driverDir := filepath.Join(AccountStateDir(config.DefaultAccountName), "drivers")
 	os.MkdirAll(driverDir, 0755)
 	
 	// OS별 ChromeDriver 파일명
@@ -116,27 +198,27 @@ func (b *BrowserClient) downloadChromeDriver() (string, error) {
 	
 	// 이미 존재하면 사용
 	if _, err := os.Stat(driverPath); err == nil {
-		log.Printf("✅ ChromeDriver 이미 존재: %s", driverPath)
+		logger.Infof(i18n.T("browser.driver_exists"), driverPath)
 		return driverPath, nil
 	}
 	
-	log.Println("📥 ChromeDriver 다운로드 중...")
+	logger.Info(i18n.T("browser.driver_downloading"))
 	
 	// Chrome 버전 확인
 	chromeVersion, err := getChromeVersion()
 	if err != nil {
-		log.Printf("⚠️ Chrome 버전 확인 실패: %v", err)
+		logger.Warnf(i18n.T("browser.chrome_version_failed"), err)
 		chromeVersion = "stable"
 	}
 	
 	// ChromeDriver 다운로드 URL 생성
 	downloadURL := getChromeDriverURL(chromeVersion)
-	log.Printf("   다운로드 URL: %s", downloadURL)
+	logger.Debugf(i18n.T("browser.download_url"), downloadURL)
 	
 	// 다운로드
 	resp, err := http.Get(downloadURL)
 	if err != nil {
-		return "", fmt.Errorf("ChromeDriver 다운로드 실패: %w", err)
+		return "", fmt.Errorf(i18n.T("browser.driver_download_failed"), err)
 	}
 	defer resp.Body.Close()
 	
@@ -144,24 +226,24 @@ func (b *BrowserClient) downloadChromeDriver() (string, error) {
 	zipFile := filepath.Join(driverDir, "chromedriver.zip")
 	out, err := os.Create(zipFile)
 	if err != nil {
-		return "", fmt.Errorf("파일 생성 실패: %w", err)
+		return "", fmt.Errorf(i18n.T("browser.file_create_failed"), err)
 	}
 	
 	size, err := io.Copy(out, resp.Body)
 	out.Close()
 	if err != nil {
-		return "", fmt.Errorf("파일 저장 실패: %w", err)
+		return "", fmt.Errorf(i18n.T("browser.file_save_failed"), err)
 	}
-	log.Printf("   다운로드 완료: %d bytes", size)
+	logger.Debugf(i18n.T("browser.downloaded"), size)
 	
 	// 압축 해제
-	log.Println("   압축 해제 중...")
+	logger.Debug(i18n.T("browser.unzipping"))
 	cmd := exec.Command("unzip", "-o", zipFile, "-d", driverDir)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return "", fmt.Errorf("압축 해제 실패: %w\n출력: %s", err, string(output))
+		return "", fmt.Errorf(i18n.T("browser.unzip_failed"), err, string(output))
 	}
-	log.Printf("   압축 해제 완료: %s", string(output))
+	logger.Debugf(i18n.T("browser.unzipped"), string(output))
 	
 	// ZIP 파일 삭제
 	os.Remove(zipFile)
@@ -174,7 +256,7 @@ func (b *BrowserClient) downloadChromeDriver() (string, error) {
 				// 하위 디렉토리의 chromedriver를 상위로 이동
 				subDriverPath := filepath.Join(driverDir, entry.Name(), "chromedriver")
 				if _, err := os.Stat(subDriverPath); err == nil {
-					log.Printf("   ChromeDriver 발견: %s", subDriverPath)
+					logger.Debugf(i18n.T("browser.driver_found"), subDriverPath)
 					os.Rename(subDriverPath, driverPath)
 					os.RemoveAll(filepath.Join(driverDir, entry.Name()))
 					break
@@ -186,11 +268,11 @@ func (b *BrowserClient) downloadChromeDriver() (string, error) {
 	// 실행 권한 부여 (Unix 계열)
 	if runtime.GOOS != "windows" {
 		if err := os.Chmod(driverPath, 0755); err != nil {
-			log.Printf("⚠️ 실행 권한 설정 실패: %v", err)
+			logger.Warnf(i18n.T("browser.chmod_failed"), err)
 		}
 	}
 	
-	log.Printf("✅ ChromeDriver 다운로드 완료: %s", driverPath)
+	logger.Infof(i18n.T("browser.driver_downloaded"), driverPath)
 	return driverPath, nil
 }
 
@@ -226,12 +308,12 @@ func getChromeVersion() (string, error) {
 		}
 	}
 	
-	return "", fmt.Errorf("Chrome 버전을 파싱할 수 없음")
+	return "", errors.New(i18n.T("browser.chrome_version_parse_failed"))
 }
 
 // getChromeDriverURL returns the download URL for ChromeDriver
 func getChromeDriverURL(chromeVersion string) string {
-	log.Printf("   Chrome 버전 %s에 맞는 ChromeDriver 검색 중...", chromeVersion)
+	logger.Debugf(i18n.T("browser.driver_searching"), chromeVersion)
 	
 	// Chrome for Testing API 사용
 	apiURL := "https://googlechromelabs.github.io/chrome-for-testing/known-good-versions-with-downloads.json"
@@ -239,7 +321,7 @@ func getChromeDriverURL(chromeVersion string) string {
 	// API 호출
 	resp, err := http.Get(apiURL)
 	if err != nil {
-		log.Printf("⚠️ ChromeDriver API 호출 실패: %v", err)
+		logger.Warnf(i18n.T("browser.driver_api_failed"), err)
 		// 폴백 URL 반환
 		return getStableChromeDriverURL()
 	}
@@ -247,7 +329,7 @@ func getChromeDriverURL(chromeVersion string) string {
 	
 	var data map[string]interface{}
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		log.Printf("⚠️ API 응답 파싱 실패: %v", err)
+		logger.Warnf(i18n.T("browser.driver_api_parse_failed"), err)
 		return getStableChromeDriverURL()
 	}
 	
@@ -282,7 +364,7 @@ func getChromeDriverURL(chromeVersion string) string {
 	
 	// 일치하는 버전 찾음
 	if bestMatch != nil {
-		log.Printf("   ChromeDriver 버전 발견: %s", bestMatch["version"])
+		logger.Debugf(i18n.T("browser.driver_version_found"), bestMatch["version"])
 		downloads := bestMatch["downloads"].(map[string]interface{})
 		chromedriver := downloads["chromedriver"].([]interface{})
 		
@@ -291,20 +373,20 @@ func getChromeDriverURL(chromeVersion string) string {
 			download := item.(map[string]interface{})
 			if download["platform"] == platform {
 				url := download["url"].(string)
-				log.Printf("   다운로드 URL: %s", url)
+				logger.Debugf(i18n.T("browser.download_url"), url)
 				return url
 			}
 		}
 	}
 	
 	// 정확한 버전을 찾지 못한 경우 가장 가까운 버전 사용
-	log.Printf("⚠️ Chrome %s용 정확한 ChromeDriver를 찾지 못함, 대체 버전 사용", chromeVersion)
+	logger.Warnf(i18n.T("browser.driver_fallback"), chromeVersion)
 	
 	// Chrome 139용 직접 URL (하드코딩)
 	if chromeVersion == "139" {
 		baseURL := "https://storage.googleapis.com/chrome-for-testing-public/139.0.6812.58"
 		url := fmt.Sprintf("%s/%s/chromedriver-%s.zip", baseURL, platform, platform)
-		log.Printf("   Chrome 139용 대체 URL: %s", url)
+		logger.Debugf(i18n.T("browser.driver_fallback_url"), url)
 		return url
 	}
 	
@@ -337,28 +419,46 @@ func getStableChromeDriverURL() string {
 	return fmt.Sprintf("%s/%s/chromedriver-%s.zip", baseURL, platform, platform)
 }
 
-// Start launches the browser with Selenium
+// Start launches the browser with Selenium.
+// 다른 프로세스가 같은 상태 디렉토리를 사용 중이면 잠금을 가진 PID와 함께 *pidfile.LockedError를 반환합니다.
 func (b *BrowserClient) Start(headless bool) error {
+	// 같은 Chrome 프로필을 두 프로세스가 동시에 쓰지 않도록 상태 디렉토리 잠금
+	if b.lock == nil {
+		lock, err := pidfile.Acquire(filepath.Join(b.stateDir, lockFileName))
+		if err != nil {
+			return fmt.Errorf(i18n.T("browser.profile_lock_failed"), err)
+		}
+		b.lock = lock
+	}
+	
 	// ChromeDriver 다운로드/확인
 	driverPath, err := b.downloadChromeDriver()
 	if err != nil {
-		log.Printf("⚠️ ChromeDriver 자동 다운로드 실패: %v", err)
-		log.Println("수동으로 ChromeDriver를 설치해주세요: https://chromedriver.chromium.org/")
+		logger.Warnf(i18n.T("browser.driver_auto_download_failed"), err)
+		logger.Info(i18n.T("browser.driver_manual"))
 		// 시스템 PATH에서 찾기 시도
 		driverPath = "chromedriver"
 	}
 	
 	// Selenium 서비스 시작
 	seleniumPath := driverPath // ChromeDriver 경로 사용
-	port := 9515
+	port := b.driverPort
+	if port == 0 {
+		// 다른 모니터/GUI와 포트가 겹치지 않도록 빈 포트 사용
+		port, err = freePort()
+		if err != nil {
+			return fmt.Errorf(i18n.T("browser.driver_port_failed"), err)
+		}
+	}
 	
+	// ChromeDriver 자체 로그는 로그 디렉토리의 chromedriver.log로 (파일 로그가 꺼져 있으면 버림)
 	opts := []selenium.ServiceOption{
-		selenium.Output(nil), // 로그 비활성화
+		selenium.Output(logging.DriverOutput()),
 	}
 	
 	service, err := selenium.NewChromeDriverService(seleniumPath, port, opts...)
 	if err != nil {
-		return fmt.Errorf("ChromeDriver 서비스 시작 실패: %w", err)
+		return fmt.Errorf(i18n.T("browser.driver_service_failed"), err)
 	}
 	b.service = service
 	
@@ -409,12 +509,13 @@ func (b *BrowserClient) Start(headless bool) error {
 		W3C: false, // W3C 모드 비활성화 (레거시 모드 사용)
 	}
 	
+	b.headless = headless
 	if headless {
 		chromeCaps.Args = append(chromeCaps.Args, "--headless=new")
 	}
 	
 	// 사용자 데이터 디렉토리 설정 (세션 유지)
-	userDataDir := filepath.Join(b.stateDir, "chrome-profile")
+	userDataDir := filepath.Join(b.stateDir, profileDirName)
 	os.MkdirAll(userDataDir, 0755)
 	chromeCaps.Args = append(chromeCaps.Args, fmt.Sprintf("--user-data-dir=%s", userDataDir))
 	
@@ -424,7 +525,7 @@ func (b *BrowserClient) Start(headless bool) error {
 	// WebDriver 생성
 	wd, err := selenium.NewRemote(caps, fmt.Sprintf("http://localhost:%d/wd/hub", port))
 	if err != nil {
-		return fmt.Errorf("WebDriver 생성 실패: %w", err)
+		return fmt.Errorf(i18n.T("browser.webdriver_failed"), err)
 	}
 	b.driver = wd
 	
@@ -501,30 +602,30 @@ func (b *BrowserClient) Start(headless bool) error {
 		};
 	`
 	if _, err := b.driver.ExecuteScript(script, nil); err != nil {
-		log.Printf("⚠️ Stealth 스크립트 실행 실패: %v", err)
+		logger.Warnf(i18n.T("browser.stealth_failed"), err)
 	}
 	
-	log.Println("✅ Selenium WebDriver 시작 완료")
+	logger.Info(i18n.T("browser.webdriver_started"))
 	return nil
 }
 
 // CheckLoginStatus checks if already logged in
 func (b *BrowserClient) CheckLoginStatus() bool {
-	log.Println("🔍 로그인 상태 확인 중...")
+	logger.Info(i18n.T("browser.checking_login"))
 	
 	// 메인 페이지로 이동
-	log.Printf("1️⃣ BMW 드라이빙 센터 메인 페이지 접속: %s", b.baseURL)
+	logger.Infof(i18n.T("browser.open_main"), b.baseURL)
 	if err := b.driver.Get(b.baseURL); err != nil {
-		log.Printf("⚠️ 메인 페이지 접속 실패: %v", err)
+		logger.Warnf(i18n.T("browser.main_failed"), err)
 		return false
 	}
 	
 	time.Sleep(2 * time.Second)
 	
 	// 예약 페이지로 이동 시도
-	log.Println("2️⃣ 예약 페이지로 이동 시도...")
+	logger.Info(i18n.T("browser.open_reservation"))
 	if err := b.driver.Get(b.baseURL + "/orders/programs/products/view"); err != nil {
-		log.Printf("⚠️ 예약 페이지 이동 실패: %v", err)
+		logger.Warnf(i18n.T("browser.reservation_nav_failed"), err)
 		return false
 	}
 	
@@ -532,71 +633,74 @@ func (b *BrowserClient) CheckLoginStatus() bool {
 	
 	// 현재 URL 확인
 	currentURL, _ := b.driver.CurrentURL()
-	log.Printf("📍 현재 URL: %s", currentURL)
+	logger.Debugf(i18n.T("browser.current_url"), currentURL)
 	
 	// 로그인 페이지로 리다이렉트되지 않으면 로그인된 상태
 	if strings.Contains(currentURL, "driving-center.bmw.co.kr/orders") {
-		log.Println("✅ 이미 로그인되어 있음 (세션 유효)")
+		logger.Info(i18n.T("browser.already_logged_in"))
 		b.isLoggedIn = true
+		if b.loggedInAt.IsZero() {
+			b.loggedInAt = time.Now()
+		}
 		return true
 	}
 	
 	// customer.bmwgroup.com으로 리다이렉트되면 로그인 필요
-	if strings.Contains(currentURL, "customer.bmwgroup.com") {
-		log.Println("⚠️ 로그인 페이지로 리다이렉트됨 - 로그인 필요")
+	if strings.Contains(currentURL, loginDomain) {
+		logger.Warn(i18n.T("browser.redirected_login"))
 		b.isLoggedIn = false
 		return false
 	}
 	
-	log.Printf("⚠️ 예상치 못한 페이지: %s", currentURL)
+	logger.Warnf(i18n.T("browser.unexpected_page"), currentURL)
 	b.isLoggedIn = false
 	return false
 }
 
 // Login performs login to BMW Driving Center
 func (b *BrowserClient) Login(username, password string) error {
-	log.Println("===== BMW 드라이빙 센터 로그인 시작 =====")
+	logger.Info(i18n.T("browser.login_start"))
 	
 	// 현재 페이지 URL 확인
 	currentURL, _ := b.driver.CurrentURL()
-	log.Printf("📍 현재 페이지: %s", currentURL)
+	logger.Debugf(i18n.T("browser.current_page"), currentURL)
 	
 	// 로그인 페이지가 아니면 이동
-	if !strings.Contains(currentURL, "customer.bmwgroup.com") {
+	if !strings.Contains(currentURL, loginDomain) {
 		// 로그인 상태 재확인
 		if b.CheckLoginStatus() {
-			log.Println("🎉 이미 로그인됨")
+			logger.Info(i18n.T("browser.logged_in"))
 			return nil
 		}
 		
 		// OAuth 로그인 페이지로 이동
 		oauthURL := b.baseURL + "/oauth2/authorization/gcdm?language=ko"
-		log.Printf("OAuth URL로 이동: %s", oauthURL)
+		logger.Debugf(i18n.T("browser.oauth_url"), oauthURL)
 		if err := b.driver.Get(oauthURL); err != nil {
-			return fmt.Errorf("OAuth 페이지 이동 실패: %w", err)
+			return fmt.Errorf(i18n.T("browser.oauth_nav_failed"), err)
 		}
 		
 		// 리다이렉트 대기
 		time.Sleep(3 * time.Second)
 		currentURL, _ = b.driver.CurrentURL()
-		log.Printf("📍 리다이렉트 후 URL: %s", currentURL)
+		logger.Debugf(i18n.T("browser.redirect_url"), currentURL)
 	}
 	
-	log.Println("✅ BMW 고객 계정 로그인 페이지 감지")
+	logger.Info(i18n.T("browser.login_page_detected"))
 	
 	// 쿠키 확인
 	cookies, _ := b.driver.GetCookies()
-	log.Printf("🍪 현재 쿠키 개수: %d", len(cookies))
+	logger.Debugf(i18n.T("browser.cookie_count"), len(cookies))
 	
 	// localStorage 확인
 	if storedParams, err := b.driver.ExecuteScript(`
 		return localStorage.getItem('storedParameters');
 	`, nil); err == nil && storedParams != nil {
-		log.Printf("📦 localStorage.storedParameters: %v", storedParams)
+		logger.Debugf("📦 localStorage.storedParameters: %v", storedParams)
 	}
 	
 	// ==== STEP 1: 이메일 입력 ====
-	log.Println("\n===== STEP 1: 이메일 입력 =====")
+	logger.Info(i18n.T("browser.step_email"))
 	
 	// 이메일 필드 찾기 (visible만)
 	emailField, err := b.driver.FindElement(selenium.ByCSSSelector, "input#email:not([type='hidden'])")
@@ -604,22 +708,22 @@ func (b *BrowserClient) Login(username, password string) error {
 		// 폴백: name으로 찾기
 		emailField, err = b.driver.FindElement(selenium.ByName, "email")
 		if err != nil {
-			return fmt.Errorf("이메일 필드를 찾을 수 없음: %w", err)
+			return fmt.Errorf(i18n.T("browser.email_field_missing"), err)
 		}
 	}
 	
 	// 이메일 입력
-	log.Printf("이메일 입력: %s", username)
+	logger.Debugf(i18n.T("browser.entering_email"), username)
 	if err := emailField.Clear(); err != nil {
-		log.Printf("⚠️ 필드 클리어 실패: %v", err)
+		logger.Warnf(i18n.T("browser.clear_failed"), err)
 	}
 	if err := emailField.SendKeys(username); err != nil {
-		return fmt.Errorf("이메일 입력 실패: %w", err)
+		return fmt.Errorf(i18n.T("browser.email_failed"), err)
 	}
-	log.Println("✅ 이메일 입력 완료")
+	logger.Info(i18n.T("browser.email_entered"))
 	
 	// ==== STEP 2: "계속" 버튼 클릭 ====
-	log.Println("\n===== STEP 2: '계속' 버튼 클릭 =====")
+	logger.Info(i18n.T("browser.step_continue"))
 	
 	time.Sleep(1 * time.Second)
 	
@@ -628,44 +732,44 @@ func (b *BrowserClient) Login(username, password string) error {
 	if err != nil {
 		continueBtn, err = b.driver.FindElement(selenium.ByXPATH, "//button[contains(text(), '계속')]")
 		if err != nil {
-			return fmt.Errorf("계속 버튼을 찾을 수 없음: %w", err)
+			return fmt.Errorf(i18n.T("browser.continue_missing"), err)
 		}
 	}
 	
 	// 버튼 클릭
-	log.Println("버튼 클릭...")
+	logger.Debug(i18n.T("browser.clicking"))
 	if err := continueBtn.Click(); err != nil {
-		return fmt.Errorf("계속 버튼 클릭 실패: %w", err)
+		return fmt.Errorf(i18n.T("browser.continue_failed"), err)
 	}
-	log.Println("✅ 버튼 클릭 성공")
+	logger.Info(i18n.T("browser.clicked"))
 	
 	// 비밀번호 화면 대기
 	time.Sleep(2 * time.Second)
 	
 	// ==== STEP 3: 비밀번호 입력 ====
-	log.Println("\n===== STEP 3: 비밀번호 입력 =====")
+	logger.Info(i18n.T("browser.step_password"))
 	
 	// 비밀번호 필드 찾기
 	passwordField, err := b.driver.FindElement(selenium.ByCSSSelector, "input#password:not([type='hidden'])")
 	if err != nil {
 		passwordField, err = b.driver.FindElement(selenium.ByName, "password")
 		if err != nil {
-			return fmt.Errorf("비밀번호 필드를 찾을 수 없음: %w", err)
+			return fmt.Errorf(i18n.T("browser.password_field_missing"), err)
 		}
 	}
 	
 	// 비밀번호 입력
-	log.Println("비밀번호 입력...")
+	logger.Debug(i18n.T("browser.entering_password"))
 	if err := passwordField.Clear(); err != nil {
-		log.Printf("⚠️ 필드 클리어 실패: %v", err)
+		logger.Warnf(i18n.T("browser.clear_failed"), err)
 	}
 	if err := passwordField.SendKeys(password); err != nil {
-		return fmt.Errorf("비밀번호 입력 실패: %w", err)
+		return fmt.Errorf(i18n.T("browser.password_failed"), err)
 	}
-	log.Println("✅ 비밀번호 입력 완료")
+	logger.Info(i18n.T("browser.password_entered"))
 	
 	// ==== STEP 4: 로그인 버튼 클릭 ====
-	log.Println("\n===== STEP 4: 로그인 버튼 클릭 =====")
+	logger.Info(i18n.T("browser.step_login"))
 	
 	time.Sleep(1 * time.Second)
 	
@@ -674,69 +778,70 @@ func (b *BrowserClient) Login(username, password string) error {
 	if err != nil {
 		loginBtn, err = b.driver.FindElement(selenium.ByXPATH, "//button[contains(text(), '로그인')]")
 		if err != nil {
-			return fmt.Errorf("로그인 버튼을 찾을 수 없음: %w", err)
+			return fmt.Errorf(i18n.T("browser.login_button_missing"), err)
 		}
 	}
 	
 	// 버튼 클릭
-	log.Println("로그인 버튼 클릭...")
+	logger.Debug(i18n.T("browser.clicking_login"))
 	if err := loginBtn.Click(); err != nil {
-		return fmt.Errorf("로그인 버튼 클릭 실패: %w", err)
+		return fmt.Errorf(i18n.T("browser.login_click_failed"), err)
 	}
-	log.Println("✅ 로그인 버튼 클릭 성공")
+	logger.Info(i18n.T("browser.login_clicked"))
 	
 	// ==== 로그인 처리 대기 ====
-	log.Println("\n===== 로그인 처리 대기 =====")
+	logger.Info(i18n.T("browser.waiting_login"))
 	for i := 0; i < 15; i++ {
 		time.Sleep(1 * time.Second)
 		currentURL, _ := b.driver.CurrentURL()
-		log.Printf("[%d초] 현재 URL: %s", i+1, currentURL)
+		logger.Debugf(i18n.T("browser.waiting_url"), i+1, currentURL)
 		
 		if strings.Contains(currentURL, "driving-center.bmw.co.kr") {
-			log.Println("\n🎉🎉 로그인 성공! BMW 드라이빙 센터로 리다이렉트됨 🎉🎉")
+			logger.Info(i18n.T("browser.login_ok"))
 			
 			// 로그인 직후 바로 CAPTCHA 확인!!! (아무것도 하지 않고)
-			log.Println("\n🔍 로그인 직후 즉시 CAPTCHA 확인 중...")
+			logger.Info(i18n.T("browser.captcha_check_after_login"))
 			time.Sleep(2 * time.Second) // 페이지 안정화를 위한 최소 대기
 			
 			if b.checkForCaptcha() {
-				log.Println("\n🚨🚨🚨 로그인 직후 hCAPTCHA 감지됨! 🚨🚨🚨")
-				log.Println("⚠️ CAPTCHA를 먼저 해결해야 합니다!")
+				logger.Warn(i18n.T("browser.captcha_after_login"))
+				logger.Warn(i18n.T("browser.captcha_first"))
 				
 				// CAPTCHA 해결 대기
 				if !b.waitForCaptchaSolution(300) { // 5분 대기
-					return fmt.Errorf("로그인 후 CAPTCHA 해결 실패")
+					return errors.New(i18n.T("browser.captcha_after_login_failed"))
 				}
-				log.Println("✅ CAPTCHA 해결 완료!")
+				logger.Info(i18n.T("browser.captcha_solved"))
 			} else {
-				log.Println("✅ CAPTCHA 없음 - 정상 진행")
+				logger.Info(i18n.T("browser.no_captcha"))
 			}
 			
 			// CAPTCHA 처리 후에만 다른 작업 수행
 			// 로그인 후 쿠키 확인
 			cookies, _ := b.driver.GetCookies()
-			log.Printf("🍪 로그인 후 쿠키 개수: %d", len(cookies))
+			logger.Debugf(i18n.T("browser.cookies_after_login"), len(cookies))
 			
 			// 메인 페이지로 이동하여 세션 안정화
-			log.Println("🏠 메인 페이지로 이동하여 세션 확인...")
+			logger.Info(i18n.T("browser.verify_session"))
 			if err := b.driver.Get(b.baseURL); err != nil {
-				log.Printf("⚠️ 메인 페이지 이동 실패: %v", err)
+				logger.Warnf(i18n.T("browser.main_nav_failed"), err)
 			}
 			time.Sleep(2 * time.Second)
 			
 			b.isLoggedIn = true
+			b.loggedInAt = time.Now()
 			return nil
 		}
 		
 		// hCaptcha 확인
 		if i == 5 || i == 10 {
 			if b.checkForCaptcha() {
-				log.Println("⏳ CAPTCHA 해결 대기 중...")
+				logger.Info(i18n.T("browser.waiting_captcha"))
 				// CAPTCHA가 사라질 때까지 추가 대기
 				for j := 0; j < 30; j++ {
 					time.Sleep(1 * time.Second)
 					if !b.checkForCaptcha() {
-						log.Println("✅ CAPTCHA 해결됨")
+						logger.Info(i18n.T("browser.captcha_done"))
 						break
 					}
 				}
@@ -744,7 +849,7 @@ func (b *BrowserClient) Login(username, password string) error {
 		}
 	}
 	
-	return fmt.Errorf("로그인 실패 - 타임아웃")
+	return errors.New(i18n.T("browser.login_timeout"))
 }
 
 // checkForCaptcha checks if hCaptcha is present
@@ -847,21 +952,19 @@ func (b *BrowserClient) checkForCaptcha() bool {
 	}
 	
 	if captchaDetected {
-		log.Println("\n")
-		log.Println("🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨")
-		log.Println("🚨                                                  🚨")
-		log.Println("🚨           hCAPTCHA 감지됨!!!                    🚨")
-		log.Println("🚨                                                  🚨")
-		log.Println("🚨   🖱️  브라우저 창을 확인하세요!                  🚨")
-		log.Println("🚨   ✅ CAPTCHA를 수동으로 해결해주세요!           🚨")
-		log.Println("🚨   ⏳ 해결 후 자동으로 진행됩니다...             🚨")
-		log.Println("🚨                                                  🚨")
-		log.Println("🚨   👉 잠시만 기다려주세요!!!                     🚨")
-		log.Println("🚨   👉 프로그램이 계속 실행 중입니다!!!           🚨")
-		log.Println("🚨   👉 종료하지 마세요!!!                         🚨")
-		log.Println("🚨                                                  🚨")
-		log.Println("🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨")
-		log.Println("\n")
+		logger.Warn("🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨")
+		logger.Warn("🚨                                                  🚨")
+		logger.Warn(i18n.T("browser.banner.detected"))
+		logger.Warn("🚨                                                  🚨")
+		logger.Warn(i18n.T("browser.banner.check_window"))
+		logger.Warn(i18n.T("browser.banner.solve"))
+		logger.Warn(i18n.T("browser.banner.continue"))
+		logger.Warn("🚨                                                  🚨")
+		logger.Warn(i18n.T("browser.banner.wait"))
+		logger.Warn(i18n.T("browser.banner.running"))
+		logger.Warn(i18n.T("browser.banner.no_exit"))
+		logger.Warn("🚨                                                  🚨")
+		logger.Warn("🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨🚨")
 	}
 	
 	return captchaDetected
@@ -871,7 +974,7 @@ func (b *BrowserClient) checkForCaptcha() bool {
 func (b *BrowserClient) waitForCaptchaSolution(timeoutSeconds int) bool {
 	// Try auto-solving first if enabled
 	if b.autoSolveCaptcha && b.captchaSolver != nil {
-		log.Println("🤖 hCaptcha 자동 해결 시도 중...")
+		logger.Info(i18n.T("browser.auto_solving"))
 		
 		// Extract sitekey from page
 		siteKey := b.extractSiteKey()
@@ -882,23 +985,23 @@ func (b *BrowserClient) waitForCaptchaSolution(timeoutSeconds int) bool {
 			if err == nil && solution != "" {
 				// Inject solution into page
 				if b.injectCaptchaSolution(solution) {
-					log.Println("✅ hCaptcha 자동 해결 성공!")
+					logger.Info(i18n.T("browser.auto_solved"))
 					time.Sleep(2 * time.Second)
 					return true
 				}
 			}
-			log.Println("⚠️ 자동 해결 실패, 수동 모드로 전환")
+			logger.Warn(i18n.T("browser.auto_failed"))
 		}
 	}
 	
 	// Manual solving fallback
-	log.Println("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	log.Println("⏸️  프로그램 일시 정지 - hCAPTCHA 해결 필요")
-	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	log.Println("👆 브라우저 창에서 CAPTCHA를 해결해주세요")
-	log.Printf("⏱️  최대 %d초간 대기합니다...", timeoutSeconds)
-	log.Println("💡 TIP: 체크박스를 클릭하거나 이미지를 선택하세요")
-	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	logger.Info("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	logger.Info(i18n.T("browser.paused_captcha"))
+	logger.Info("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	logger.Info(i18n.T("browser.solve_in_browser"))
+	logger.Infof(i18n.T("browser.waiting_max"), timeoutSeconds)
+	logger.Info(i18n.T("browser.captcha_tip"))
+	logger.Info("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	
 	for i := 0; i < timeoutSeconds; i++ {
 		time.Sleep(1 * time.Second)
@@ -907,7 +1010,7 @@ func (b *BrowserClient) waitForCaptchaSolution(timeoutSeconds int) bool {
 		if i%10 == 0 && i > 0 {
 			// CAPTCHA가 사라졌는지 확인
 			if !b.checkForCaptchaQuiet() {
-				log.Println("\n✅ CAPTCHA 해결 완료!")
+				logger.Info(i18n.T("browser.captcha_solved"))
 				time.Sleep(2 * time.Second) // 페이지 전환 대기
 				return true
 			}
@@ -915,12 +1018,12 @@ func (b *BrowserClient) waitForCaptchaSolution(timeoutSeconds int) bool {
 			// 진행 상황 표시
 			if i%30 == 0 {
 				remaining := timeoutSeconds - i
-				log.Printf("⏳ CAPTCHA 대기 중... (남은 시간: %d초)", remaining)
+				logger.Infof(i18n.T("browser.captcha_waiting"), remaining)
 			}
 		}
 	}
 	
-	log.Printf("\n⏱️ CAPTCHA 해결 시간 초과 (%d초)", timeoutSeconds)
+	logger.Infof(i18n.T("browser.captcha_timeout"), timeoutSeconds)
 	return false
 }
 
@@ -964,7 +1067,7 @@ func (b *BrowserClient) extractSiteKey() string {
 		for _, div := range divs {
 			siteKey, err := div.GetAttribute("data-sitekey")
 			if err == nil && siteKey != "" {
-				log.Printf("🔑 hCaptcha sitekey 발견: %s", siteKey)
+				logger.Infof(i18n.T("browser.sitekey_found"), siteKey)
 				return siteKey
 			}
 		}
@@ -982,14 +1085,14 @@ func (b *BrowserClient) extractSiteKey() string {
 					if ampIdx := strings.Index(siteKey, "&"); ampIdx != -1 {
 						siteKey = siteKey[:ampIdx]
 					}
-					log.Printf("🔑 hCaptcha sitekey 발견 (iframe): %s", siteKey)
+					logger.Infof(i18n.T("browser.sitekey_found_iframe"), siteKey)
 					return siteKey
 				}
 			}
 		}
 	}
 	
-	log.Println("⚠️ hCaptcha sitekey를 찾을 수 없음")
+	logger.Warn(i18n.T("browser.sitekey_missing"))
 	return ""
 }
 
@@ -1014,93 +1117,337 @@ func (b *BrowserClient) injectCaptchaSolution(token string) bool {
 	
 	_, err := b.driver.ExecuteScript(script, nil)
 	if err != nil {
-		log.Printf("⚠️ 솔루션 주입 실패: %v", err)
+		logger.Warnf(i18n.T("browser.inject_failed"), err)
 		return false
 	}
 	
-	log.Println("✅ hCaptcha 솔루션 주입 성공")
+	logger.Info(i18n.T("browser.injected"))
 	return true
 }
 
 
 // CheckReservationPageWithCaptchaAlert checks the reservation page
-func (b *BrowserClient) CheckReservationPageWithCaptchaAlert(programs []string) (map[string]bool, bool, error) {
-	log.Println("📋 예약 페이지 확인 시작...")
+func (b *BrowserClient) CheckReservationPageWithCaptchaAlert(programs []models.Program) (map[string]bool, bool, error) {
+	logger.Info(i18n.T("browser.check_start"))
 	
 	// 현재 URL 확인
 	currentURL, _ := b.driver.CurrentURL()
-	log.Printf("   현재 URL: %s", currentURL)
+	logger.Debugf(i18n.T("browser.current_url_debug"), currentURL)
 	
 	// 예약 페이지가 아닌 경우에만 이동
 	if !strings.Contains(currentURL, "/orders/programs/products/view") {
-		log.Println("📋 예약 페이지로 이동...")
+		logger.Info(i18n.T("browser.going_reservation"))
 		if err := b.driver.Get(b.baseURL + "/orders/programs/products/view"); err != nil {
-			return nil, false, fmt.Errorf("예약 페이지 이동 실패: %w", err)
+			return nil, false, fmt.Errorf(i18n.T("browser.reservation_failed"), err)
 		}
 		
-		log.Println("⏳ 페이지 로딩 대기 중... (3초)")
+		logger.Info(i18n.T("browser.loading_3s"))
 		time.Sleep(3 * time.Second)
 	} else {
 		// 이미 예약 페이지에 있는 경우 새로고침
-		log.Println("🔄 예약 페이지 새로고침...")
+		logger.Info(i18n.T("browser.refreshing"))
 		if err := b.driver.Refresh(); err != nil {
-			log.Printf("⚠️ 페이지 새로고침 실패: %v", err)
+			logger.Warnf(i18n.T("browser.refresh_failed"), err)
 		}
-		log.Println("⏳ 페이지 로딩 대기 중... (2초)")
+		logger.Info(i18n.T("browser.loading_2s"))
 		time.Sleep(2 * time.Second)
 	}
 	
 	// 페이지 로딩 후 URL 다시 확인
 	currentURL, _ = b.driver.CurrentURL()
-	log.Printf("   이동 후 URL: %s", currentURL)
+	logger.Debugf(i18n.T("browser.url_after"), currentURL)
+	
+	// 로그인 페이지로 리다이렉트되면 세션 만료 (모두 예약 불가로 보고하지 않도록 오류 반환)
+	if strings.Contains(currentURL, loginDomain) {
+		logger.Warn(i18n.T("browser.redirected_expired"))
+		b.isLoggedIn = false
+		return nil, false, ErrSessionExpired
+	}
 	
 	// 페이지 내용 가져오기
 	pageSource, err := b.driver.PageSource()
 	if err != nil {
-		return nil, false, fmt.Errorf("페이지 내용 가져오기 실패: %w", err)
+		return nil, false, fmt.Errorf(i18n.T("browser.page_source_failed"), err)
 	}
 	
-	result := make(map[string]bool)
-	for _, program := range programs {
-		// 프로그램 존재 및 예약 가능 여부 확인
-		if strings.Contains(pageSource, program) {
-			// 매진/마감 확인
-			isSoldOut := strings.Contains(pageSource, program+".*매진") || 
-			            strings.Contains(pageSource, program+".*마감")
-			result[program] = !isSoldOut
-		} else {
-			result[program] = false
-		}
+	result := scraper.ParseProgramAvailability(pageSource, programs)
+	
+	// 회차(날짜/시간) 파싱 - 달력 내보내기 등에 사용
+	b.sessions = scraper.ParseSessions(pageSource, programs, currentURL)
+	b.programLinks = scraper.ParseProgramLinks(pageSource, programs, currentURL)
+	
+	if b.recorder != nil {
+		b.recordCapture(currentURL, programs, pageSource, result)
 	}
 	
 	// CAPTCHA는 이제 로그인 직후에만 확인하므로 여기서는 false 반환
 	return result, false, nil
 }
 
+// recordCapture stores the fetched page source with its parse result
+func (b *BrowserClient) recordCapture(url string, programs []models.Program, pageSource string, result map[string]bool) {
+	inputs := make([]capture.ProgramInput, 0, len(programs))
+	for _, program := range programs {
+		inputs = append(inputs, capture.ProgramInput{Name: program.Name, Keywords: program.Keywords})
+	}
+	
+	rec := &capture.Record{
+		Source:    capture.SourceBrowser,
+		URL:       url,
+		FetchedAt: time.Now(),
+		Programs:  inputs,
+		Result:    result,
+		Page:      pageSource,
+	}
+	if err := b.recorder.Save(rec); err != nil {
+		logger.Warnf(i18n.T("browser.capture_save_failed"), err)
+	}
+}
+
+// LastSessions returns the sessions parsed by the last reservation page check
+func (b *BrowserClient) LastSessions() []models.Session {
+	return b.sessions
+}
+
+// LastProgramLinks returns the booking links of the programs found by the last reservation page check
+func (b *BrowserClient) LastProgramLinks() map[string]string {
+	return b.programLinks
+}
+
+// Headless reports whether the browser runs without a visible window
+func (b *BrowserClient) Headless() bool {
+	return b.headless
+}
+
+// Open shows a page in the browser window, for a person to take over (예: 예약 마무리).
+// 다음 확인 때 예약 페이지로 돌아가므로 그동안 확인을 멈춰야 합니다.
+func (b *BrowserClient) Open(pageURL string) error {
+	if b.driver == nil {
+		return errors.New(i18n.T("browser.not_started"))
+	}
+	if err := b.driver.Get(pageURL); err != nil {
+		return fmt.Errorf(i18n.T("browser.open_failed"), err)
+	}
+	return nil
+}
+
 // CheckReservationPage checks the reservation page (backward compatibility)
-func (b *BrowserClient) CheckReservationPage(programs []string) (map[string]bool, error) {
+func (b *BrowserClient) CheckReservationPage(programs []models.Program) (map[string]bool, error) {
 	result, _, err := b.CheckReservationPageWithCaptchaAlert(programs)
 	return result, err
 }
 
+// RefreshSession renews the session by re-running the OAuth flow.
+// GCDM 세션이 살아있으면 자동으로 재발급되고, 로그인 페이지가 나오면 Login을 수행합니다.
+func (b *BrowserClient) RefreshSession(username, password string) error {
+	oauthURL := b.baseURL + "/oauth2/authorization/gcdm?language=ko"
+	logger.Infof(i18n.T("browser.refreshing_session"), oauthURL)
+	if err := b.driver.Get(oauthURL); err != nil {
+		return fmt.Errorf(i18n.T("browser.oauth_nav_failed"), err)
+	}
+	time.Sleep(3 * time.Second)
+	
+	currentURL, _ := b.driver.CurrentURL()
+	if strings.Contains(currentURL, loginDomain) {
+		return b.Login(username, password)
+	}
+	if strings.Contains(currentURL, "driving-center.bmw.co.kr") {
+		logger.Info(i18n.T("browser.session_refreshed"))
+		b.isLoggedIn = true
+		b.loggedInAt = time.Now()
+		return nil
+	}
+	
+	return fmt.Errorf(i18n.T("browser.refresh_unexpected"), currentURL)
+}
+
+// SessionInfo returns the session age and the expiry time taken from session cookies
+func (b *BrowserClient) SessionInfo() SessionInfo {
+	info := SessionInfo{LoggedInAt: b.loggedInAt}
+	if b.driver == nil {
+		return info
+	}
+	
+	cookies, err := b.driver.GetCookies()
+	if err != nil {
+		return info
+	}
+	
+	for _, cookie := range cookies {
+		if cookie.Expiry == 0 || !isSessionCookie(cookie.Name) {
+			continue
+		}
+		expiresAt := time.Unix(int64(cookie.Expiry), 0)
+		if info.ExpiresAt.IsZero() || expiresAt.Before(info.ExpiresAt) {
+			info.ExpiresAt = expiresAt
+		}
+	}
+	
+	return info
+}
+
+// isSessionCookie reports whether a cookie is related to the login session
+func isSessionCookie(name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, "session") ||
+		strings.Contains(name, "auth") ||
+		strings.Contains(name, "token") ||
+		strings.Contains(name, "gcdm")
+}
+
 // SaveSession saves the current browser session
 func (b *BrowserClient) SaveSession() error {
 	// Selenium with Chrome user-data-dir automatically saves session
-	log.Println("✅ 세션은 Chrome 프로필에 자동 저장됨")
+	logger.Info(i18n.T("browser.session_saved"))
+	return nil
+}
+
+// StateDir returns the browser state directory of this client
+func (b *BrowserClient) StateDir() string {
+	return b.stateDir
+}
+
+// sessionOrigins returns the origins whose cookies and localStorage make up a login session
+func (b *BrowserClient) sessionOrigins() []string {
+	return []string{b.baseURL, "https://" + loginDomain}
+}
+
+// ExportSession collects the Driving Center and GCDM cookies plus localStorage of the logged-in browser
+func (b *BrowserClient) ExportSession() (*session.Snapshot, error) {
+	snap := &session.Snapshot{
+		ExportedAt:   time.Now(),
+		LocalStorage: make(map[string]map[string]string),
+	}
+	
+	for _, origin := range b.sessionOrigins() {
+		logger.Infof(i18n.T("browser.exporting"), origin)
+		if err := b.driver.Get(origin + "/"); err != nil {
+			return nil, fmt.Errorf(i18n.T("browser.nav_failed"), origin, err)
+		}
+		time.Sleep(2 * time.Second)
+		
+		cookies, err := b.driver.GetCookies()
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("browser.cookies_failed"), err)
+		}
+		for _, cookie := range cookies {
+			snap.Cookies = append(snap.Cookies, session.Cookie{
+				Name:   cookie.Name,
+				Value:  cookie.Value,
+				Domain: cookie.Domain,
+				Path:   cookie.Path,
+				Secure: cookie.Secure,
+				Expiry: int64(cookie.Expiry),
+			})
+		}
+		
+		// localStorage (storedParameters 등)
+		storage, err := b.driver.ExecuteScript(`return JSON.stringify(Object.assign({}, localStorage));`, nil)
+		if err != nil {
+			logger.Warnf(i18n.T("browser.local_storage_read_failed"), err)
+			continue
+		}
+		if text, ok := storage.(string); ok {
+			items := make(map[string]string)
+			if err := json.Unmarshal([]byte(text), &items); err == nil && len(items) > 0 {
+				snap.LocalStorage[origin] = items
+			}
+		}
+	}
+	
+	logger.Infof(i18n.T("browser.exported"), len(snap.Cookies), len(snap.LocalStorage))
+	return snap, nil
+}
+
+// ImportSession loads cookies and localStorage from a snapshot into the browser
+func (b *BrowserClient) ImportSession(snap *session.Snapshot) error {
+	for _, origin := range b.sessionOrigins() {
+		originURL, err := url.Parse(origin)
+		if err != nil {
+			continue
+		}
+		
+		// 쿠키는 해당 도메인 페이지에 있을 때만 추가할 수 있음
+		logger.Infof(i18n.T("browser.importing"), origin)
+		if err := b.driver.Get(origin + "/"); err != nil {
+			return fmt.Errorf(i18n.T("browser.nav_failed"), origin, err)
+		}
+		time.Sleep(2 * time.Second)
+		
+		added := 0
+		for _, cookie := range snap.Cookies {
+			if !cookie.MatchesHost(originURL.Hostname()) {
+				continue
+			}
+			if cookie.Expiry != 0 && time.Unix(cookie.Expiry, 0).Before(time.Now()) {
+				continue // 만료된 쿠키
+			}
+			err := b.driver.AddCookie(&selenium.Cookie{
+				Name:   cookie.Name,
+				Value:  cookie.Value,
+				Domain: cookie.Domain,
+				Path:   cookie.Path,
+				Secure: cookie.Secure,
+				Expiry: uint(cookie.Expiry),
+			})
+			if err != nil {
+				logger.Warnf(i18n.T("browser.cookie_add_failed"), cookie.Name, err)
+				continue
+			}
+			added++
+		}
+		
+		for key, value := range snap.LocalStorage[origin] {
+			if _, err := b.driver.ExecuteScript(`localStorage.setItem(arguments[0], arguments[1]);`, []interface{}{key, value}); err != nil {
+				logger.Warnf(i18n.T("browser.local_storage_set_failed"), key, err)
+			}
+		}
+		logger.Debugf(i18n.T("browser.applied"), added, len(snap.LocalStorage[origin]))
+	}
+	
 	return nil
 }
 
+// ApplyPendingSession imports a session saved by "session import" into this browser.
+// 가져온 세션이 없으면 false를 반환합니다.
+func (b *BrowserClient) ApplyPendingSession() (bool, error) {
+	snap, err := session.LoadPending(b.stateDir)
+	if err != nil || snap == nil {
+		return false, err
+	}
+	
+	logger.Infof(i18n.T("browser.imported"), snap.ExportedAt.Local().Format("2006-01-02 15:04"))
+	if err := b.ImportSession(snap); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // Close closes the browser
 func (b *BrowserClient) Close() error {
 	if b.driver != nil {
 		if err := b.driver.Quit(); err != nil {
-			log.Printf("⚠️ WebDriver 종료 오류: %v", err)
+			logger.Warnf(i18n.T("browser.webdriver_quit_failed"), err)
 		}
 	}
 	if b.service != nil {
 		if err := b.service.Stop(); err != nil {
-			log.Printf("⚠️ Selenium 서비스 종료 오류: %v", err)
+			logger.Warnf(i18n.T("browser.service_stop_failed"), err)
 		}
 	}
+	if b.lock != nil {
+		b.lock.Release()
+		b.lock = nil
+	}
 	return nil
+}
+
+// freePort returns a TCP port on localhost that is currently unused
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
 }
\ No newline at end of file
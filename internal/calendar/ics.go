@@ -0,0 +1,109 @@
+package calendar
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/models"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType is the MIME type of an iCalendar document
+const ContentType = "text/calendar; charset=utf-8"
+
+const (
+	prodID        = "-//bmw-driving-center-alter//monitor//KO"
+	uidDomain     = "bmw-driving-center-alter"
+	maxLineOctets = 75 // RFC 5545 3.1 - CRLF 제외 한 줄 최대 길이
+	utcFormat     = "20060102T150405Z"
+	dateFormat    = "20060102"
+)
+
+// refreshInterval is how often subscribed calendars should re-fetch the feed
+const refreshInterval = "PT5M"
+
+// Render builds an iCalendar (RFC 5545) document with one event per session
+func Render(name string, sessions []models.Session, now time.Time) []byte {
+	var w writer
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + prodID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	w.line("X-WR-CALNAME:" + escapeText(name))
+	w.line("REFRESH-INTERVAL;VALUE=DURATION:" + refreshInterval) // RFC 7986
+	w.line("X-PUBLISHED-TTL:" + refreshInterval)
+
+	stamp := now.UTC().Format(utcFormat)
+	for _, session := range sessions {
+		label := programLabel(session.Program)
+
+		w.line("BEGIN:VEVENT")
+		w.line("UID:" + UID(session))
+		w.line("DTSTAMP:" + stamp)
+		if session.AllDay {
+			w.line("DTSTART;VALUE=DATE:" + session.Start.Format(dateFormat))
+			w.line("DTEND;VALUE=DATE:" + session.Start.AddDate(0, 0, 1).Format(dateFormat))
+		} else {
+			w.line("DTSTART:" + session.Start.UTC().Format(utcFormat))
+			if !session.End.IsZero() {
+				w.line("DTEND:" + session.End.UTC().Format(utcFormat))
+			}
+		}
+		w.line("SUMMARY:" + escapeText(i18n.T("calendar.summary")+label))
+		description := fmt.Sprintf(i18n.T("calendar.event_description"),
+			label, session.URL, now.Format("2006-01-02 15:04"))
+		w.line("DESCRIPTION:" + escapeText(description))
+		if session.URL != "" {
+			w.line("URL:" + session.URL)
+		}
+		w.line("LOCATION:" + escapeText(i18n.T("calendar.location")))
+		w.line("TRANSP:TRANSPARENT") // 일정 확인용 - 바쁨으로 표시하지 않음
+		w.line("END:VEVENT")
+	}
+
+	w.line("END:VCALENDAR")
+	return []byte(w.String())
+}
+
+// UID returns a stable event identifier, so re-imports update instead of duplicating
+func UID(session models.Session) string {
+	sum := sha1.Sum([]byte(session.Key()))
+	return hex.EncodeToString(sum[:10]) + "@" + uidDomain
+}
+
+// programLabel returns "Name (한국어 이름)" when the name is localized
+func programLabel(name string) string {
+	return i18n.ProgramLabel(name)
+}
+
+// escapeText escapes a TEXT value (RFC 5545 3.3.11)
+func escapeText(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(text)
+}
+
+// writer emits CRLF-terminated content lines folded at 75 octets
+type writer struct {
+	strings.Builder
+}
+
+func (w *writer) line(content string) {
+	limit := maxLineOctets
+	for len(content) > limit {
+		// UTF-8 문자 중간에서 자르지 않도록
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.WriteString(content[:cut])
+		w.WriteString("\r\n ")
+		content = content[cut:]
+		limit = maxLineOctets - 1 // 이어지는 줄은 앞의 공백 포함
+	}
+	w.WriteString(content)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,175 @@
+package capture
+
+import (
+	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/i18n"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Capture sources - 어떤 파서로 결과를 만들었는지 구분
+const (
+	SourceBrowser = "browser" // BrowserClient (프로그램 이름 매칭)
+	SourceScraper = "scraper" // scraper.Scraper (키워드 매칭)
+)
+
+const (
+	fileSuffix      = ".json.gz"
+	defaultMaxFiles = 500
+)
+
+// Record represents a single fetched page with its parse result
+type Record struct {
+	Source    string          `json:"source"`
+	URL       string          `json:"url"`
+	FetchedAt time.Time       `json:"fetched_at"`
+	Programs  []ProgramInput  `json:"programs"`
+	Result    map[string]bool `json:"result"`
+	Page      string          `json:"page"`
+}
+
+// ProgramInput is the parser input recorded with each capture
+type ProgramInput struct {
+	Name     string   `json:"name"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// Recorder stores captures as compressed files and rotates old ones
+type Recorder struct {
+	dir      string
+	maxFiles int
+	mu       sync.Mutex
+}
+
+// DefaultDir returns the default capture directory
+func DefaultDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".bmw-driving-center", "captures")
+}
+
+// NewRecorder creates a new capture recorder
+func NewRecorder(cfg config.CaptureConfig) (*Recorder, error) {
+	dir := cfg.Dir
+	if dir == "" {
+		dir = DefaultDir()
+	}
+	maxFiles := cfg.MaxFiles
+	if maxFiles <= 0 {
+		maxFiles = defaultMaxFiles
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf(i18n.T("capture.dir_failed"), err)
+	}
+
+	return &Recorder{
+		dir:      dir,
+		maxFiles: maxFiles,
+	}, nil
+}
+
+// Dir returns the capture directory
+func (r *Recorder) Dir() string {
+	return r.dir
+}
+
+// Save writes a record to disk and removes the oldest captures beyond the limit
+func (r *Recorder) Save(rec *Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name := fmt.Sprintf("capture-%s-%s%s",
+		rec.FetchedAt.Format("20060102-150405.000"), rec.Source, fileSuffix)
+	path := filepath.Join(r.dir, name)
+
+	if err := writeRecord(path, rec); err != nil {
+		return err
+	}
+
+	return r.rotate()
+}
+
+// rotate deletes the oldest captures so that at most maxFiles remain
+func (r *Recorder) rotate() error {
+	files, err := List(r.dir)
+	if err != nil {
+		return err
+	}
+
+	for len(files) > r.maxFiles {
+		if err := os.Remove(files[0]); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf(i18n.T("capture.prune_failed"), err)
+		}
+		files = files[1:]
+	}
+
+	return nil
+}
+
+func writeRecord(path string, rec *Record) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf(i18n.T("capture.create_failed"), err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	if err := json.NewEncoder(gz).Encode(rec); err != nil {
+		gz.Close()
+		return fmt.Errorf(i18n.T("capture.encode_failed"), err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf(i18n.T("capture.compress_failed"), err)
+	}
+
+	return nil
+}
+
+// Load reads a single capture file
+func Load(path string) (*Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("capture.open_failed"), err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("capture.decompress_failed"), err)
+	}
+	defer gz.Close()
+
+	var rec Record
+	if err := json.NewDecoder(gz).Decode(&rec); err != nil {
+		return nil, fmt.Errorf(i18n.T("capture.parse_failed"), err)
+	}
+
+	return &rec, nil
+}
+
+// List returns capture files in a directory, oldest first
+func List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("capture.read_dir_failed"), err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), fileSuffix) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, entry.Name()))
+	}
+
+	// 파일명에 타임스탬프가 들어있으므로 이름순 = 시간순
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,173 @@
+package claims
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// keepAfterStart is how long a claim is kept after its session has started
+const keepAfterStart = 24 * time.Hour
+
+// ErrClaimed is returned when someone else already claimed the session
+var ErrClaimed = i18n.NewError("claims.already_claimed")
+
+// Claim records who took on booking a session
+type Claim struct {
+	Session   string    `json:"session"` // models.Session.ID()
+	Program   string    `json:"program"`
+	Account   string    `json:"account,omitempty"`
+	Start     time.Time `json:"start"`
+	By        string    `json:"by"`
+	ClaimedAt time.Time `json:"claimed_at"`
+	AlertID   string    `json:"alert_id,omitempty"`
+}
+
+// Store keeps the claims in a JSON file so every process (CLI status, GUI) sees them
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// DefaultPath returns the default claims file path
+func DefaultPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".bmw-driving-center", "claims.json")
+}
+
+// NewStore creates a claims store (empty path = default path)
+func NewStore(path string) *Store {
+	if path == "" {
+		path = DefaultPath()
+	}
+	return &Store{path: path}
+}
+
+// Claim records the claim, unless the session is already claimed by someone else.
+// 같은 사람이 다시 등록하면 기존 기록을 그대로 반환합니다.
+func (s *Store) Claim(claim Claim) (Claim, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	claims, err := s.load()
+	if err != nil {
+		return Claim{}, err
+	}
+	if existing, ok := claims[claim.Session]; ok {
+		if existing.By != claim.By {
+			return existing, fmt.Errorf("%w (%s)", ErrClaimed, existing.By)
+		}
+		return existing, nil
+	}
+
+	if claim.ClaimedAt.IsZero() {
+		claim.ClaimedAt = time.Now()
+	}
+	claims[claim.Session] = claim
+	return claim, s.save(claims)
+}
+
+// Release removes the claim of a session. 담당자 본인만 취소할 수 있습니다.
+func (s *Store) Release(session, by string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	claims, err := s.load()
+	if err != nil {
+		return err
+	}
+	existing, ok := claims[session]
+	if !ok {
+		return nil
+	}
+	if existing.By != by {
+		return fmt.Errorf(i18n.T("claims.not_owner"), existing.By)
+	}
+	delete(claims, session)
+	return s.save(claims)
+}
+
+// Get returns the claim of a session
+func (s *Store) Get(session string) (Claim, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	claims, err := s.load()
+	if err != nil {
+		return Claim{}, false
+	}
+	claim, ok := claims[session]
+	return claim, ok
+}
+
+// List returns the current claims, earliest session first
+func (s *Store) List() ([]Claim, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	claims, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]Claim, 0, len(claims))
+	for _, claim := range claims {
+		list = append(list, claim)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Start.Before(list[j].Start) })
+	return list, nil
+}
+
+// load reads the claims file, dropping claims of sessions that are long over
+func (s *Store) load() (map[string]Claim, error) {
+	claims := make(map[string]Claim)
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return claims, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("claims.read_failed"), err)
+	}
+
+	var list []Claim
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf(i18n.T("claims.parse_failed"), err)
+	}
+	for _, claim := range list {
+		if !claim.Start.IsZero() && time.Since(claim.Start) > keepAfterStart {
+			continue
+		}
+		claims[claim.Session] = claim
+	}
+	return claims, nil
+}
+
+// save writes the claims through a temporary file so readers never see a partial file
+func (s *Store) save(claims map[string]Claim) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf(i18n.T("claims.dir_failed"), err)
+	}
+
+	list := make([]Claim, 0, len(claims))
+	for _, claim := range claims {
+		list = append(list, claim)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Start.Before(list[j].Start) })
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return fmt.Errorf(i18n.T("claims.encode_failed"), err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf(i18n.T("claims.save_failed"), err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf(i18n.T("claims.save_failed"), err)
+	}
+	return nil
+}
@@ -1,21 +1,35 @@
 package config
 
 import (
+	"bmw-driving-center-alter/internal/i18n"
 	"bmw-driving-center-alter/internal/models"
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the application configuration
 type Config struct {
+	Version       int                 `yaml:"version"`            // 설정 파일 형식 버전 (CurrentVersion, 예전 파일은 Load에서 자동 변환)
+	Language      string              `yaml:"language,omitempty"` // auto (OS 언어), ko, en
 	Auth          AuthConfig          `yaml:"auth"`
+	Accounts      []AccountConfig     `yaml:"accounts,omitempty"`
 	Monitor       MonitorConfig       `yaml:"monitor"`
 	Programs      []models.Program    `yaml:"programs"`
 	Email         EmailConfig         `yaml:"email"`
 	CaptchaSolver CaptchaSolverConfig `yaml:"captcha_solver,omitempty"`
+	Capture       CaptureConfig       `yaml:"capture,omitempty"`
+	Logging       LoggingConfig       `yaml:"logging,omitempty"`
+	Server        ServerConfig        `yaml:"server,omitempty"`
+	Notify        NotifyConfig        `yaml:"notify,omitempty"`
+	Telegram      TelegramConfig      `yaml:"telegram,omitempty"`
 }
 
 // AuthConfig represents authentication settings
@@ -24,14 +38,41 @@ type AuthConfig struct {
 	Password string `yaml:"password"`
 }
 
+// DefaultAccountName is the name of the account built from the top-level auth/programs settings
+const DefaultAccountName = "default"
+
+var accountNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// AccountConfig represents a BMW account monitored with its own browser profile
+type AccountConfig struct {
+	Name       string           `yaml:"name"`                 // 계정 식별 이름 (상태 디렉토리 이름으로 사용)
+	Username   string           `yaml:"username"`             // BMW ID
+	Password   string           `yaml:"password"`             // BMW 비밀번호
+	Programs   []models.Program `yaml:"programs"`             // 이 계정으로 모니터링할 프로그램
+	Recipients []string         `yaml:"recipients,omitempty"` // 알림 수신자 (비어있으면 email.to 사용)
+}
+
 // MonitorConfig represents monitoring settings
 type MonitorConfig struct {
 	Interval        int    `yaml:"interval"`          // in seconds
 	ReservationURL  string `yaml:"reservation_url"`   // 예약 페이지 URL
 	ProgramListURL  string `yaml:"program_list_url"`  // 프로그램 목록 URL
 	Headless        bool   `yaml:"headless,omitempty"` // 브라우저 숨김 여부 (true: 숨김, false: 표시)
+	Boost           BoostConfig `yaml:"boost,omitempty"` // 자주 열리던 시간대에 더 자주 확인
 }
 
+// BoostConfig checks more often around the weekdays and hours in which programs
+// have usually opened, learned from the check history
+type BoostConfig struct {
+	Enabled     bool `yaml:"enabled,omitempty"`
+	Interval    int  `yaml:"interval,omitempty"`     // 그 시간대의 확인 간격(초) (기본 60, 최소 30)
+	MinOpenings int  `yaml:"min_openings,omitempty"` // 같은 요일/시간대에 이만큼 열린 적이 있어야 적용 (기본 3)
+	Days        int  `yaml:"days,omitempty"`         // 학습에 쓸 기록 기간(일) (기본 90)
+}
+
+// minBoostInterval keeps the boosted polling from hammering the site
+const minBoostInterval = 30
+
 // EmailConfig represents email notification settings
 type EmailConfig struct {
 	SMTP SMTPConfig `yaml:"smtp"`
@@ -54,6 +95,231 @@ type CaptchaSolverConfig struct {
 	APIKey  string `yaml:"api_key,omitempty"`
 }
 
+// CaptureConfig represents page capture (record-and-replay) settings
+type CaptureConfig struct {
+	Enabled  bool   `yaml:"enabled,omitempty"`   // 가져온 페이지 저장 여부
+	Dir      string `yaml:"dir,omitempty"`       // 저장 위치 (비어있으면 ~/.bmw-driving-center/captures)
+	MaxFiles int    `yaml:"max_files,omitempty"` // 보관할 최대 파일 수 (기본 500)
+}
+
+// LoggingConfig represents log level, format and log file settings
+type LoggingConfig struct {
+	Level      string `yaml:"level,omitempty"`       // debug, info, warn, error (기본 info)
+	Format     string `yaml:"format,omitempty"`      // text 또는 json (기본 text)
+	File       string `yaml:"file,omitempty"`        // 로그 파일 (비어있으면 ~/.bmw-driving-center/logs/monitor.log, "off"면 기록 안 함)
+	MaxSizeMB  int    `yaml:"max_size_mb,omitempty"` // 이 크기를 넘으면 회전 (기본 10MB)
+	MaxBackups int    `yaml:"max_backups,omitempty"` // 보관할 이전 파일 수 (기본 5)
+}
+
+// ServerConfig represents the local HTTP server (calendar feed) settings
+type ServerConfig struct {
+	Listen    string `yaml:"listen,omitempty"`     // 주소 (예: 127.0.0.1:8765), 비어있으면 서버를 열지 않음
+	PublicURL string        `yaml:"public_url,omitempty"` // 알림 이메일의 링크에 쓸 주소 (예: http://192.168.0.10:8765)
+	Webhook   WebhookConfig `yaml:"webhook,omitempty"`    // 외부 시스템용 /api 엔드포인트 인증
+	DashboardPassword string `yaml:"dashboard_password,omitempty"` // 웹 대시보드 비밀번호 (HTTP 기본 인증, 비어있으면 인증 없음)
+}
+
+// minWebhookKeyLength is the shortest accepted webhook token or secret
+const minWebhookKeyLength = 16
+
+// WebhookConfig enables the authenticated /api endpoints for external systems.
+// 둘 다 비어있으면 /api 엔드포인트는 꺼집니다.
+type WebhookConfig struct {
+	Token  string `yaml:"token,omitempty"`  // Authorization: Bearer <token>
+	Secret string `yaml:"secret,omitempty"` // HMAC-SHA256 서명 키 (X-Timestamp, X-Signature 헤더)
+}
+
+// Enabled reports whether any webhook authentication is configured
+func (w WebhookConfig) Enabled() bool {
+	return w.Token != "" || w.Secret != ""
+}
+
+// BaseURL returns the address used in links to the server, or "" when the server is off
+func (s ServerConfig) BaseURL() string {
+	if s.Listen == "" {
+		return ""
+	}
+	if s.PublicURL != "" {
+		return strings.TrimRight(s.PublicURL, "/")
+	}
+	host, port, err := net.SplitHostPort(s.Listen)
+	if err != nil {
+		return "http://" + s.Listen
+	}
+	// 모든 주소에서 받는 경우 이 컴퓨터 기준 주소 (다른 컴퓨터용 링크는 public_url 설정)
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port)
+}
+
+// NotifyConfig represents the notification policy: quiet hours, digests and escalation
+type NotifyConfig struct {
+	QuietHours    QuietHoursConfig `yaml:"quiet_hours,omitempty"`
+	DigestMinutes int              `yaml:"digest_minutes,omitempty"` // 중요도 low 알림을 모아 보내는 간격 (기본 60분)
+	Escalation    []EscalationStep `yaml:"escalation,omitempty"`     // 확인(ack)이 없을 때 차례로 보낼 대상
+	LastSeats     int              `yaml:"last_seats,omitempty"`     // 회차 잔여석이 이 수 이하로 줄면 "마지막 좌석" 알림 (0이면 끔)
+}
+
+// QuietHoursConfig is a daily time window in which only high-severity openings are sent right away
+type QuietHoursConfig struct {
+	Start    string `yaml:"start,omitempty"`    // 시작 시각 (예: 23:00)
+	End      string `yaml:"end,omitempty"`      // 끝 시각 (예: 07:00, 시작보다 이르면 다음 날)
+	Timezone string `yaml:"timezone,omitempty"` // 시간대 (예: Asia/Seoul, 비어있으면 시스템 시간대)
+}
+
+// Enabled reports whether quiet hours are configured
+func (q QuietHoursConfig) Enabled() bool {
+	return q.Start != "" && q.End != ""
+}
+
+// EscalationStep sends an unacknowledged alert to more recipients after a delay
+type EscalationStep struct {
+	AfterMinutes int      `yaml:"after_minutes"` // 첫 알림 후 이 시간(분) 동안 확인이 없으면
+	Recipients   []string `yaml:"recipients"`    // 이 수신자에게 전송
+}
+
+// TelegramConfig represents the Telegram bot that sends alerts and takes commands.
+// token이 비어있으면 봇을 시작하지 않습니다.
+type TelegramConfig struct {
+	Token   string  `yaml:"token,omitempty"`    // BotFather에서 받은 봇 토큰
+	ChatIDs []int64 `yaml:"chat_ids,omitempty"` // 명령을 받고 알림을 보낼 채팅 (그 외 채팅은 무시)
+	APIURL  string  `yaml:"api_url,omitempty"`  // Bot API 주소 (비어있으면 https://api.telegram.org)
+}
+
+// Enabled reports whether the Telegram bot is configured
+func (t TelegramConfig) Enabled() bool {
+	return t.Token != ""
+}
+
+// GetAccounts returns the accounts to monitor.
+// accounts가 비어있으면 기존 auth/programs 설정으로 단일 계정을 구성합니다.
+func (c *Config) GetAccounts() []AccountConfig {
+	if len(c.Accounts) > 0 {
+		return c.Accounts
+	}
+
+	return []AccountConfig{{
+		Name:     DefaultAccountName,
+		Username: c.Auth.Username,
+		Password: c.Auth.Password,
+		Programs: c.Programs,
+	}}
+}
+
+// RecipientsFor returns the notification recipients for an account
+func (c *Config) RecipientsFor(account AccountConfig) []string {
+	if len(account.Recipients) > 0 {
+		return account.Recipients
+	}
+	return c.Email.To
+}
+
+// Validate checks the configuration for errors that would prevent monitoring
+func (c *Config) Validate() error {
+	seen := make(map[string]bool)
+	for i, account := range c.GetAccounts() {
+		if account.Name == "" {
+			return fmt.Errorf(i18n.T("config.account_no_name"), i+1)
+		}
+		if !accountNamePattern.MatchString(account.Name) {
+			return fmt.Errorf(i18n.T("config.account_bad_name"), account.Name)
+		}
+		if seen[account.Name] {
+			return fmt.Errorf(i18n.T("config.account_duplicate"), account.Name)
+		}
+		seen[account.Name] = true
+
+		if account.Username == "" || account.Password == "" {
+			return fmt.Errorf(i18n.T("config.account_no_auth"), account.Name)
+		}
+		if len(account.Programs) == 0 {
+			return fmt.Errorf(i18n.T("config.account_no_programs"), account.Name)
+		}
+		for _, program := range account.Programs {
+			if err := program.Filter.Validate(); err != nil {
+				return fmt.Errorf(i18n.T("config.account_filter"), account.Name, program.Name, err)
+			}
+			switch program.Severity {
+			case "", models.SeverityHigh, models.SeverityNormal, models.SeverityLow:
+			default:
+				return fmt.Errorf(i18n.T("config.account_severity"), account.Name, program.Name)
+			}
+		}
+	}
+
+	if !i18n.ValidLanguage(c.Language) {
+		return fmt.Errorf(i18n.T("config.language"), c.Language)
+	}
+
+	switch strings.ToLower(c.Logging.Level) {
+	case "", "debug", "info", "warn", "warning", "error":
+	default:
+		return fmt.Errorf(i18n.T("config.logging_level"), c.Logging.Level)
+	}
+	if c.Logging.Format != "" && c.Logging.Format != "text" && c.Logging.Format != "json" {
+		return fmt.Errorf(i18n.T("config.logging_format"), c.Logging.Format)
+	}
+
+	if boost := c.Monitor.Boost; boost.Interval != 0 && boost.Interval < minBoostInterval {
+		return fmt.Errorf(i18n.T("config.boost_interval"), minBoostInterval)
+	} else if boost.MinOpenings < 0 || boost.Days < 0 {
+		return errors.New(i18n.T("config.boost_values"))
+	}
+
+	if err := c.Notify.validate(); err != nil {
+		return err
+	}
+	for _, key := range []string{c.Server.Webhook.Token, c.Server.Webhook.Secret} {
+		if key != "" && len(key) < minWebhookKeyLength {
+			return fmt.Errorf(i18n.T("config.webhook_length"), minWebhookKeyLength)
+		}
+	}
+	if c.Telegram.Enabled() && len(c.Telegram.ChatIDs) == 0 {
+		return errors.New(i18n.T("config.telegram_chat_ids"))
+	}
+
+	return nil
+}
+
+func (n NotifyConfig) validate() error {
+	quiet := n.QuietHours
+	if (quiet.Start == "") != (quiet.End == "") {
+		return errors.New(i18n.T("config.quiet_hours_both"))
+	}
+	for _, clock := range []string{quiet.Start, quiet.End} {
+		if clock == "" {
+			continue
+		}
+		if _, err := time.Parse("15:04", clock); err != nil {
+			return fmt.Errorf(i18n.T("config.quiet_hours_format"), clock)
+		}
+	}
+	if quiet.Timezone != "" {
+		if _, err := time.LoadLocation(quiet.Timezone); err != nil {
+			return fmt.Errorf(i18n.T("config.quiet_hours_timezone"), quiet.Timezone, err)
+		}
+	}
+	if n.DigestMinutes < 0 {
+		return errors.New(i18n.T("config.digest_minutes"))
+	}
+	if n.LastSeats < 0 {
+		return errors.New(i18n.T("config.last_seats"))
+	}
+	for i, step := range n.Escalation {
+		if step.AfterMinutes <= 0 {
+			return fmt.Errorf(i18n.T("config.escalation_after"), i+1)
+		}
+		if len(step.Recipients) == 0 {
+			return fmt.Errorf(i18n.T("config.escalation_recipients"), i+1)
+		}
+		if i > 0 && step.AfterMinutes <= n.Escalation[i-1].AfterMinutes {
+			return fmt.Errorf(i18n.T("config.escalation_order"), i+1)
+		}
+	}
+	return nil
+}
+
 // GetConfigPath finds the configuration file path
 func GetConfigPath() string {
 	// 1. 실행 파일과 같은 디렉토리의 configs/config.yaml
@@ -104,17 +370,52 @@ func Load(path string) (*Config, error) {
 	
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("설정 파일 읽기 실패 (failed to read config file): %w", err)
+		return nil, fmt.Errorf(i18n.T("config.read_failed"), err)
+	}
+
+	// 예전 형식의 파일은 백업 후 현재 형식으로 변환
+	data, err = migrateFile(path, data)
+	if err != nil {
+		return nil, err
 	}
 
 	var cfg Config
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("설정 파일 파싱 실패 (failed to parse config file): %w", err)
+		return nil, fmt.Errorf(i18n.T("config.parse_failed"), err)
 	}
 
 	return &cfg, nil
 }
 
+// Update loads the config file, applies update, validates and saves it.
+// 실행 중인 설정과 별개로 파일을 다시 읽으므로 프로필, 환경 변수, CLI 옵션으로 덮어쓴 값은 저장되지 않습니다.
+func Update(path string, update func(cfg *Config) error) error {
+	cfg, err := Load(path)
+	if err != nil {
+		return err
+	}
+	if err := update(cfg); err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	return Save(path, cfg)
+}
+
+// Clone returns a deep copy of the configuration, for changing it without touching the original
+func (c *Config) Clone() (*Config, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("config.copy_failed"), err)
+	}
+	var clone Config
+	if err := yaml.Unmarshal(data, &clone); err != nil {
+		return nil, fmt.Errorf(i18n.T("config.copy_failed"), err)
+	}
+	return &clone, nil
+}
+
 // Save saves the configuration to file
 func Save(path string, cfg *Config) error {
 	// 경로가 비어있으면 자동 탐색
@@ -125,16 +426,17 @@ func Save(path string, cfg *Config) error {
 	// 디렉토리 생성
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("디렉토리 생성 실패: %w", err)
+		return fmt.Errorf(i18n.T("config.dir_failed"), err)
 	}
 	
+	cfg.Version = CurrentVersion
 	data, err := yaml.Marshal(cfg)
 	if err != nil {
-		return fmt.Errorf("설정 직렬화 실패: %w", err)
+		return fmt.Errorf(i18n.T("config.encode_failed"), err)
 	}
 	
 	if err := os.WriteFile(path, data, 0644); err != nil {
-		return fmt.Errorf("설정 파일 저장 실패: %w", err)
+		return fmt.Errorf(i18n.T("config.save_failed"), err)
 	}
 	
 	return nil
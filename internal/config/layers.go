@@ -0,0 +1,451 @@
+package config
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config values are merged from these layers, later ones winning:
+//
+//	default < file (config.yaml) < profile (config.<name>.yaml) < env (BMW_MONITOR_*) < flag
+//
+// 프로필 파일에는 바꿀 값만 적으면 되고, 섹션(맵)은 키 단위로 합쳐지며 목록은 통째로 바뀝니다.
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceProfile = "profile"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+)
+
+// EnvPrefix starts the environment variables that override config values: the key in
+// upper case with "_" between the parts (monitor.interval → BMW_MONITOR_MONITOR_INTERVAL)
+const EnvPrefix = "BMW_MONITOR_"
+
+// ProfileEnv selects the profile when none is given on the command line
+const ProfileEnv = EnvPrefix + "PROFILE"
+
+// Source is where a config value came from
+type Source struct {
+	Kind   string `json:"kind"`             // default, file, profile, env, flag
+	Detail string `json:"detail,omitempty"` // 파일 경로, 환경 변수 또는 플래그 이름
+}
+
+func (s Source) String() string {
+	if s.Detail == "" {
+		return s.Kind
+	}
+	return s.Kind + " " + s.Detail
+}
+
+// Override is a config value given outside the config files, like a command line flag
+type Override struct {
+	Key    string // 설정 키 (예: monitor.headless)
+	Value  string
+	Source string // 출처 표시 (예: -headless)
+}
+
+// Layers selects what is merged over the config file
+type Layers struct {
+	Profile string     // 프로필 이름 (비어있으면 BMW_MONITOR_PROFILE)
+	Env     []string   // KEY=value 목록 (nil이면 os.Environ(), 빈 목록이면 환경 변수 무시)
+	Flags   []Override // 명령줄 플래그 (가장 우선)
+}
+
+// Effective is the config merged from every layer, with the source of each value
+type Effective struct {
+	Config     *Config
+	Path       string
+	Profile    string
+	Sources    map[string]Source // 키 → 출처 (목록은 목록 키 하나에 기록)
+	UnknownEnv []string          // BMW_MONITOR_로 시작하지만 설정 키가 아닌 환경 변수 (오타 확인용)
+}
+
+// Value is one effective config value for display
+type Value struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source Source `json:"source"`
+}
+
+// setting is a config key that can be set from the environment or the command line
+type setting struct {
+	key  string
+	kind reflect.Kind
+	list bool // 쉼표로 구분한 목록 ([]string, []int64)
+}
+
+// LoadEffective loads the config file and merges the profile, environment variables
+// and flags over it. 설정 파일을 저장할 때는 합쳐진 값이 아니라 Load로 읽은 파일 값을 사용해야 합니다.
+func LoadEffective(path string, layers Layers) (*Effective, error) {
+	if path == "" {
+		path = GetConfigPath()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("config.read_failed"), err)
+	}
+	if data, err = migrateFile(path, data); err != nil {
+		return nil, err
+	}
+	root, err := parseRoot(data)
+	if err != nil {
+		return nil, err
+	}
+
+	eff := &Effective{Path: path, Sources: make(map[string]Source)}
+	markSources(eff.Sources, "", root, Source{Kind: SourceFile, Detail: path})
+
+	env := layers.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	vars := make(map[string]string)
+	for _, entry := range env {
+		if name, value, ok := strings.Cut(entry, "="); ok && strings.HasPrefix(name, EnvPrefix) {
+			vars[name] = value
+		}
+	}
+
+	profile := layers.Profile
+	if profile == "" {
+		profile = vars[ProfileEnv]
+	}
+	if profile != "" {
+		if err := mergeProfile(root, path, profile, eff.Sources); err != nil {
+			return nil, err
+		}
+		eff.Profile = profile
+	}
+
+	settings := settingKeys()
+	known := map[string]bool{ProfileEnv: true}
+	for _, s := range settings {
+		name := EnvName(s.key)
+		known[name] = true
+		value, ok := vars[name]
+		if !ok {
+			continue
+		}
+		if err := setValue(root, s, value); err != nil {
+			return nil, fmt.Errorf(i18n.T("config.env_invalid"), name, err)
+		}
+		markSources(eff.Sources, s.key, nil, Source{Kind: SourceEnv, Detail: name})
+	}
+	for name := range vars {
+		if !known[name] {
+			eff.UnknownEnv = append(eff.UnknownEnv, name)
+		}
+	}
+	sort.Strings(eff.UnknownEnv)
+
+	for _, flag := range layers.Flags {
+		s, ok := findSetting(settings, flag.Key)
+		if !ok {
+			return nil, fmt.Errorf(i18n.T("config.unknown_key"), flag.Key)
+		}
+		if err := setValue(root, s, flag.Value); err != nil {
+			return nil, fmt.Errorf(i18n.T("config.flag_invalid"), flag.Source, err)
+		}
+		markSources(eff.Sources, s.key, nil, Source{Kind: SourceFlag, Detail: flag.Source})
+	}
+
+	var cfg Config
+	if err := root.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf(i18n.T("config.parse_failed"), err)
+	}
+	eff.Config = &cfg
+	return eff, nil
+}
+
+// ProfilePath returns the overlay file of a profile, next to the config file
+// (configs/config.yaml, server → configs/config.server.yaml)
+func ProfilePath(path, profile string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + profile + ext
+}
+
+// EnvName returns the environment variable that overrides a config key
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// SourceOf returns the source of a key, or of the closest parent that was set as a whole
+// (programs[0].name → programs)
+func (e *Effective) SourceOf(key string) Source {
+	for key != "" {
+		if source, ok := e.Sources[key]; ok {
+			return source
+		}
+		i := strings.LastIndexAny(key, ".[")
+		if i < 0 {
+			break
+		}
+		key = key[:i]
+	}
+	return Source{Kind: SourceDefault}
+}
+
+// Values lists every effective value with its source. 비밀번호와 토큰은 가려서 보여줍니다.
+func (e *Effective) Values() []Value {
+	var values []Value
+	flatten(reflect.ValueOf(*e.Config), "", func(key, value string) {
+		if strings.Contains(key, "[") && (value == "" || value == "0" || value == "false") {
+			return // 목록 항목의 비어있는 값 (회차 필터 등)은 생략
+		}
+		if value != "" && isSecret(key) {
+			value = "********"
+		}
+		values = append(values, Value{Key: key, Value: value, Source: e.SourceOf(key)})
+	})
+	return values
+}
+
+// mergeProfile merges the profile file over the config tree
+func mergeProfile(root *yaml.Node, path, profile string, sources map[string]Source) error {
+	if !accountNamePattern.MatchString(profile) {
+		return fmt.Errorf(i18n.T("config.profile_bad_name"), profile)
+	}
+	profilePath := ProfilePath(path, profile)
+	data, err := os.ReadFile(profilePath)
+	if err != nil {
+		return fmt.Errorf(i18n.T("config.profile_read_failed"), profile, err)
+	}
+	overlay, err := parseRoot(data)
+	if err != nil {
+		return fmt.Errorf(i18n.T("config.profile_parse_failed"), profilePath, err)
+	}
+	removeKeys(overlay, "version")
+	merge(root, overlay, "", Source{Kind: SourceProfile, Detail: profile + " (" + profilePath + ")"}, sources)
+	return nil
+}
+
+// parseRoot parses a config document into its top-level mapping (an empty one for an empty file)
+func parseRoot(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf(i18n.T("config.parse_failed"), err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Tag == "!!null" {
+		return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}, nil
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf(i18n.T("config.parse_failed"), i18n.T("config.not_mapping"))
+	}
+	return doc.Content[0], nil
+}
+
+// merge copies the keys of src into dst: mappings are merged key by key, everything else is replaced
+func merge(dst, src *yaml.Node, prefix string, source Source, sources map[string]Source) {
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		name, value := src.Content[i].Value, src.Content[i+1]
+		key := prefix + name
+		if current := mappingValue(dst, name); current != nil && current.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode {
+			merge(current, value, key+".", source, sources)
+			continue
+		}
+		setKey(dst, name, value)
+		markSources(sources, key, value, source)
+	}
+}
+
+// markSources records the source of every value under key, replacing what was recorded before
+func markSources(sources map[string]Source, key string, node *yaml.Node, source Source) {
+	if key != "" {
+		for k := range sources {
+			if k == key || strings.HasPrefix(k, key+".") {
+				delete(sources, k)
+			}
+		}
+	}
+	if node != nil && node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			child := node.Content[i].Value
+			if key != "" {
+				child = key + "." + child
+			}
+			markSources(sources, child, node.Content[i+1], source)
+		}
+		return
+	}
+	if node != nil && node.Tag == "!!null" {
+		return // "monitor:"처럼 값이 없으면 기본값 사용
+	}
+	sources[key] = source
+}
+
+// setKey sets a key of a mapping node, adding it when missing
+func setKey(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// setValue writes a value given as text at the setting's key, creating the sections on the way
+func setValue(root *yaml.Node, s setting, value string) error {
+	node, err := s.node(value)
+	if err != nil {
+		return err
+	}
+	parts := strings.Split(s.key, ".")
+	mapping := root
+	for _, part := range parts[:len(parts)-1] {
+		next := mappingValue(mapping, part)
+		if next == nil || next.Kind != yaml.MappingNode {
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			setKey(mapping, part, next)
+		}
+		mapping = next
+	}
+	setKey(mapping, parts[len(parts)-1], node)
+	return nil
+}
+
+// node converts text to a YAML node of the setting's type. 목록은 쉼표로 구분합니다.
+func (s setting) node(value string) (*yaml.Node, error) {
+	if !s.list {
+		return scalarNode(s.kind, strings.TrimSpace(value))
+	}
+	list := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		node, err := scalarNode(s.kind, item)
+		if err != nil {
+			return nil, err
+		}
+		list.Content = append(list.Content, node)
+	}
+	return list, nil
+}
+
+func scalarNode(kind reflect.Kind, value string) (*yaml.Node, error) {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	switch kind {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("config.value_not_bool"), value)
+		}
+		node.Tag, node.Value = "!!bool", strconv.FormatBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("config.value_not_number"), value)
+		}
+		node.Tag, node.Value = "!!int", strconv.FormatInt(n, 10)
+	}
+	return node, nil
+}
+
+// settingKeys lists the scalar keys of Config and its sections, in file order.
+// accounts, programs처럼 항목이 구조체인 목록은 파일이나 프로필에서만 바꿀 수 있습니다.
+func settingKeys() []setting {
+	var settings []setting
+	var walk func(t reflect.Type, prefix string)
+	walk = func(t reflect.Type, prefix string) {
+		for i := 0; i < t.NumField(); i++ {
+			name := yamlName(t.Field(i))
+			if name == "" || prefix+name == "version" {
+				continue
+			}
+			key := prefix + name
+			switch ft := t.Field(i).Type; {
+			case ft.Kind() == reflect.Struct:
+				walk(ft, key+".")
+			case ft.Kind() == reflect.Slice && isScalar(ft.Elem().Kind()):
+				settings = append(settings, setting{key: key, kind: ft.Elem().Kind(), list: true})
+			case isScalar(ft.Kind()):
+				settings = append(settings, setting{key: key, kind: ft.Kind()})
+			}
+		}
+	}
+	walk(reflect.TypeOf(Config{}), "")
+	return settings
+}
+
+func findSetting(settings []setting, key string) (setting, bool) {
+	for _, s := range settings {
+		if s.key == key {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+// flatten calls emit for every value under v, listing struct items of slices by index
+func flatten(v reflect.Value, key string, emit func(key, value string)) {
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name := yamlName(t.Field(i))
+			if name == "" {
+				continue
+			}
+			if key != "" {
+				name = key + "." + name
+			}
+			flatten(v.Field(i), name, emit)
+		}
+	case reflect.Slice:
+		if isScalar(v.Type().Elem().Kind()) {
+			items := make([]string, v.Len())
+			for i := range items {
+				items[i] = fmt.Sprint(v.Index(i).Interface())
+			}
+			emit(key, strings.Join(items, ", "))
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			flatten(v.Index(i), fmt.Sprintf("%s[%d]", key, i), emit)
+		}
+	default:
+		emit(key, fmt.Sprint(v.Interface()))
+	}
+}
+
+// yamlName returns the YAML key of a struct field, or "" when it is not written
+func yamlName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+func isScalar(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+// isSecret reports whether a key holds a password or token
+func isSecret(key string) bool {
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		key = key[i+1:]
+	}
+	switch key {
+	case "password", "api_key", "token", "secret", "dashboard_password":
+		return true
+	}
+	return false
+}
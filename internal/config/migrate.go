@@ -0,0 +1,208 @@
+package config
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentVersion is the config file format written by this build.
+// 형식을 바꿀 때는 버전을 올리고 migrations에 변환 함수를 추가합니다.
+const CurrentVersion = 2
+
+// migration upgrades a config document by one version and describes each change.
+// YAML 트리를 직접 고치므로 주석과 키 순서가 유지됩니다.
+type migration func(root *yaml.Node) []string
+
+// migrations[i] upgrades version i+1 to i+2. version 키가 없는 파일은 버전 1입니다.
+var migrations = []migration{
+	removeRuntimeFields, // 1 → 2
+}
+
+// MigrationOutput receives the summary printed when an old config file is upgraded
+var MigrationOutput io.Writer = os.Stderr
+
+// Migration describes the upgrade of a config file
+type Migration struct {
+	From, To int
+	Backup   string   // 원래 파일의 백업 (저장하지 못했으면 "")
+	Changes  []string // 바뀐 내용
+}
+
+// migrateFile upgrades the config file to CurrentVersion when it is older, keeping a backup
+// of the original next to it, and returns the data to parse
+func migrateFile(path string, data []byte) ([]byte, error) {
+	migrated, result, err := Migrate(data)
+	if err != nil || result == nil {
+		return data, err
+	}
+
+	result.Backup = fmt.Sprintf("%s.v%d.bak", path, result.From)
+	if err := writeMigrated(path, result.Backup, data, migrated); err != nil {
+		// 읽기 전용 위치 등 - 이번 실행에는 변환한 내용을 그대로 사용
+		result.Backup = ""
+		fmt.Fprintf(MigrationOutput, i18n.T("config.migrate.write_failed"), err)
+	}
+	result.print(path)
+	return migrated, nil
+}
+
+// Migrate upgrades config file data to CurrentVersion.
+// 이미 현재 버전이면 nil Migration과 원래 data를 반환합니다.
+func Migrate(data []byte) ([]byte, *Migration, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf(i18n.T("config.parse_failed"), err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return data, nil, nil // 빈 파일은 그대로 두고 파싱 단계에서 처리
+	}
+	root := doc.Content[0]
+
+	version, err := fileVersion(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	if version > CurrentVersion {
+		return nil, nil, fmt.Errorf(i18n.T("config.version_newer"), version, CurrentVersion)
+	}
+	if version == CurrentVersion {
+		return data, nil, nil
+	}
+
+	result := &Migration{From: version, To: CurrentVersion}
+	for v := version; v < CurrentVersion; v++ {
+		result.Changes = append(result.Changes, migrations[v-1](root)...)
+	}
+	setVersion(root, CurrentVersion)
+	result.Changes = append(result.Changes, fmt.Sprintf(i18n.T("config.migrate.version_set"), CurrentVersion))
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(4)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, nil, fmt.Errorf(i18n.T("config.encode_failed"), err)
+	}
+	encoder.Close()
+	return buf.Bytes(), result, nil
+}
+
+// writeMigrated saves the backup first, then replaces the file with the same permissions
+func writeMigrated(path, backup string, original, migrated []byte) error {
+	perm := os.FileMode(0600)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if err := os.WriteFile(backup, original, perm); err != nil {
+		return err
+	}
+	return os.WriteFile(path, migrated, perm)
+}
+
+func (m *Migration) print(path string) {
+	fmt.Fprintf(MigrationOutput, i18n.T("config.migrate.summary"), path, m.From, m.To)
+	for _, change := range m.Changes {
+		fmt.Fprintf(MigrationOutput, "   • %s\n", change)
+	}
+	if m.Backup != "" {
+		fmt.Fprintf(MigrationOutput, i18n.T("config.migrate.backup"), m.Backup)
+	}
+}
+
+// fileVersion returns the version key of the document (1 when missing)
+func fileVersion(root *yaml.Node) (int, error) {
+	node := mappingValue(root, "version")
+	if node == nil {
+		return 1, nil
+	}
+	version, err := strconv.Atoi(node.Value)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf(i18n.T("config.version_invalid"), node.Value)
+	}
+	return version, nil
+}
+
+// setVersion writes the version key at the top of the document
+func setVersion(root *yaml.Node, version int) {
+	value := strconv.Itoa(version)
+	if node := mappingValue(root, "version"); node != nil {
+		node.Value = value
+		return
+	}
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "version"}
+	val := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: value}
+	if len(root.Content) > 0 {
+		// 파일 맨 위의 주석은 계속 맨 위에
+		key.HeadComment, root.Content[0].HeadComment = root.Content[0].HeadComment, ""
+	}
+	root.Content = append([]*yaml.Node{key, val}, root.Content...)
+}
+
+// mappingValue returns the value of a key in a mapping node, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// removeKeys deletes keys from a mapping node and returns the ones it found
+func removeKeys(mapping *yaml.Node, keys ...string) []string {
+	var removed []string
+	kept := mapping.Content[:0]
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key := mapping.Content[i].Value
+		if slices.Contains(keys, key) {
+			removed = append(removed, key)
+			continue
+		}
+		kept = append(kept, mapping.Content[i], mapping.Content[i+1])
+	}
+	mapping.Content = kept
+	return removed
+}
+
+// removeRuntimeFields drops the check results (isopen, lastchecked) that older builds
+// wrote into the programs of the config along with the settings
+func removeRuntimeFields(root *yaml.Node) []string {
+	var changes []string
+	clean := func(prefix string, programs *yaml.Node) {
+		if programs == nil || programs.Kind != yaml.SequenceNode {
+			return
+		}
+		for i, program := range programs.Content {
+			if program.Kind != yaml.MappingNode {
+				continue
+			}
+			removed := removeKeys(program, "isopen", "lastchecked", "is_open", "last_checked")
+			if len(removed) == 0 {
+				continue
+			}
+			name := fmt.Sprintf("%s[%d]", prefix, i)
+			if value := mappingValue(program, "name"); value != nil && value.Value != "" {
+				name += " (" + value.Value + ")"
+			}
+			changes = append(changes, fmt.Sprintf(i18n.T("config.migrate.runtime_removed"), name, strings.Join(removed, ", ")))
+		}
+	}
+
+	clean("programs", mappingValue(root, "programs"))
+	if accounts := mappingValue(root, "accounts"); accounts != nil && accounts.Kind == yaml.SequenceNode {
+		for i, account := range accounts.Content {
+			clean(fmt.Sprintf("accounts[%d].programs", i), mappingValue(account, "programs"))
+		}
+	}
+	return changes
+}
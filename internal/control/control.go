@@ -0,0 +1,174 @@
+package control
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/pidfile"
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cmdShutdown asks the instance to stop gracefully
+const cmdShutdown = "shutdown"
+
+const (
+	dialTimeout      = 3 * time.Second
+	takeoverPoll     = 500 * time.Millisecond
+	defaultTakeover  = 90 * time.Second // 진행 중인 확인과 브라우저 종료를 기다리는 시간
+	replyOK          = "ok"
+	replyUnsupported = "unsupported"
+)
+
+// Server accepts control commands from other instances on a local socket
+type Server struct {
+	listener   net.Listener
+	path       string
+	onShutdown func()
+	once       sync.Once
+}
+
+// SocketDir returns the directory holding the control sockets
+func SocketDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".bmw-driving-center", "control")
+}
+
+// SocketPath returns the control socket of the process with the given PID
+func SocketPath(pid int) string {
+	return filepath.Join(SocketDir(), strconv.Itoa(pid)+".sock")
+}
+
+// Listen opens the control socket of the current process.
+// onShutdown은 다른 인스턴스가 종료(인계)를 요청하면 한 번 호출됩니다.
+func Listen(onShutdown func()) (*Server, error) {
+	if err := os.MkdirAll(SocketDir(), 0700); err != nil {
+		return nil, fmt.Errorf(i18n.T("control.dir_failed"), err)
+	}
+
+	path := SocketPath(os.Getpid())
+	os.Remove(path) // 같은 PID로 남아 있던 이전 소켓 정리
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("control.listen_failed"), err)
+	}
+	os.Chmod(path, 0600)
+
+	server := &Server{
+		listener:   listener,
+		path:       path,
+		onShutdown: onShutdown,
+	}
+	go server.serve()
+	return server, nil
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(dialTimeout))
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return
+	}
+
+	switch strings.TrimSpace(line) {
+	case cmdShutdown:
+		fmt.Fprintf(conn, "%s\n", replyOK)
+		s.once.Do(func() {
+			if s.onShutdown != nil {
+				go s.onShutdown()
+			}
+		})
+	default:
+		fmt.Fprintf(conn, "%s\n", replyUnsupported)
+	}
+}
+
+// Close stops accepting commands and removes the socket
+func (s *Server) Close() error {
+	if s == nil {
+		return nil
+	}
+	err := s.listener.Close()
+	os.Remove(s.path)
+	return err
+}
+
+// send delivers one command to the process with the given PID and returns its reply
+func send(pid int, command string) (string, error) {
+	conn, err := net.DialTimeout("unix", SocketPath(pid), dialTimeout)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(dialTimeout))
+
+	if _, err := fmt.Fprintf(conn, "%s\n", command); err != nil {
+		return "", err
+	}
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(reply), nil
+}
+
+// RequestShutdown asks the process with the given PID to stop gracefully
+func RequestShutdown(pid int) error {
+	reply, err := send(pid, cmdShutdown)
+	if err != nil {
+		return fmt.Errorf(i18n.T("control.connect_failed"), pid, err)
+	}
+	if reply != replyOK {
+		return fmt.Errorf(i18n.T("control.refused"), pid, reply)
+	}
+	return nil
+}
+
+// AcquireOrTakeOver acquires the PID file lock. If another instance holds it and takeover
+// is true, that instance is asked to shut down and the lock is acquired once it exits.
+func AcquireOrTakeOver(path string, takeover bool, logf func(format string, args ...any)) (*pidfile.File, error) {
+	file, err := pidfile.Acquire(path)
+	if err == nil || !takeover {
+		return file, err
+	}
+
+	var locked *pidfile.LockedError
+	if !errors.As(err, &locked) || locked.PID == 0 {
+		return nil, err
+	}
+
+	logf(i18n.T("control.requesting"), locked.PID)
+	if err := RequestShutdown(locked.PID); err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(defaultTakeover)
+	for time.Now().Before(deadline) {
+		time.Sleep(takeoverPoll)
+		file, err = pidfile.Acquire(path)
+		if err == nil {
+			logf(i18n.T("control.taken_over"), locked.PID)
+			return file, nil
+		}
+	}
+	return nil, fmt.Errorf(i18n.T("control.timeout"), locked.PID, defaultTakeover, err)
+}
@@ -0,0 +1,394 @@
+package daemon
+
+import (
+	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/i18n"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Service names
+const (
+	SystemdUnitName = "bmw-driving-center.service"
+	LaunchdLabel    = "com.bmw-driving-center.monitor"
+)
+
+// LogJournal selects the systemd journal instead of a log file (Linux only)
+const LogJournal = "journal"
+
+// restartDelaySeconds is how long the service manager waits before restarting a crashed monitor
+const restartDelaySeconds = 30
+
+// passthroughEnv are environment variables copied into the service definition when set at install time
+var passthroughEnv = []string{
+	"PATH",
+	"SOLVECAPTCHA_API_KEY",
+	"TWOCAPTCHA_API_KEY",
+}
+
+// Options describes how the monitor service is run
+type Options struct {
+	Executable string            // CLI 실행 파일 절대 경로
+	ConfigPath string            // 설정 파일 절대 경로
+	Profile    string            // 설정 프로필 (비어있으면 사용 안 함)
+	LogPath    string            // 로그 파일 경로 (Linux에서 LogJournal이면 journald 사용)
+	Env        map[string]string // 서비스 환경 변수
+}
+
+// Status describes the installed service
+type Status struct {
+	Manager   string `json:"manager"`   // systemd 또는 launchd
+	UnitPath  string `json:"unit_path"` // 서비스 정의 파일 경로
+	Installed bool   `json:"installed"`
+	Active    bool   `json:"active"` // 서비스 관리자가 실행 중으로 보고함
+	Detail    string `json:"detail,omitempty"`
+}
+
+// DefaultLogPath returns the default file for the service's stdout/stderr.
+// 모니터 자체 로그(monitor.log)와 겹치지 않도록 별도 파일을 사용합니다.
+func DefaultLogPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".bmw-driving-center", "logs", "service.log")
+}
+
+// NewOptions builds service options for the running executable and the given config file
+func NewOptions(configPath, logPath string) (Options, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return Options{}, fmt.Errorf(i18n.T("daemon.executable_failed"), err)
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+
+	configPath, err = filepath.Abs(configPath)
+	if err != nil {
+		return Options{}, fmt.Errorf(i18n.T("daemon.config_path_failed"), err)
+	}
+	if _, err := os.Stat(configPath); err != nil {
+		return Options{}, fmt.Errorf(i18n.T("daemon.config_missing"), configPath)
+	}
+
+	if logPath == "" {
+		logPath = DefaultLogPath()
+	}
+	if logPath != LogJournal {
+		if logPath, err = filepath.Abs(logPath); err != nil {
+			return Options{}, fmt.Errorf(i18n.T("daemon.log_path_failed"), err)
+		}
+	}
+
+	env := make(map[string]string)
+	for _, name := range passthroughEnv {
+		if value := os.Getenv(name); value != "" {
+			env[name] = value
+		}
+	}
+	// 설정 값을 바꾸는 BMW_MONITOR_* 환경 변수도 서비스에서 그대로 적용되도록
+	for _, entry := range os.Environ() {
+		if name, value, ok := strings.Cut(entry, "="); ok && strings.HasPrefix(name, config.EnvPrefix) {
+			env[name] = value
+		}
+	}
+
+	return Options{
+		Executable: executable,
+		ConfigPath: configPath,
+		LogPath:    logPath,
+		Env:        env,
+	}, nil
+}
+
+func (o Options) args() []string {
+	args := []string{o.Executable, "run", "-config", o.ConfigPath}
+	if o.Profile != "" {
+		args = append(args, "-profile", o.Profile)
+	}
+	return args
+}
+
+func (o Options) envNames() []string {
+	names := make([]string, 0, len(o.Env))
+	for name := range o.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Manager returns the service manager used on this platform
+func Manager() (string, error) {
+	switch runtime.GOOS {
+	case "linux":
+		return "systemd", nil
+	case "darwin":
+		return "launchd", nil
+	}
+	return "", fmt.Errorf(i18n.T("daemon.unsupported_os"), runtime.GOOS)
+}
+
+// UnitPath returns where the service definition is installed
+func UnitPath() (string, error) {
+	manager, err := Manager()
+	if err != nil {
+		return "", err
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	if manager == "launchd" {
+		return filepath.Join(homeDir, "Library", "LaunchAgents", LaunchdLabel+".plist"), nil
+	}
+
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		configDir = filepath.Join(homeDir, ".config")
+	}
+	return filepath.Join(configDir, "systemd", "user", SystemdUnitName), nil
+}
+
+// Render returns the service definition for this platform
+func Render(opts Options) (string, error) {
+	manager, err := Manager()
+	if err != nil {
+		return "", err
+	}
+	if manager == "launchd" {
+		return LaunchdPlist(opts), nil
+	}
+	return SystemdUnit(opts), nil
+}
+
+// SystemdUnit renders a systemd user unit
+func SystemdUnit(opts Options) string {
+	var b strings.Builder
+	b.WriteString("[Unit]\n")
+	b.WriteString("Description=BMW Driving Center reservation monitor\n")
+	b.WriteString("After=network-online.target\n")
+	b.WriteString("Wants=network-online.target\n")
+	b.WriteString("StartLimitIntervalSec=0\n")
+	b.WriteString("\n[Service]\n")
+	b.WriteString("Type=simple\n")
+
+	var args []string
+	for _, arg := range opts.args() {
+		args = append(args, quoteSystemd(arg))
+	}
+	fmt.Fprintf(&b, "ExecStart=%s\n", strings.Join(args, " "))
+	// WorkingDirectory는 따옴표를 풀지 않으므로 공백이 있어도 그대로 적습니다
+	fmt.Fprintf(&b, "WorkingDirectory=%s\n", escapeSystemd(filepath.Dir(opts.ConfigPath)))
+	b.WriteString("Restart=on-failure\n")
+	fmt.Fprintf(&b, "RestartSec=%d\n", restartDelaySeconds)
+	// 종료 시 Chrome/ChromeDriver 정리를 기다림
+	b.WriteString("KillSignal=SIGTERM\n")
+	b.WriteString("TimeoutStopSec=60\n")
+
+	for _, name := range opts.envNames() {
+		fmt.Fprintf(&b, "Environment=%s\n", quoteSystemd(name+"="+opts.Env[name]))
+	}
+
+	if opts.LogPath != LogJournal {
+		fmt.Fprintf(&b, "StandardOutput=append:%s\n", escapeSystemd(opts.LogPath))
+		fmt.Fprintf(&b, "StandardError=append:%s\n", escapeSystemd(opts.LogPath))
+	}
+
+	b.WriteString("\n[Install]\n")
+	b.WriteString("WantedBy=default.target\n")
+	return b.String()
+}
+
+// escapeSystemd escapes specifiers (%) in a unit file value
+func escapeSystemd(value string) string {
+	return strings.ReplaceAll(value, "%", "%%")
+}
+
+// quoteSystemd quotes a unit file word if it contains spaces or quotes
+func quoteSystemd(value string) string {
+	value = escapeSystemd(value)
+	if !strings.ContainsAny(value, " \t\"'\\") {
+		return value
+	}
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
+
+// LaunchdPlist renders a launchd agent property list
+func LaunchdPlist(opts Options) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString(`<plist version="1.0">` + "\n")
+	b.WriteString("<dict>\n")
+	writePlistString(&b, "Label", LaunchdLabel)
+
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, arg := range opts.args() {
+		fmt.Fprintf(&b, "\t\t<string>%s</string>\n", xmlEscape(arg))
+	}
+	b.WriteString("\t</array>\n")
+
+	writePlistString(&b, "WorkingDirectory", filepath.Dir(opts.ConfigPath))
+	b.WriteString("\t<key>RunAtLoad</key>\n\t<true/>\n")
+	// 비정상 종료 시에만 재시작
+	b.WriteString("\t<key>KeepAlive</key>\n\t<dict>\n\t\t<key>SuccessfulExit</key>\n\t\t<false/>\n\t</dict>\n")
+	fmt.Fprintf(&b, "\t<key>ThrottleInterval</key>\n\t<integer>%d</integer>\n", restartDelaySeconds)
+	b.WriteString("\t<key>ExitTimeOut</key>\n\t<integer>60</integer>\n")
+
+	if opts.LogPath != LogJournal {
+		writePlistString(&b, "StandardOutPath", opts.LogPath)
+		writePlistString(&b, "StandardErrorPath", opts.LogPath)
+	}
+
+	if len(opts.Env) > 0 {
+		b.WriteString("\t<key>EnvironmentVariables</key>\n\t<dict>\n")
+		for _, name := range opts.envNames() {
+			fmt.Fprintf(&b, "\t\t<key>%s</key>\n\t\t<string>%s</string>\n", xmlEscape(name), xmlEscape(opts.Env[name]))
+		}
+		b.WriteString("\t</dict>\n")
+	}
+
+	b.WriteString("</dict>\n</plist>\n")
+	return b.String()
+}
+
+func writePlistString(b *strings.Builder, key, value string) {
+	fmt.Fprintf(b, "\t<key>%s</key>\n\t<string>%s</string>\n", key, xmlEscape(value))
+}
+
+func xmlEscape(value string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(value))
+	return buf.String()
+}
+
+// Install writes the service definition and starts the service
+func Install(opts Options, start bool) (string, error) {
+	manager, err := Manager()
+	if err != nil {
+		return "", err
+	}
+	if opts.LogPath == LogJournal {
+		if manager != "systemd" {
+			return "", errors.New(i18n.T("daemon.journal_linux_only"))
+		}
+	} else if err := os.MkdirAll(filepath.Dir(opts.LogPath), 0755); err != nil {
+		return "", fmt.Errorf(i18n.T("daemon.log_dir_failed"), err)
+	}
+
+	path, err := UnitPath()
+	if err != nil {
+		return "", err
+	}
+	content, err := Render(opts)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf(i18n.T("daemon.service_dir_failed"), err)
+	}
+	// API 키가 들어갈 수 있으므로 본인만 읽을 수 있게 저장
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		return "", fmt.Errorf(i18n.T("daemon.service_save_failed"), err)
+	}
+
+	if manager == "launchd" {
+		// 이미 로드되어 있으면 새 설정으로 다시 로드
+		run("launchctl", "unload", path)
+		if start {
+			if out, err := run("launchctl", "load", "-w", path); err != nil {
+				return path, fmt.Errorf(i18n.T("daemon.launchctl_failed"), err, out)
+			}
+		}
+		return path, nil
+	}
+
+	if out, err := run("systemctl", "--user", "daemon-reload"); err != nil {
+		return path, fmt.Errorf(i18n.T("daemon.reload_failed"), err, out)
+	}
+	if start {
+		if out, err := run("systemctl", "--user", "enable", "--now", SystemdUnitName); err != nil {
+			return path, fmt.Errorf(i18n.T("daemon.start_failed"), err, out)
+		}
+		// 설정을 바꾼 뒤 다시 설치하는 경우 새 정의로 재시작
+		run("systemctl", "--user", "restart", SystemdUnitName)
+	}
+	return path, nil
+}
+
+// Uninstall stops the service and removes its definition
+func Uninstall() (string, error) {
+	manager, err := Manager()
+	if err != nil {
+		return "", err
+	}
+	path, err := UnitPath()
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return path, fmt.Errorf(i18n.T("daemon.not_installed"), path)
+	}
+
+	if manager == "launchd" {
+		run("launchctl", "unload", "-w", path)
+	} else {
+		run("systemctl", "--user", "disable", "--now", SystemdUnitName)
+	}
+
+	if err := os.Remove(path); err != nil {
+		return path, fmt.Errorf(i18n.T("daemon.remove_failed"), err)
+	}
+	if manager == "systemd" {
+		run("systemctl", "--user", "daemon-reload")
+	}
+	return path, nil
+}
+
+// GetStatus reports whether the service is installed and running
+func GetStatus() (Status, error) {
+	manager, err := Manager()
+	if err != nil {
+		return Status{}, err
+	}
+	path, err := UnitPath()
+	if err != nil {
+		return Status{}, err
+	}
+
+	status := Status{Manager: manager, UnitPath: path}
+	if _, err := os.Stat(path); err == nil {
+		status.Installed = true
+	}
+
+	if manager == "launchd" {
+		out, err := run("launchctl", "list", LaunchdLabel)
+		status.Active = err == nil
+		if err == nil {
+			status.Detail = "loaded"
+		} else {
+			status.Detail = strings.TrimSpace(out)
+		}
+		return status, nil
+	}
+
+	out, err := run("systemctl", "--user", "is-active", SystemdUnitName)
+	status.Detail = strings.TrimSpace(out)
+	status.Active = err == nil && status.Detail == "active"
+	return status, nil
+}
+
+func run(name string, args ...string) (string, error) {
+	out, err := exec.Command(name, args...).CombinedOutput()
+	return strings.TrimSpace(string(out)), err
+}
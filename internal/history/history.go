@@ -0,0 +1,187 @@
+package history
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// maxFileSize is the size at which the history file is rotated to <path>.1
+const maxFileSize = 20 * 1024 * 1024
+
+// Entry is the result of checking one account's programs
+type Entry struct {
+	Time        time.Time       `json:"time"`
+	Account     string          `json:"account"`
+	Programs    []ProgramResult `json:"programs"`
+	NewlyOpened []string        `json:"newly_opened,omitempty"`
+	Captcha     bool            `json:"captcha,omitempty"`
+}
+
+// ProgramResult is the availability of one program in a check
+type ProgramResult struct {
+	Name      string      `json:"name"`
+	Available bool        `json:"available"`
+	Sessions  []time.Time `json:"sessions,omitempty"` // 예약 가능한 회차의 시작 시각 (공개 시점 분석용)
+}
+
+// Available returns the names of the programs that were available
+func (e Entry) Available() []string {
+	var names []string
+	for _, program := range e.Programs {
+		if program.Available {
+			names = append(names, program.Name)
+		}
+	}
+	return names
+}
+
+// Filter selects history entries
+type Filter struct {
+	Account string    // 비어있으면 모든 계정
+	Program string    // 비어있으면 모든 프로그램
+	Since   time.Time // 이 시각 이후 기록만
+	Limit   int       // 0보다 크면 최근 N개만
+}
+
+func (f Filter) matches(entry Entry) bool {
+	if f.Account != "" && entry.Account != f.Account {
+		return false
+	}
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+	if f.Program != "" {
+		for _, program := range entry.Programs {
+			if program.Name == f.Program {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Store appends check results to a JSON Lines file
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// DefaultPath returns the default history file path
+func DefaultPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".bmw-driving-center", "history.jsonl")
+}
+
+// NewStore creates a history store (empty path = default path)
+func NewStore(path string) *Store {
+	if path == "" {
+		path = DefaultPath()
+	}
+	return &Store{path: path}
+}
+
+// Path returns the history file path
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Append writes one entry to the end of the history file
+func (s *Store) Append(entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf(i18n.T("history.dir_failed"), err)
+	}
+
+	// 파일이 너무 커지면 이전 파일 하나만 남기고 교체
+	if info, err := os.Stat(s.path); err == nil && info.Size() > maxFileSize {
+		os.Rename(s.path, s.path+".1")
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf(i18n.T("history.encode_failed"), err)
+	}
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf(i18n.T("history.open_failed"), err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf(i18n.T("history.save_failed"), err)
+	}
+	return nil
+}
+
+// Read returns the entries matching the filter, oldest first
+func (s *Store) Read(filter Filter) ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var entries []Entry
+	for _, path := range []string{s.path + ".1", s.path} {
+		fileEntries, err := readFile(path, filter)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fileEntries...)
+	}
+
+	if filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[len(entries)-filter.Limit:]
+	}
+	return entries, nil
+}
+
+// Latest returns the most recent entry of each account
+func (s *Store) Latest() (map[string]Entry, error) {
+	entries, err := s.Read(Filter{})
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]Entry)
+	for _, entry := range entries {
+		latest[entry.Account] = entry
+	}
+	return latest, nil
+}
+
+func readFile(path string, filter Filter) ([]Entry, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("history.open_failed"), err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// 쓰는 중에 중단된 줄은 건너뜀
+			continue
+		}
+		if filter.matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf(i18n.T("history.read_failed"), err)
+	}
+	return entries, nil
+}
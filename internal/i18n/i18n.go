@@ -0,0 +1,176 @@
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jeandeaual/go-locale"
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported languages (config language 값)
+const (
+	Auto    = "auto"
+	Korean  = "ko"
+	English = "en"
+)
+
+//go:embed locales/*.yaml
+var locales embed.FS
+
+var (
+	bundle = newBundle()
+
+	mu        sync.RWMutex
+	current   string            // 비어있으면 아직 정하지 않음 (처음 쓸 때 OS 언어로 결정)
+	localizer *goi18n.Localizer // current 언어
+	cache     map[string]string
+)
+
+// newBundle loads the embedded message catalogs. 한국어가 기본 언어이고, 번역이 빠진 메시지는 한국어로 표시됩니다.
+func newBundle() *goi18n.Bundle {
+	b := goi18n.NewBundle(language.Korean)
+	b.RegisterUnmarshalFunc("yaml", yaml.Unmarshal)
+	for _, lang := range []string{Korean, English} {
+		if _, err := b.LoadMessageFileFS(locales, "locales/"+lang+".yaml"); err != nil {
+			panic(fmt.Sprintf("i18n: %s 메시지 카탈로그 로드 실패: %v", lang, err))
+		}
+	}
+	return b
+}
+
+// Languages returns the supported language codes
+func Languages() []string {
+	return []string{Korean, English}
+}
+
+// ValidLanguage reports whether the value can be used as the language setting ("" is auto)
+func ValidLanguage(lang string) bool {
+	switch strings.ToLower(strings.TrimSpace(lang)) {
+	case "", Auto, Korean, English:
+		return true
+	}
+	return false
+}
+
+// SetLanguage switches the language of all messages. "" 또는 "auto"면 OS 언어를 따릅니다.
+func SetLanguage(lang string) error {
+	if !ValidLanguage(lang) {
+		return fmt.Errorf(T("i18n.unknown_language"), lang)
+	}
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if lang == "" || lang == Auto {
+		lang = Detect()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	current = lang
+	localizer = goi18n.NewLocalizer(bundle, lang)
+	cache = make(map[string]string)
+	return nil
+}
+
+// Language returns the language currently in use (ko or en)
+func Language() string {
+	mu.RLock()
+	lang := current
+	mu.RUnlock()
+	if lang == "" {
+		SetLanguage(Auto)
+		return Language()
+	}
+	return lang
+}
+
+// Detect returns the supported language closest to the OS locale.
+// 한국어 환경이면 ko, 그 밖의 언어는 en, 알 수 없으면 기존처럼 ko를 사용합니다.
+func Detect() string {
+	tag, err := locale.GetLanguage()
+	if err != nil || tag == "" || tag == "C" || tag == "POSIX" {
+		return Korean
+	}
+	if strings.HasPrefix(strings.ToLower(tag), Korean) {
+		return Korean
+	}
+	return English
+}
+
+// T returns the message with the id in the current language.
+// 메시지는 fmt 형식 문자열이므로 인자가 있으면 fmt.Sprintf 등에 그대로 넘기면 됩니다.
+// 카탈로그에 없는 id는 그대로 반환합니다.
+func T(id string) string {
+	Language()
+
+	mu.RLock()
+	text, ok := cache[id]
+	lc := localizer
+	mu.RUnlock()
+	if ok {
+		return text
+	}
+
+	text, err := lc.Localize(&goi18n.LocalizeConfig{MessageID: id})
+	if text == "" && err != nil {
+		text = id
+	}
+
+	mu.Lock()
+	cache[id] = text
+	mu.Unlock()
+	return text
+}
+
+// Tf formats the message with the id like fmt.Sprintf
+func Tf(id string, args ...any) string {
+	return fmt.Sprintf(T(id), args...)
+}
+
+// Error is an error whose message is looked up when it is printed,
+// so package-level errors follow the language chosen after they were created
+type Error string
+
+// NewError returns an error with the message id
+func NewError(id string) error {
+	return Error(id)
+}
+
+func (e Error) Error() string {
+	return T(string(e))
+}
+
+// ProgramName returns the display name of a program in the current language
+// (영문 이름은 그대로, 한국어는 카탈로그의 한국어 이름)
+func ProgramName(name string) string {
+	if text := T("program." + name); text != "program."+name {
+		return text
+	}
+	return name
+}
+
+// ProgramLabel formats a program name with its localized name when they differ, like "Taxi (택시)"
+func ProgramLabel(name string) string {
+	if local := ProgramName(name); local != name {
+		return name + " (" + local + ")"
+	}
+	return name
+}
+
+var weekdayIDs = [7]string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// Weekday returns the short name of a weekday (일, 월 / Sun, Mon)
+func Weekday(day time.Weekday) string {
+	return T("weekday." + weekdayIDs[day])
+}
+
+// FormatTime formats t with a time layout like t.Format, writing the short weekday ("Mon")
+// in the current language: "01/02 (Mon) 15:04" → "11/07 (토) 09:00"
+func FormatTime(t time.Time, layout string) string {
+	const mark = "\x00"
+	return strings.ReplaceAll(t.Format(strings.ReplaceAll(layout, "Mon", mark)), mark, Weekday(t.Weekday()))
+}
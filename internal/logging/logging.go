@@ -0,0 +1,340 @@
+package logging
+
+import (
+	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/i18n"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileOff disables the log file when used as logging.file
+const FileOff = "off"
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+const (
+	componentKey      = "component"
+	defaultMaxSizeMB  = 10
+	defaultMaxBackups = 5
+	driverLogName     = "chromedriver.log"
+)
+
+var (
+	level   = new(slog.LevelVar)
+	console = &switchWriter{w: os.Stderr, timestamps: true}
+
+	mu         sync.Mutex
+	logFile    *RotatingFile
+	driverFile *RotatingFile
+)
+
+// DefaultDir returns the directory holding the log files
+func DefaultDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".bmw-driving-center", "logs")
+}
+
+// DefaultPath returns the default application log file
+func DefaultPath() string {
+	return filepath.Join(DefaultDir(), "monitor.log")
+}
+
+// ParseLevel converts a level name (debug, info, warn, error) to a slog level
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "debug":
+		return slog.LevelDebug, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf(i18n.T("logging.unknown_level"), name)
+}
+
+// Setup installs the default slog logger: the console (stderr), the rotating log file
+// and any extra handlers (e.g. the GUI ring buffer). The standard log package is
+// routed through the same handlers. An error is returned only for an invalid level
+// or format; if the file cannot be opened a warning is logged to the console.
+func Setup(cfg config.LoggingConfig, extra ...slog.Handler) error {
+	lvl, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return err
+	}
+	format := cfg.Format
+	if format == "" {
+		format = FormatText
+	}
+	if format != FormatText && format != FormatJSON {
+		return fmt.Errorf(i18n.T("logging.unknown_format"), cfg.Format)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	closeFiles()
+	level.Set(lvl)
+	options := &slog.HandlerOptions{Level: level}
+
+	var handlers []slog.Handler
+	if format == FormatJSON {
+		handlers = append(handlers, slog.NewJSONHandler(console, options))
+	} else {
+		handlers = append(handlers, &consoleHandler{out: console})
+	}
+
+	var fileErr error
+	if cfg.File != FileOff {
+		path := cfg.File
+		if path == "" {
+			path = DefaultPath()
+		}
+		maxSize := int64(cfg.MaxSizeMB)
+		if maxSize <= 0 {
+			maxSize = defaultMaxSizeMB
+		}
+		maxSize *= 1024 * 1024
+		backups := cfg.MaxBackups
+		if backups <= 0 {
+			backups = defaultMaxBackups
+		}
+
+		logFile, fileErr = OpenRotating(path, maxSize, backups)
+		if fileErr == nil {
+			if format == FormatJSON {
+				handlers = append(handlers, slog.NewJSONHandler(logFile, options))
+			} else {
+				handlers = append(handlers, slog.NewTextHandler(logFile, options))
+			}
+			// ChromeDriver 자체 로그는 별도 파일로 (--verbose라 양이 많음)
+			driverFile, _ = OpenRotating(filepath.Join(filepath.Dir(path), driverLogName), maxSize, backups)
+		}
+	}
+
+	handlers = append(handlers, extra...)
+	slog.SetDefault(slog.New(fanout(handlers)))
+	if fileErr != nil {
+		slog.Warn(fmt.Sprintf(i18n.T("logging.console_only"), fileErr))
+	}
+	return nil
+}
+
+// SetLevel changes the minimum level of the console and the log file
+func SetLevel(lvl slog.Level) {
+	level.Set(lvl)
+}
+
+// FilePath returns the active log file, or "" if file logging is off
+func FilePath() string {
+	mu.Lock()
+	defer mu.Unlock()
+	if logFile == nil {
+		return ""
+	}
+	return logFile.Path()
+}
+
+// DriverOutput returns the writer for the ChromeDriver log, or nil to discard it
+func DriverOutput() io.Writer {
+	mu.Lock()
+	defer mu.Unlock()
+	if driverFile == nil {
+		return nil
+	}
+	return driverFile
+}
+
+// SetConsole redirects console output, e.g. into the terminal UI which adds its own
+// timestamps. The returned function restores the previous console.
+func SetConsole(w io.Writer, timestamps bool) (restore func()) {
+	prev, prevTimestamps := console.swap(w, timestamps)
+	return func() { console.swap(prev, prevTimestamps) }
+}
+
+// Close flushes and closes the log files
+func Close() {
+	mu.Lock()
+	defer mu.Unlock()
+	closeFiles()
+}
+
+func closeFiles() {
+	if logFile != nil {
+		logFile.Close()
+		logFile = nil
+	}
+	if driverFile != nil {
+		driverFile.Close()
+		driverFile = nil
+	}
+}
+
+// switchWriter lets the console destination change after the handlers are built
+type switchWriter struct {
+	mu         sync.Mutex
+	w          io.Writer
+	timestamps bool
+}
+
+func (s *switchWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+func (s *switchWriter) swap(w io.Writer, timestamps bool) (io.Writer, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, prevTimestamps := s.w, s.timestamps
+	s.w, s.timestamps = w, timestamps
+	return prev, prevTimestamps
+}
+
+func (s *switchWriter) withTimestamps() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.timestamps
+}
+
+// consoleHandler prints records the way the log package used to:
+// "2006/01/02 15:04:05 메시지", with the level for anything other than INFO
+type consoleHandler struct {
+	out   *switchWriter
+	attrs []slog.Attr
+}
+
+func (h *consoleHandler) Enabled(_ context.Context, lvl slog.Level) bool {
+	return lvl >= level.Level()
+}
+
+func (h *consoleHandler) Handle(_ context.Context, record slog.Record) error {
+	var b strings.Builder
+	if h.out.withTimestamps() {
+		b.WriteString(record.Time.Format("2006/01/02 15:04:05 "))
+	}
+	if record.Level != slog.LevelInfo {
+		b.WriteString(record.Level.String())
+		b.WriteByte(' ')
+	}
+	b.WriteString(record.Message)
+
+	write := func(a slog.Attr) bool {
+		if a.Key != componentKey {
+			fmt.Fprintf(&b, " %s=%v", a.Key, a.Value)
+		}
+		return true
+	}
+	for _, a := range h.attrs {
+		write(a)
+	}
+	record.Attrs(write)
+	b.WriteByte('\n')
+
+	_, err := io.WriteString(h.out, b.String())
+	return err
+}
+
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &consoleHandler{out: h.out, attrs: append(append([]slog.Attr(nil), h.attrs...), attrs...)}
+}
+
+func (h *consoleHandler) WithGroup(string) slog.Handler {
+	return h
+}
+
+// fanout sends every record to several handlers
+type fanout []slog.Handler
+
+func (f fanout) Enabled(ctx context.Context, lvl slog.Level) bool {
+	for _, h := range f {
+		if h.Enabled(ctx, lvl) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f fanout) Handle(ctx context.Context, record slog.Record) error {
+	var errs []error
+	for _, h := range f {
+		if h.Enabled(ctx, record.Level) {
+			if err := h.Handle(ctx, record.Clone()); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (f fanout) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := make(fanout, len(f))
+	for i, h := range f {
+		out[i] = h.WithAttrs(attrs)
+	}
+	return out
+}
+
+func (f fanout) WithGroup(name string) slog.Handler {
+	out := make(fanout, len(f))
+	for i, h := range f {
+		out[i] = h.WithGroup(name)
+	}
+	return out
+}
+
+// Logger writes printf-style messages to the default slog logger, tagged with a component
+type Logger struct {
+	attrs []slog.Attr
+}
+
+// Component returns a logger whose records carry component=name
+func Component(name string) *Logger {
+	return &Logger{attrs: []slog.Attr{slog.String(componentKey, name)}}
+}
+
+// With returns a logger that adds key=value to every record
+func (l *Logger) With(key string, value any) *Logger {
+	attrs := append(append([]slog.Attr(nil), l.attrs...), slog.Any(key, value))
+	return &Logger{attrs: attrs}
+}
+
+// Log writes msg at the given level
+func (l *Logger) Log(lvl slog.Level, msg string) {
+	// 기본 로거는 호출 시점에 찾음 - 패키지 변수로 만든 로거도 Setup 이후 설정을 따름
+	handler := slog.Default().Handler()
+	ctx := context.Background()
+	if !handler.Enabled(ctx, lvl) {
+		return
+	}
+	record := slog.NewRecord(time.Now(), lvl, msg, 0)
+	record.AddAttrs(l.attrs...)
+	handler.Handle(ctx, record)
+}
+
+// Logf formats and writes a message at the given level
+func (l *Logger) Logf(lvl slog.Level, format string, args ...any) {
+	l.Log(lvl, fmt.Sprintf(format, args...))
+}
+
+func (l *Logger) Debug(msg string) { l.Log(slog.LevelDebug, msg) }
+func (l *Logger) Info(msg string)  { l.Log(slog.LevelInfo, msg) }
+func (l *Logger) Warn(msg string)  { l.Log(slog.LevelWarn, msg) }
+func (l *Logger) Error(msg string) { l.Log(slog.LevelError, msg) }
+
+func (l *Logger) Debugf(format string, args ...any) { l.Logf(slog.LevelDebug, format, args...) }
+func (l *Logger) Infof(format string, args ...any)  { l.Logf(slog.LevelInfo, format, args...) }
+func (l *Logger) Warnf(format string, args ...any)  { l.Logf(slog.LevelWarn, format, args...) }
+func (l *Logger) Errorf(format string, args ...any) { l.Logf(slog.LevelError, format, args...) }
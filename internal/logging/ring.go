@@ -0,0 +1,122 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry is one log record kept in a Ring
+type Entry struct {
+	Time      time.Time
+	Level     slog.Level
+	Component string
+	Message   string
+}
+
+// Ring is a slog handler that keeps the most recent records in memory.
+// 모든 레벨을 보관하고, 보여줄 레벨은 화면에서 고릅니다.
+type Ring struct {
+	state *ringState
+	attrs []slog.Attr
+}
+
+type ringState struct {
+	mu       sync.Mutex
+	entries  []Entry
+	next     int
+	full     bool
+	onAppend func(Entry)
+}
+
+// NewRing creates a ring buffer holding up to size records
+func NewRing(size int) *Ring {
+	if size <= 0 {
+		size = 1000
+	}
+	return &Ring{state: &ringState{entries: make([]Entry, size)}}
+}
+
+// OnAppend registers a callback invoked (outside the lock) for every new record
+func (r *Ring) OnAppend(fn func(Entry)) {
+	r.state.mu.Lock()
+	r.state.onAppend = fn
+	r.state.mu.Unlock()
+}
+
+// Entries returns the kept records, oldest first
+func (r *Ring) Entries() []Entry {
+	s := r.state
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.full {
+		return append([]Entry(nil), s.entries[:s.next]...)
+	}
+	out := make([]Entry, 0, len(s.entries))
+	out = append(out, s.entries[s.next:]...)
+	return append(out, s.entries[:s.next]...)
+}
+
+// Clear drops all kept records
+func (r *Ring) Clear() {
+	s := r.state
+	s.mu.Lock()
+	s.next = 0
+	s.full = false
+	s.mu.Unlock()
+}
+
+// Enabled implements slog.Handler
+func (r *Ring) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle implements slog.Handler
+func (r *Ring) Handle(_ context.Context, record slog.Record) error {
+	entry := Entry{Time: record.Time, Level: record.Level, Message: record.Message}
+
+	var extra []string
+	collect := func(a slog.Attr) bool {
+		if a.Key == componentKey {
+			entry.Component = a.Value.String()
+		} else {
+			extra = append(extra, a.Key+"="+a.Value.String())
+		}
+		return true
+	}
+	for _, a := range r.attrs {
+		collect(a)
+	}
+	record.Attrs(collect)
+	if len(extra) > 0 {
+		entry.Message += " " + strings.Join(extra, " ")
+	}
+
+	s := r.state
+	s.mu.Lock()
+	s.entries[s.next] = entry
+	s.next = (s.next + 1) % len(s.entries)
+	if s.next == 0 {
+		s.full = true
+	}
+	onAppend := s.onAppend
+	s.mu.Unlock()
+
+	if onAppend != nil {
+		onAppend(entry)
+	}
+	return nil
+}
+
+// WithAttrs implements slog.Handler
+func (r *Ring) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &Ring{state: r.state, attrs: append(append([]slog.Attr(nil), r.attrs...), attrs...)}
+}
+
+// WithGroup implements slog.Handler (groups are flattened)
+func (r *Ring) WithGroup(string) slog.Handler {
+	return r
+}
@@ -0,0 +1,166 @@
+package models
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	filterDateLayout = "2006-01-02"
+	filterTimeLayout = "15:04"
+)
+
+// weekdayNames maps the accepted weekday spellings (English and Korean) to weekdays
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday, "일": time.Sunday, "일요일": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday, "월": time.Monday, "월요일": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday, "화": time.Tuesday, "화요일": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday, "수": time.Wednesday, "수요일": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday, "목": time.Thursday, "목요일": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday, "금": time.Friday, "금요일": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday, "토": time.Saturday, "토요일": time.Saturday,
+}
+
+// weekdayGroups are shorthands for several weekdays
+var weekdayGroups = map[string][]time.Weekday{
+	"weekend": {time.Saturday, time.Sunday},
+	"주말":      {time.Saturday, time.Sunday},
+	"weekday": {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	"평일":      {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+}
+
+// SessionFilter limits which sessions of a program count as an opening.
+// 값을 알 수 없는 항목(가격, 잔여석)은 통과로 처리합니다 - 파싱 실패로 알림을 놓치지 않도록.
+type SessionFilter struct {
+	Weekdays   []string `yaml:"weekdays,omitempty" json:"weekdays,omitempty"`       // 허용 요일 (sat, sun, weekend, 토, 주말 ...)
+	From       string   `yaml:"from,omitempty" json:"from,omitempty"`               // 이 날짜부터 (2006-01-02)
+	To         string   `yaml:"to,omitempty" json:"to,omitempty"`                   // 이 날짜까지 (포함)
+	WithinDays int      `yaml:"within_days,omitempty" json:"within_days,omitempty"` // 오늘부터 N일 이내
+	TimeFrom   string   `yaml:"time_from,omitempty" json:"time_from,omitempty"`     // 시작 시각 하한 (15:04)
+	TimeTo     string   `yaml:"time_to,omitempty" json:"time_to,omitempty"`         // 시작 시각 상한 (포함)
+	MaxPrice   int      `yaml:"max_price,omitempty" json:"max_price,omitempty"`     // 최대 가격 (원)
+	MinSeats   int      `yaml:"min_seats,omitempty" json:"min_seats,omitempty"`     // 최소 잔여석
+	Tracks     []string `yaml:"tracks,omitempty" json:"tracks,omitempty"`           // 트랙/차량 - 회차 정보에 하나라도 포함되어야 함
+}
+
+// Active reports whether any filter condition is set
+func (f SessionFilter) Active() bool {
+	return len(f.Weekdays) > 0 || f.From != "" || f.To != "" || f.WithinDays > 0 ||
+		f.TimeFrom != "" || f.TimeTo != "" || f.MaxPrice > 0 || f.MinSeats > 0 || len(f.Tracks) > 0
+}
+
+// Validate checks the weekday names, dates and times
+func (f SessionFilter) Validate() error {
+	if _, err := f.weekdays(); err != nil {
+		return err
+	}
+	for _, date := range []string{f.From, f.To} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(filterDateLayout, date); err != nil {
+			return fmt.Errorf(i18n.T("models.filter_date"), date)
+		}
+	}
+	for _, clock := range []string{f.TimeFrom, f.TimeTo} {
+		if clock == "" {
+			continue
+		}
+		if _, err := time.Parse(filterTimeLayout, clock); err != nil {
+			return fmt.Errorf(i18n.T("models.filter_time"), clock)
+		}
+	}
+	if f.WithinDays < 0 || f.MaxPrice < 0 || f.MinSeats < 0 {
+		return errors.New(i18n.T("models.filter_values"))
+	}
+	return nil
+}
+
+func (f SessionFilter) weekdays() (map[time.Weekday]bool, error) {
+	if len(f.Weekdays) == 0 {
+		return nil, nil
+	}
+	allowed := make(map[time.Weekday]bool)
+	for _, name := range f.Weekdays {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if day, ok := weekdayNames[key]; ok {
+			allowed[day] = true
+			continue
+		}
+		group, ok := weekdayGroups[key]
+		if !ok {
+			return nil, fmt.Errorf(i18n.T("models.filter_weekday"), name)
+		}
+		for _, day := range group {
+			allowed[day] = true
+		}
+	}
+	return allowed, nil
+}
+
+// Match reports whether the session passes the filter. now is used for within_days.
+func (f SessionFilter) Match(session Session, now time.Time) bool {
+	start := session.Start
+	loc := start.Location()
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+
+	if allowed, err := f.weekdays(); err == nil && allowed != nil && !allowed[start.Weekday()] {
+		return false
+	}
+	if f.From != "" {
+		if from, err := time.ParseInLocation(filterDateLayout, f.From, loc); err == nil && day.Before(from) {
+			return false
+		}
+	}
+	if f.To != "" {
+		if to, err := time.ParseInLocation(filterDateLayout, f.To, loc); err == nil && day.After(to) {
+			return false
+		}
+	}
+	if f.WithinDays > 0 {
+		local := now.In(loc)
+		today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+		if day.After(today.AddDate(0, 0, f.WithinDays)) {
+			return false
+		}
+	}
+
+	// 시간 조건은 시간이 표시된 회차에만 적용
+	if !session.AllDay {
+		minutes := start.Hour()*60 + start.Minute()
+		if f.TimeFrom != "" {
+			if from, err := time.Parse(filterTimeLayout, f.TimeFrom); err == nil && minutes < from.Hour()*60+from.Minute() {
+				return false
+			}
+		}
+		if f.TimeTo != "" {
+			if to, err := time.Parse(filterTimeLayout, f.TimeTo); err == nil && minutes > to.Hour()*60+to.Minute() {
+				return false
+			}
+		}
+	}
+
+	if f.MaxPrice > 0 && session.Price > 0 && session.Price > f.MaxPrice {
+		return false
+	}
+	if f.MinSeats > 0 && session.Seats >= 0 && session.Seats < f.MinSeats {
+		return false
+	}
+	if len(f.Tracks) > 0 {
+		details := strings.ToLower(session.Details)
+		found := false
+		for _, track := range f.Tracks {
+			if strings.Contains(details, strings.ToLower(strings.TrimSpace(track))) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -2,17 +2,56 @@ package models
 
 import "time"
 
-// Program represents a driving program to monitor
+// Program represents a driving program to monitor, as written in the config file
 type Program struct {
 	Name     string   `yaml:"name" json:"name"`
 	Keywords []string `yaml:"keywords" json:"keywords"`
-	IsOpen   bool     `json:"is_open"`
+	Filter   SessionFilter `yaml:"filter,omitempty" json:"filter,omitempty"` // 알림 대상 회차 조건
+	Severity string   `yaml:"severity,omitempty" json:"severity,omitempty"` // 알림 중요도: high, normal(기본), low
+}
+
+// ProgramStatus is the result of checking a program.
+// 실행 중에만 쓰는 상태이므로 설정 파일에는 저장하지 않습니다.
+type ProgramStatus struct {
+	Program
+	IsOpen      bool      `json:"is_open"`
 	LastChecked time.Time `json:"last_checked"`
+	BookingURL  string    `json:"booking_url,omitempty"` // 예약 페이지에서 찾은 이 프로그램의 예약 링크
+}
+
+// ReservationPageURL is the reservation page that lists every program
+const ReservationPageURL = "https://driving-center.bmw.co.kr/orders/programs/products/view"
+
+// BookingLink returns the program's own booking link, or the reservation page when none was found
+func (p ProgramStatus) BookingLink() string {
+	if p.BookingURL != "" {
+		return p.BookingURL
+	}
+	return ReservationPageURL
+}
+
+// Notification severities of a program
+const (
+	SeverityHigh   = "high"   // 조용한 시간에도 즉시 알림
+	SeverityNormal = "normal" // 즉시 알림, 조용한 시간에는 끝난 뒤 요약으로
+	SeverityLow    = "low"    // 요약 알림으로 모아서
+)
+
+// SeverityLevel returns the program's severity, defaulting to normal
+func (p Program) SeverityLevel() string {
+	switch p.Severity {
+	case SeverityHigh, SeverityLow:
+		return p.Severity
+	default:
+		return SeverityNormal
+	}
 }
 
 // ReservationStatus represents the current status of reservations
 type ReservationStatus struct {
-	Programs    []Program `json:"programs"`
+	Programs    []ProgramStatus `json:"programs"`
 	CheckedAt   time.Time `json:"checked_at"`
 	HasOpenings bool      `json:"has_openings"`
+	Sessions    []Session `json:"sessions,omitempty"` // 예약 가능한 회차 (날짜를 파싱한 경우)
+	AlertID     string    `json:"alert_id,omitempty"` // 확인/예약 담당 링크에 사용할 알림 ID
 }
\ No newline at end of file
@@ -62,7 +62,8 @@ func GetAllProgramNames() []string {
 	return programs
 }
 
-// GetKoreanProgramNames returns Korean names for programs (mapping)
+// ProgramNameMap holds the Korean names the booking site uses for programs, for matching page text.
+// 화면에 표시할 이름은 i18n.ProgramName을 사용하세요.
 var ProgramNameMap = map[string]string{
 	"Test Drive":          "테스트 드라이브",
 	"Off-Road":           "오프로드",
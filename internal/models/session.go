@@ -0,0 +1,64 @@
+package models
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Session is one bookable date/time of a program, parsed from the reservation page
+type Session struct {
+	Program  string    `json:"program"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end,omitempty"`       // 종료 시각 (알 수 없으면 zero)
+	AllDay   bool      `json:"all_day,omitempty"`   // 시간 없이 날짜만 표시된 경우
+	Open     bool      `json:"open"`                // 매진/마감 표시가 없으면 true
+	URL      string    `json:"url,omitempty"`       // 예약 링크 (없으면 예약 페이지)
+	Price    int       `json:"price,omitempty"`     // 가격 (원, 0이면 알 수 없음)
+	Seats    int       `json:"seats"`               // 잔여석 (-1이면 알 수 없음)
+	SeatRate float64   `json:"seat_rate,omitempty"` // 최근 잔여석 감소 속도 (분당 석 수, 0이면 줄지 않았거나 모름)
+	Details  string    `json:"details,omitempty"`   // 회차 행의 텍스트 (트랙/차량 필터에 사용)
+}
+
+// Key identifies the session across checks (program + start time)
+func (s Session) Key() string {
+	if s.AllDay {
+		return s.Program + "|" + s.Start.Format("2006-01-02")
+	}
+	return s.Program + "|" + s.Start.Format(time.RFC3339)
+}
+
+// ID is a short stable identifier of the session, safe to use in URLs
+func (s Session) ID() string {
+	sum := sha1.Sum([]byte(s.Key()))
+	return hex.EncodeToString(sum[:6])
+}
+
+// SeatStatus describes the remaining seats and how fast they are going,
+// 예: "3석 남음 · 분당 약 1석 감소 (약 3분 후 마감 예상)". 잔여석을 모르면 "".
+func (s Session) SeatStatus() string {
+	if s.Seats < 0 {
+		return ""
+	}
+	text := fmt.Sprintf(i18n.T("models.seats_left"), s.Seats)
+	if s.SeatRate <= 0 || s.Seats == 0 {
+		return text
+	}
+
+	if s.SeatRate >= 1 {
+		text += fmt.Sprintf(i18n.T("models.seats_per_minute"), strconv.FormatFloat(math.Round(s.SeatRate*10)/10, 'f', -1, 64))
+	} else {
+		text += fmt.Sprintf(i18n.T("models.seats_every"), 1/s.SeatRate)
+	}
+	minutes := math.Ceil(float64(s.Seats) / s.SeatRate)
+	if minutes < 60 {
+		text += fmt.Sprintf(i18n.T("models.sold_out_minutes"), minutes)
+	} else {
+		text += fmt.Sprintf(i18n.T("models.sold_out_hours"), math.Round(minutes/60))
+	}
+	return text
+}
@@ -0,0 +1,191 @@
+package monitor
+
+import (
+	"bmw-driving-center-alter/internal/claims"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/models"
+	"bmw-driving-center-alter/internal/notifier"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// AlertStatus is a sent alert with the account it belongs to and the claims of its sessions
+type AlertStatus struct {
+	notifier.Alert
+	Account string
+	Claims  map[string]claims.Claim // 회차 ID별 예약 담당
+}
+
+// Claimed reports whether any session of the alert has been claimed
+func (a AlertStatus) Claimed() bool {
+	return len(a.Claims) > 0
+}
+
+// Alerts returns the recently sent alerts of all accounts, newest first
+func (e *Engine) Alerts() []AlertStatus {
+	var alerts []AlertStatus
+	for _, account := range e.accounts {
+		for _, alert := range account.policy.Alerts() {
+			alerts = append(alerts, e.alertStatus(account.Config.Name, alert))
+		}
+	}
+	sort.SliceStable(alerts, func(i, j int) bool { return alerts[i].SentAt.After(alerts[j].SentAt) })
+	return alerts
+}
+
+// Alert returns a recently sent alert by ID
+func (e *Engine) Alert(id string) (AlertStatus, bool) {
+	for _, account := range e.accounts {
+		if alert, ok := account.policy.Alert(id); ok {
+			return e.alertStatus(account.Config.Name, alert), true
+		}
+	}
+	return AlertStatus{}, false
+}
+
+func (e *Engine) alertStatus(account string, alert notifier.Alert) AlertStatus {
+	status := AlertStatus{Alert: alert, Account: account, Claims: make(map[string]claims.Claim)}
+	if e.claims == nil {
+		return status
+	}
+	for _, session := range alert.Sessions {
+		if claim, ok := e.claims.Get(session.ID()); ok {
+			status.Claims[session.ID()] = claim
+		}
+	}
+	return status
+}
+
+// Acknowledge marks a sent alert as handled so it is not escalated further
+func (e *Engine) Acknowledge(alertID, by string) error {
+	account, err := e.acknowledge(alertID, by)
+	if err != nil {
+		return err
+	}
+	e.emit(account, EventClaim, fmt.Sprintf(i18n.T("monitor.alert_acked"), by))
+	return nil
+}
+
+// acknowledge marks the alert as handled and returns the account it belongs to
+func (e *Engine) acknowledge(alertID, by string) (string, error) {
+	for _, account := range e.accounts {
+		if err := account.policy.Acknowledge(alertID, by); err == nil {
+			return account.Config.Name, nil
+		}
+	}
+	return "", fmt.Errorf(i18n.T("monitor.alert_not_found"), alertID)
+}
+
+// Claim records that someone is booking a session of an alert. 알림도 확인 처리되어
+// 에스컬레이션이 멈추고, 이후 확인에서 그 회차는 다시 알리지 않습니다.
+func (e *Engine) Claim(alertID, sessionID, by string) (claims.Claim, error) {
+	if e.claims == nil {
+		return claims.Claim{}, errors.New(i18n.T("monitor.claims_not_set"))
+	}
+	alert, ok := e.Alert(alertID)
+	if !ok {
+		return claims.Claim{}, fmt.Errorf(i18n.T("monitor.alert_not_found"), alertID)
+	}
+	session, ok := findSession(alert.Sessions, sessionID)
+	if !ok {
+		return claims.Claim{}, fmt.Errorf(i18n.T("monitor.session_not_in_alert"), sessionID)
+	}
+
+	claim, err := e.claims.Claim(claims.Claim{
+		Session: sessionID,
+		Program: session.Program,
+		Account: alert.Account,
+		Start:   session.Start,
+		By:      by,
+		AlertID: alertID,
+	})
+	if err != nil {
+		return claim, err
+	}
+
+	e.acknowledge(alertID, by)
+	e.emit(alert.Account, EventClaim, fmt.Sprintf(i18n.T("monitor.claimed"), by, session.Program, formatSessionTime(session)))
+	return claim, nil
+}
+
+// Release removes someone's claim of a session, so it is alerted again when open
+func (e *Engine) Release(alertID, sessionID, by string) error {
+	if e.claims == nil {
+		return errors.New(i18n.T("monitor.claims_not_set"))
+	}
+	alert, ok := e.Alert(alertID)
+	if !ok {
+		return fmt.Errorf(i18n.T("monitor.alert_not_found"), alertID)
+	}
+	session, ok := findSession(alert.Sessions, sessionID)
+	if !ok {
+		return fmt.Errorf(i18n.T("monitor.session_not_in_alert"), sessionID)
+	}
+	if err := e.claims.Release(sessionID, by); err != nil {
+		return err
+	}
+	e.emit(alert.Account, EventClaim, fmt.Sprintf(i18n.T("monitor.unclaimed"), by, session.Program, formatSessionTime(session)))
+	return nil
+}
+
+// Claims returns the current claims (nil when no store is set)
+func (e *Engine) Claims() []claims.Claim {
+	if e.claims == nil {
+		return nil
+	}
+	list, err := e.claims.List()
+	if err != nil {
+		e.emit("", EventWarning, fmt.Sprintf("⚠️ %v", err))
+	}
+	return list
+}
+
+// allClaimed reports whether the program has open sessions and all of them are claimed
+func (e *Engine) allClaimed(program string, sessions []models.Session) bool {
+	if e.claims == nil {
+		return false
+	}
+	open := 0
+	for _, session := range sessions {
+		if session.Program != program || !session.Open {
+			continue
+		}
+		open++
+		if _, claimed := e.claims.Get(session.ID()); !claimed {
+			return false
+		}
+	}
+	return open > 0
+}
+
+// unclaimed drops the sessions someone has already claimed
+func (e *Engine) unclaimed(sessions []models.Session) []models.Session {
+	if e.claims == nil {
+		return sessions
+	}
+	var kept []models.Session
+	for _, session := range sessions {
+		if _, claimed := e.claims.Get(session.ID()); !claimed {
+			kept = append(kept, session)
+		}
+	}
+	return kept
+}
+
+func findSession(sessions []models.Session, id string) (models.Session, bool) {
+	for _, session := range sessions {
+		if session.ID() == id {
+			return session, true
+		}
+	}
+	return models.Session{}, false
+}
+
+// formatSessionTime formats a session start for messages (11/07 (Sat) 10:00)
+func formatSessionTime(session models.Session) string {
+	if session.AllDay {
+		return i18n.FormatTime(session.Start, "01/02 (Mon)")
+	}
+	return i18n.FormatTime(session.Start, "01/02 (Mon) 15:04")
+}
@@ -0,0 +1,118 @@
+package monitor
+
+import (
+	"bmw-driving-center-alter/internal/analytics"
+	"bmw-driving-center-alter/internal/history"
+	"bmw-driving-center-alter/internal/i18n"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Polling boost defaults (monitor.boost)
+const (
+	defaultBoostInterval    = 60 // 초
+	defaultBoostMinOpenings = 3
+	defaultBoostDays        = 90
+
+	boostRelearn = 6 * time.Hour    // 기록에서 시간대를 다시 학습하는 주기
+	boostLead    = 10 * time.Minute // 시간대가 시작되기 이만큼 전부터 자주 확인
+)
+
+// boostState is the learned opening windows; only used from Run's goroutine
+type boostState struct {
+	windows []analytics.Window
+	learned time.Time
+	active  bool
+}
+
+// checkInterval returns the time to wait before the next scheduled check.
+// monitor.boost가 켜져 있으면 기록상 자주 열리던 요일/시간대에 간격을 줄입니다.
+func (e *Engine) checkInterval(now time.Time) time.Duration {
+	e.configMu.RLock()
+	monitorCfg := e.cfg.Monitor
+	e.configMu.RUnlock()
+
+	interval := time.Duration(monitorCfg.Interval) * time.Second
+	if interval <= 0 {
+		interval = 60 * time.Second
+	}
+	boost := monitorCfg.Boost
+	if !boost.Enabled || e.history == nil {
+		return interval
+	}
+
+	if now.Sub(e.boost.learned) >= boostRelearn {
+		e.learnWindows(now)
+	}
+	active := analytics.InWindow(e.boost.windows, now, boostLead)
+	if active != e.boost.active {
+		e.boost.active = active
+		if active {
+			e.emit("", EventInfo, i18n.T("monitor.boost_on"))
+		} else {
+			e.emit("", EventInfo, i18n.T("monitor.boost_off"))
+		}
+	}
+	if !active {
+		// 긴 간격 때문에 시간대 시작을 놓치지 않도록 그 전에 한 번 깨어남
+		if until := analytics.UntilWindow(e.boost.windows, now); until > boostLead && until-boostLead < interval {
+			return until - boostLead
+		}
+		return interval
+	}
+
+	boosted := time.Duration(boost.Interval) * time.Second
+	if boost.Interval == 0 {
+		boosted = defaultBoostInterval * time.Second
+	}
+	return min(interval, boosted)
+}
+
+// learnWindows finds the weekdays and hours in which the watched programs usually opened
+func (e *Engine) learnWindows(now time.Time) {
+	e.boost.learned = now
+
+	e.configMu.RLock()
+	boost := e.cfg.Monitor.Boost
+	watched := make(map[string]bool)
+	for _, account := range e.cfg.GetAccounts() {
+		for _, program := range account.Programs {
+			watched[program.Name] = true
+		}
+	}
+	e.configMu.RUnlock()
+
+	days := boost.Days
+	if days == 0 {
+		days = defaultBoostDays
+	}
+	minOpenings := boost.MinOpenings
+	if minOpenings == 0 {
+		minOpenings = defaultBoostMinOpenings
+	}
+
+	entries, err := e.history.Read(history.Filter{Since: now.AddDate(0, 0, -days)})
+	if err != nil {
+		e.emit("", EventWarning, fmt.Sprintf(i18n.T("monitor.boost_history_failed"), err))
+		return
+	}
+	var stats []analytics.ProgramStats
+	for _, s := range analytics.Analyze(entries, analytics.Options{}) {
+		if watched[s.Program] {
+			stats = append(stats, s)
+		}
+	}
+	windows := analytics.LearnedWindows(stats, minOpenings)
+	changed := fmt.Sprint(windows) != fmt.Sprint(e.boost.windows)
+	e.boost.windows = windows
+	if !changed || len(windows) == 0 {
+		return
+	}
+
+	var names []string
+	for _, w := range windows {
+		names = append(names, w.String())
+	}
+	e.emit("", EventInfo, fmt.Sprintf(i18n.T("monitor.boost_windows"), days, strings.Join(names, ", ")))
+}
@@ -0,0 +1,129 @@
+package monitor
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/models"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Cancellation detection settings
+const (
+	cancellationMaxSeats = 3                // 매진 회차에 이보다 많은 자리가 한꺼번에 생기면 취소가 아니라 추가 공개로 봄
+	cancellationCooldown = 30 * time.Minute // 같은 회차의 취소석은 이 시간 안에 다시 알리지 않음
+)
+
+// sessionSnapshot is whether a session was sold out at the last check
+type sessionSnapshot struct {
+	time time.Time
+	full bool
+}
+
+// detectCancellations compares the sessions with the previous check and returns the sold-out
+// sessions in which a few seats reappeared. 처음 보는 회차는 새 회차 공개이므로 여기서 다루지 않습니다.
+// 계정마다 따로 비교하므로 같은 프로그램을 보는 모든 계정의 수신자가 알림을 받습니다.
+func (e *Engine) detectCancellations(account string, sessions []models.Session, now time.Time) []models.Session {
+	if e.sessionSeen == nil {
+		e.sessionSeen = make(map[string]sessionSnapshot)
+		e.cancelOpen = make(map[string]bool)
+		e.cancelSent = make(map[string]time.Time)
+	}
+
+	var reappeared []models.Session
+	for _, session := range sessions {
+		key := accountSessionKey(account, session)
+		full := !session.Open || session.Seats == 0
+		previous, seen := e.sessionSeen[key]
+		e.sessionSeen[key] = sessionSnapshot{time: now, full: full}
+
+		if full {
+			delete(e.cancelOpen, key)
+			continue
+		}
+		// 잔여석을 모르는 회차(-1)는 취소인지 추가 공개인지 알 수 없으므로 새 회차 알림으로 보냄
+		if !seen || !previous.full || session.Seats <= 0 || session.Seats > cancellationMaxSeats {
+			continue
+		}
+		e.cancelOpen[key] = true
+		if sent, ok := e.cancelSent[key]; ok && now.Sub(sent) < cancellationCooldown {
+			continue
+		}
+		e.cancelSent[key] = now
+		reappeared = append(reappeared, session)
+	}
+
+	for key, snapshot := range e.sessionSeen {
+		if now.Sub(snapshot.time) > seatForget {
+			delete(e.sessionSeen, key)
+			delete(e.cancelOpen, key)
+		}
+	}
+	for key, sent := range e.cancelSent {
+		if now.Sub(sent) > cancellationCooldown && !e.cancelOpen[key] {
+			delete(e.cancelSent, key)
+		}
+	}
+	return reappeared
+}
+
+// onlyCancellations reports whether every open session of the program is a cancellation seat,
+// in which case the program is announced by the cancellation alert instead of a new-opening alert
+func (e *Engine) onlyCancellations(account, program string, sessions []models.Session) bool {
+	open := openSessionsOf(sessions, []string{program})
+	if len(open) == 0 {
+		return false
+	}
+	for _, session := range open {
+		if session.Seats == 0 || !e.cancelOpen[accountSessionKey(account, session)] {
+			return false
+		}
+	}
+	return true
+}
+
+// notifyCancellations sends the cancellation-seat alert, skipping programs that are muted or
+// disabled, sessions someone already claimed, and sessions on the watchlist (회차 감시 알림이 따로 감)
+func (e *Engine) notifyCancellations(account *Account, reappeared []models.Session, now time.Time) {
+	name := account.Config.Name
+	watched := e.activeWatches()
+
+	var programs []models.Program
+	var sessions []models.Session
+	for _, program := range account.Config.Programs {
+		if !e.programEnabled(name, program.Name) || e.muted(name, program.Name) {
+			continue
+		}
+		var matched []models.Session
+		for _, session := range e.unclaimed(openSessionsOf(reappeared, []string{program.Name})) {
+			if !watchedBy(watched, name, session) {
+				matched = append(matched, session)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+		programs = append(programs, program)
+		sessions = append(sessions, matched...)
+	}
+	if len(sessions) == 0 {
+		return
+	}
+
+	var lines []string
+	for _, session := range sessions {
+		line := fmt.Sprintf("%s %s", session.Program, formatSessionTime(session))
+		if status := session.SeatStatus(); status != "" {
+			line += " - " + status
+		}
+		lines = append(lines, line)
+	}
+	e.emit(name, EventCancellation, i18n.T("monitor.cancellation")+strings.Join(lines, ", "))
+
+	if !e.notify {
+		return
+	}
+	if delivery, sent := account.policy.Cancellation(programs, sessions, now); sent {
+		e.reportDelivery(name, delivery)
+	}
+}
@@ -0,0 +1,776 @@
+package monitor
+
+import (
+	"bmw-driving-center-alter/internal/browser"
+	"bmw-driving-center-alter/internal/claims"
+	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/history"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/models"
+	"bmw-driving-center-alter/internal/mute"
+	"bmw-driving-center-alter/internal/notifier"
+	"bmw-driving-center-alter/internal/watch"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// notifyCooldown is how long to wait before notifying the same program again
+const notifyCooldown = time.Hour
+
+// policyTick is how often held digests and escalations are checked
+const policyTick = time.Minute
+
+// Session health settings
+const (
+	sessionRenewBefore = 5 * time.Minute  // 세션 쿠키 만료 전 미리 갱신
+	reloginBaseDelay   = time.Minute      // 재로그인 실패 시 첫 대기 시간 (이후 2배씩 증가)
+	reloginMaxDelay    = 30 * time.Minute // 재로그인 최대 대기 시간
+	reloginAlertAfter  = 3                // 연속 실패 횟수가 이 값에 도달하면 재인증 필요 알림
+)
+
+// EventType identifies the kind of engine event
+type EventType string
+
+const (
+	EventInfo    EventType = "info"    // 진행 상황
+	EventWarning EventType = "warning" // 경고
+	EventError   EventType = "error"   // 오류
+	EventCaptcha EventType = "captcha" // CAPTCHA 감지
+	EventSession EventType = "session" // 세션 만료/재로그인
+	EventOpened  EventType = "opened"  // 새로 예약 가능해진 프로그램 알림
+	EventCheck   EventType = "check"   // 확인 완료 (Result 포함)
+	EventClaim   EventType = "claim"   // 알림 확인 / 예약 담당 등록·취소
+
+	EventLastSeats    EventType = "last_seats"   // 알린 회차의 잔여석이 notify.last_seats 이하로 줄어듦
+	EventWatch        EventType = "watch"        // 감시 중인 회차에 빈자리가 생김 / 감시 추가·삭제
+	EventCancellation EventType = "cancellation" // 매진 회차에 자리가 다시 생김 (취소석, 새 회차 공개와 별도)
+)
+
+// Event is emitted by the engine for front-ends (CLI, GUI) to display
+type Event struct {
+	Time    time.Time
+	Account string
+	Type    EventType
+	Message string
+	Result  *CheckResult
+}
+
+// Level returns the log level used when the event is written to the log
+func (e Event) Level() slog.Level {
+	switch e.Type {
+	case EventError:
+		return slog.LevelError
+	case EventWarning, EventCaptcha, EventSession:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// CheckResult is the outcome of checking one account's programs
+type CheckResult struct {
+	Account         string
+	CheckedAt       time.Time
+	Programs        []string // 확인한 프로그램 (설정 순서)
+	Availability    map[string]bool
+	NewlyOpened     []string
+	CaptchaDetected bool
+	Session         browser.SessionInfo
+	Sessions        []models.Session  // 파싱한 회차 (날짜/시간을 찾지 못하면 비어 있음)
+	Links           map[string]string // 프로그램별 예약 링크 (찾은 프로그램만)
+}
+
+// Account holds the per-account browser session and notification state
+type Account struct {
+	Config       config.AccountConfig
+	client       *browser.BrowserClient
+	notifier     *notifier.EmailNotifier
+	policy       *notifier.Policy // 조용한 시간/요약/에스컬레이션
+	lastNotified map[string]time.Time
+	ready        bool
+
+	// 세션 상태
+	needsLogin       bool
+	loginFailures    int
+	nextLoginAttempt time.Time
+	sessionAlertSent bool
+
+	handoffUntil time.Time // 사람이 브라우저로 예약하는 동안 확인을 멈춤 (OpenInBrowser)
+}
+
+// Ready reports whether the account's browser is started and logged in
+func (a *Account) Ready() bool {
+	return a.ready
+}
+
+// SessionInfo returns the account's current browser session information
+func (a *Account) SessionInfo() browser.SessionInfo {
+	if a.client == nil {
+		return browser.SessionInfo{}
+	}
+	return a.client.SessionInfo()
+}
+
+// NotificationTest is the result of sending a test email for one account
+type NotificationTest struct {
+	Account    string
+	Recipients []string
+	Err        error
+}
+
+// Engine schedules checks for all configured accounts
+type Engine struct {
+	cfg        *config.Config
+	configMu   sync.RWMutex // UpdateConfig와 Config 사이 (확인 중에는 mu로 충분)
+	accounts   []*Account
+	handlers   []func(Event)
+	checkCount int
+	mu         sync.Mutex
+	history    *history.Store
+	claims     *claims.Store
+	mutes      *mute.Store
+	watches    *watch.Store
+	notify     bool
+
+	// 실행 중 제어 (TUI 등)
+	checkNow  chan struct{}
+	paused    bool
+	nextCheck time.Time
+	controlMu sync.Mutex
+
+	// 프로그램별 상태
+	states     map[string]*ProgramState
+	stateOrder []string
+	stateMu    sync.RWMutex
+
+	// 자주 열리는 시간대 학습 (monitor.boost)
+	boost boostState
+
+	// 회차별 잔여석 기록 (확인 중에만 사용하므로 mu로 보호)
+	seatSamples   map[string][]seatSample
+	seatsSeen     map[string]seatSample // 계정|회차 → 그 계정의 직전 확인
+	lastSeatsSent map[string]bool       // 계정|회차 → 마지막 좌석 알림을 보냈는지
+
+	// 감시|계정|회차별 마지막 예약 가능 여부 (같은 빈자리를 계정마다 한 번만 알리도록)
+	watchOpen map[string]bool
+
+	// 계정|회차별 직전 매진 여부와 취소석 알림 기록 (mu로 보호)
+	sessionSeen map[string]sessionSnapshot
+	cancelOpen  map[string]bool      // 취소석으로 열려 있는 회차 (다시 찰 때까지)
+	cancelSent  map[string]time.Time // 마지막 취소석 알림
+}
+
+// NewEngine creates a monitoring engine for every account in the configuration
+func NewEngine(cfg *config.Config) (*Engine, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	engine := &Engine{
+		cfg:      cfg,
+		checkNow: make(chan struct{}, 1),
+		notify:   true,
+	}
+	for _, accountCfg := range cfg.GetAccounts() {
+		emailNotifier := newAccountNotifier(cfg, accountCfg)
+		engine.accounts = append(engine.accounts, &Account{
+			Config:       accountCfg,
+			notifier:     emailNotifier,
+			policy:       notifier.NewPolicy(cfg.Notify, emailNotifier),
+			lastNotified: make(map[string]time.Time),
+		})
+	}
+	engine.syncStates()
+
+	return engine, nil
+}
+
+// newAccountNotifier creates an email notifier that sends to the account's recipients
+func newAccountNotifier(cfg *config.Config, account config.AccountConfig) *notifier.EmailNotifier {
+	emailCfg := cfg.Email
+	emailCfg.To = cfg.RecipientsFor(account)
+	emailNotifier := notifier.NewEmailNotifier(emailCfg)
+	emailNotifier.SetLinkBase(cfg.Server.BaseURL())
+	return emailNotifier
+}
+
+// OnEvent registers a handler that receives every engine event
+func (e *Engine) OnEvent(handler func(Event)) {
+	e.handlers = append(e.handlers, handler)
+}
+
+// SetHistory sets the store that records every check result
+func (e *Engine) SetHistory(store *history.Store) {
+	e.history = store
+}
+
+// History returns the store of check results (nil when not set)
+func (e *Engine) History() *history.Store {
+	return e.history
+}
+
+// SetClaims sets the store that records who claimed which session.
+// 설정하면 담당자가 있는 회차는 다시 알리지 않습니다.
+func (e *Engine) SetClaims(store *claims.Store) {
+	e.claims = store
+}
+
+// SetNotify enables or disables email notifications for openings and CAPTCHAs.
+// 한 번만 확인하는 스크립트 실행 등에서 끌 수 있습니다 (기본값: 켜짐).
+func (e *Engine) SetNotify(enabled bool) {
+	e.notify = enabled
+}
+
+// Accounts returns the accounts managed by the engine
+func (e *Engine) Accounts() []*Account {
+	return e.accounts
+}
+
+func (e *Engine) emit(account string, eventType EventType, message string) {
+	e.emitEvent(Event{
+		Time:    time.Now(),
+		Account: account,
+		Type:    eventType,
+		Message: message,
+	})
+}
+
+func (e *Engine) emitEvent(event Event) {
+	for _, handler := range e.handlers {
+		handler(event)
+	}
+}
+
+// Start launches a browser for each account and logs in.
+// 로그인에 실패한 계정은 건너뛰며, 모든 계정이 실패하면 오류를 반환합니다.
+func (e *Engine) Start() error {
+	readyCount := 0
+	for _, account := range e.accounts {
+		if err := e.startAccount(account); err != nil {
+			e.emit(account.Config.Name, EventError, err.Error())
+			continue
+		}
+		account.ready = true
+		readyCount++
+	}
+
+	if readyCount == 0 {
+		return errors.New(i18n.T("monitor.no_accounts"))
+	}
+	return nil
+}
+
+func (e *Engine) startAccount(account *Account) error {
+	name := account.Config.Name
+
+	client, err := browser.NewBrowserClientForAccount(e.cfg, account.Config)
+	if err != nil {
+		return fmt.Errorf(i18n.T("monitor.browser_init_failed"), err)
+	}
+	account.client = client
+
+	if e.cfg.Monitor.Headless {
+		e.emit(name, EventInfo, i18n.T("monitor.browser_headless"))
+	} else {
+		e.emit(name, EventInfo, i18n.T("monitor.browser_visible"))
+	}
+	if err := client.Start(e.cfg.Monitor.Headless); err != nil {
+		return fmt.Errorf(i18n.T("monitor.browser_start_failed"), err)
+	}
+
+	e.emit(name, EventInfo, i18n.T("monitor.checking_login"))
+	if client.CheckLoginStatus() {
+		e.emit(name, EventInfo, i18n.T("monitor.session_valid"))
+		return nil
+	}
+
+	// "session import"로 가져온 세션이 있으면 먼저 적용
+	applied, err := client.ApplyPendingSession()
+	if err != nil {
+		e.emit(name, EventWarning, fmt.Sprintf(i18n.T("monitor.imported_session_failed"), err))
+	} else if applied {
+		if client.CheckLoginStatus() {
+			e.emit(name, EventInfo, i18n.T("monitor.imported_session_ok"))
+			return nil
+		}
+		e.emit(name, EventWarning, i18n.T("monitor.imported_session_invalid"))
+	}
+
+	e.emit(name, EventInfo, fmt.Sprintf(i18n.T("monitor.login_start"), account.Config.Username))
+	if err := client.Login(account.Config.Username, account.Config.Password); err != nil {
+		return fmt.Errorf(i18n.T("monitor.login_failed"), err)
+	}
+	e.emit(name, EventInfo, i18n.T("monitor.login_ok"))
+	return nil
+}
+
+// Run checks all accounts immediately and then on every interval until stop is signalled
+func (e *Engine) Run(stop <-chan bool) {
+	interval := e.checkInterval(time.Now())
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	policyTicker := time.NewTicker(policyTick)
+	defer policyTicker.Stop()
+
+	e.CheckAll()
+	e.setNextCheck(time.Now().Add(interval))
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-e.checkNow:
+			e.CheckAll()
+		case now := <-policyTicker.C:
+			// 요약/에스컬레이션만 보내고 확인 일정은 그대로
+			e.tickNotifications(now)
+			continue
+		case <-ticker.C:
+			if e.Paused() {
+				e.setNextCheck(time.Now().Add(interval))
+				continue
+			}
+			e.CheckAll()
+		}
+		// 수동 확인 후에도 다음 확인까지 전체 간격을 기다림
+		interval = e.checkInterval(time.Now())
+		ticker.Reset(interval)
+		e.setNextCheck(time.Now().Add(interval))
+	}
+}
+
+// TriggerCheck asks Run to check all accounts right away, even while paused
+func (e *Engine) TriggerCheck() {
+	select {
+	case e.checkNow <- struct{}{}:
+	default:
+		// 이미 요청됨
+	}
+}
+
+// SetPaused pauses or resumes scheduled checks
+func (e *Engine) SetPaused(paused bool) {
+	e.controlMu.Lock()
+	e.paused = paused
+	e.controlMu.Unlock()
+
+	if paused {
+		e.emit("", EventInfo, i18n.T("monitor.paused"))
+	} else {
+		e.emit("", EventInfo, i18n.T("monitor.resumed"))
+	}
+}
+
+// Paused reports whether scheduled checks are paused
+func (e *Engine) Paused() bool {
+	e.controlMu.Lock()
+	defer e.controlMu.Unlock()
+	return e.paused
+}
+
+// NextCheck returns when the next scheduled check runs (zero before Run starts)
+func (e *Engine) NextCheck() time.Time {
+	e.controlMu.Lock()
+	defer e.controlMu.Unlock()
+	return e.nextCheck
+}
+
+func (e *Engine) setNextCheck(t time.Time) {
+	e.controlMu.Lock()
+	e.nextCheck = t
+	e.controlMu.Unlock()
+}
+
+// SendTestNotification sends a test email to every account's recipients
+func (e *Engine) SendTestNotification() []NotificationTest {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var results []NotificationTest
+	for _, account := range e.accounts {
+		name := account.Config.Name
+		result := NotificationTest{
+			Account:    name,
+			Recipients: e.cfg.RecipientsFor(account.Config),
+		}
+
+		e.emit(name, EventInfo, i18n.T("monitor.test_email_sending"))
+		result.Err = account.notifier.TestConnection()
+		if result.Err != nil {
+			e.emit(name, EventError, fmt.Sprintf(i18n.T("monitor.test_email_failed"), result.Err))
+		} else {
+			e.emit(name, EventInfo, fmt.Sprintf(i18n.T("monitor.test_email_sent"), strings.Join(result.Recipients, ", ")))
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// CheckAll checks every ready account once, one after another
+func (e *Engine) CheckAll() []CheckResult {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.checkCount++
+	e.emit("", EventInfo, fmt.Sprintf(i18n.T("monitor.checking"), e.checkCount))
+	e.refreshAccounts()
+
+	var results []CheckResult
+	for _, account := range e.accounts {
+		if !account.ready {
+			continue
+		}
+		if result, ok := e.checkAccount(account); ok {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// refreshAccounts picks up program and recipient changes made to the config while running
+func (e *Engine) refreshAccounts() {
+	for _, accountCfg := range e.cfg.GetAccounts() {
+		for _, account := range e.accounts {
+			if account.Config.Name == accountCfg.Name {
+				account.Config = accountCfg
+				account.notifier = newAccountNotifier(e.cfg, accountCfg)
+				account.policy.Update(e.cfg.Notify, account.notifier)
+				break
+			}
+		}
+	}
+	e.syncStates()
+}
+
+// UpdateConfig changes the running configuration between checks.
+// update가 오류를 반환하거나 바뀐 설정이 유효하지 않으면 아무것도 바꾸지 않습니다.
+// 계정 추가/삭제는 반영되지 않으며, 설정 파일 저장은 호출하는 쪽에서 합니다.
+func (e *Engine) UpdateConfig(update func(cfg *config.Config) error) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	next, err := e.cfg.Clone()
+	if err != nil {
+		return err
+	}
+	if err := update(next); err != nil {
+		return err
+	}
+	if err := next.Validate(); err != nil {
+		return err
+	}
+
+	// 같은 설정을 쓰는 GUI 등에도 보이도록 내용을 바꿔 끼움
+	e.configMu.Lock()
+	*e.cfg = *next
+	e.configMu.Unlock()
+	e.refreshAccounts()
+	return nil
+}
+
+// Config returns a copy of the running configuration
+func (e *Engine) Config() (*config.Config, error) {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+	return e.cfg.Clone()
+}
+
+func (e *Engine) checkAccount(account *Account) (CheckResult, bool) {
+	name := account.Config.Name
+	checkTime := time.Now()
+
+	if account.handedOff(checkTime) {
+		e.emit(name, EventInfo, fmt.Sprintf(i18n.T("monitor.handoff_skip"), account.handoffUntil.Format("15:04")))
+		return CheckResult{}, false
+	}
+
+	var programs []models.Program
+	var programNames []string
+	for _, program := range account.Config.Programs {
+		if e.programEnabled(name, program.Name) {
+			programs = append(programs, program)
+			programNames = append(programNames, program.Name)
+		}
+	}
+	if len(programNames) == 0 {
+		e.emit(name, EventInfo, i18n.T("monitor.nothing_to_check"))
+		return CheckResult{}, false
+	}
+
+	// 세션이 만료된 상태면 백오프에 따라 재로그인
+	if account.needsLogin {
+		if time.Now().Before(account.nextLoginAttempt) {
+			e.emit(name, EventWarning, fmt.Sprintf(i18n.T("monitor.session_expired_wait"), account.nextLoginAttempt.Format("15:04:05")))
+			return CheckResult{}, false
+		}
+		if err := e.relogin(account); err != nil {
+			return CheckResult{}, false
+		}
+	}
+
+	// 세션 쿠키 만료가 임박하면 미리 갱신
+	session := account.client.SessionInfo()
+	if !session.ExpiresAt.IsZero() && time.Until(session.ExpiresAt) < sessionRenewBefore {
+		e.emit(name, EventSession, fmt.Sprintf(i18n.T("monitor.session_refresh_soon"), session.ExpiresAt.Format("15:04:05")))
+		if err := account.client.RefreshSession(account.Config.Username, account.Config.Password); err != nil {
+			e.emit(name, EventWarning, fmt.Sprintf(i18n.T("monitor.session_refresh_failed"), err))
+		}
+	}
+
+	e.emit(name, EventInfo, fmt.Sprintf(i18n.T("monitor.checking_programs"), checkTime.Format("15:04:05"), len(programNames)))
+
+	// 예약 페이지 확인 (hCaptcha 감지 포함)
+	availability, captchaDetected, err := account.client.CheckReservationPageWithCaptchaAlert(programs)
+	if errors.Is(err, browser.ErrSessionExpired) {
+		// 로그인 페이지로 리다이렉트됨 - 재로그인 후 한 번 더 확인
+		e.emit(name, EventSession, i18n.T("monitor.session_expired_relogin"))
+		account.needsLogin = true
+		if err := e.relogin(account); err != nil {
+			return CheckResult{}, false
+		}
+		availability, captchaDetected, err = account.client.CheckReservationPageWithCaptchaAlert(programs)
+	}
+	if err != nil {
+		e.emit(name, EventError, fmt.Sprintf(i18n.T("monitor.check_failed"), err))
+		return CheckResult{}, false
+	}
+
+	// hCaptcha가 감지되면 이메일 알림 전송
+	if captchaDetected && e.notify {
+		e.emit(name, EventCaptcha, i18n.T("monitor.captcha_detected"))
+		if err := account.notifier.SendCaptchaAlert(); err != nil {
+			e.emit(name, EventError, fmt.Sprintf(i18n.T("monitor.captcha_alert_failed"), err))
+		}
+	}
+
+	// 회차 필터 적용 - 조건에 맞는 회차가 있어야 예약 가능으로 간주
+	sessions := e.applyFilters(name, account.Config.Programs, availability, account.client.LastSessions(), checkTime)
+	sessions, lastSeats := e.trackSeats(name, sessions, e.cfg.Notify.LastSeats, checkTime)
+	cancellations := e.detectCancellations(name, sessions, checkTime)
+	links := account.client.LastProgramLinks()
+	e.updateStates(name, programNames, availability, sessions, links, checkTime)
+	account.policy.Observe(availability)
+
+	// 새로 예약 가능해진 프로그램 (최근 1시간 내 알림 제외)
+	var openPrograms []models.ProgramStatus
+	var newlyOpened []string
+	var mutedPrograms []string
+	for _, program := range account.Config.Programs {
+		if !availability[program.Name] || !e.programEnabled(name, program.Name) {
+			continue
+		}
+		// 음소거 중이면 알리지 않음 (해제 후 아직 열려 있으면 그때 알림)
+		if e.muted(name, program.Name) {
+			mutedPrograms = append(mutedPrograms, program.Name)
+			continue
+		}
+		// 열린 회차를 모두 누군가 맡았으면 다시 알리지 않음
+		if e.allClaimed(program.Name, sessions) {
+			continue
+		}
+		// 매진 회차에 취소석만 생긴 경우는 새 회차 공개가 아니므로 취소석 알림으로 따로 알림
+		if e.onlyCancellations(name, program.Name, sessions) {
+			continue
+		}
+		lastTime, exists := account.lastNotified[program.Name]
+		if !exists || time.Since(lastTime) > notifyCooldown {
+			openPrograms = append(openPrograms, models.ProgramStatus{
+				Program:     program,
+				IsOpen:      true,
+				LastChecked: checkTime,
+				BookingURL:  links[program.Name],
+			})
+			newlyOpened = append(newlyOpened, program.Name)
+			account.lastNotified[program.Name] = time.Now()
+		}
+	}
+
+	if len(mutedPrograms) > 0 {
+		e.emit(name, EventInfo, fmt.Sprintf(i18n.T("monitor.muted_skip"), strings.Join(mutedPrograms, ", ")))
+	}
+
+	result := CheckResult{
+		Account:         name,
+		CheckedAt:       checkTime,
+		Programs:        programNames,
+		Availability:    availability,
+		NewlyOpened:     newlyOpened,
+		CaptchaDetected: captchaDetected,
+		Session:         account.client.SessionInfo(),
+		Sessions:        sessions,
+		Links:           links,
+	}
+
+	e.recordHistory(result)
+
+	e.emitEvent(Event{
+		Time:    time.Now(),
+		Account: name,
+		Type:    EventCheck,
+		Result:  &result,
+	})
+
+	if len(newlyOpened) > 0 {
+		e.emitEvent(Event{
+			Time:    time.Now(),
+			Account: name,
+			Type:    EventOpened,
+			Message: fmt.Sprintf(i18n.T("monitor.found"), strings.Join(newlyOpened, ", ")),
+			Result:  &result,
+		})
+	}
+
+	if len(newlyOpened) > 0 && e.notify {
+		status := &models.ReservationStatus{
+			Programs:    openPrograms,
+			CheckedAt:   checkTime,
+			HasOpenings: true,
+			Sessions:    e.unclaimed(openSessionsOf(sessions, newlyOpened)),
+		}
+
+		e.emit(name, EventInfo, i18n.T("monitor.email_sending"))
+		outcome := account.policy.Submit(status, checkTime)
+		for _, delivery := range outcome.Deliveries {
+			e.reportDelivery(name, delivery)
+		}
+		if len(outcome.Held) > 0 {
+			e.emit(name, EventInfo, fmt.Sprintf(i18n.T("monitor.held"), strings.Join(outcome.Held, ", ")))
+		}
+	}
+	if len(lastSeats) > 0 {
+		e.notifyLastSeats(account, lastSeats, checkTime)
+	}
+	if len(cancellations) > 0 {
+		e.notifyCancellations(account, cancellations, checkTime)
+	}
+	e.checkWatches(account, account.client.LastSessions(), checkTime)
+
+	return result, true
+}
+
+// tickNotifications sends the digests and escalations that are due
+func (e *Engine) tickNotifications(now time.Time) {
+	if !e.notify {
+		return
+	}
+	for _, account := range e.accounts {
+		for _, delivery := range account.policy.Tick(now) {
+			e.reportDelivery(account.Config.Name, delivery)
+		}
+	}
+}
+
+// reportDelivery emits the result of a notification sent by the policy
+func (e *Engine) reportDelivery(account string, delivery notifier.Delivery) {
+	kind := i18n.T("monitor.delivery.email")
+	switch delivery.Kind {
+	case notifier.DeliveryDigest:
+		kind = i18n.T("monitor.delivery.digest")
+	case notifier.DeliveryEscalation:
+		kind = i18n.T("monitor.delivery.escalation")
+	case notifier.DeliveryLastSeats:
+		kind = i18n.T("monitor.delivery.last_seats")
+	case notifier.DeliveryCancellation:
+		kind = i18n.T("monitor.delivery.cancellation")
+	}
+
+	if delivery.Err != nil {
+		e.emit(account, EventError, fmt.Sprintf(i18n.T("monitor.delivery_failed"), kind, delivery.Err))
+		return
+	}
+	e.emit(account, EventInfo, fmt.Sprintf(i18n.T("monitor.delivery_sent"), kind,
+		strings.Join(delivery.Programs, ", "), strings.Join(delivery.Recipients, ", ")))
+}
+
+// openSessionsOf returns the open sessions of the given programs
+func openSessionsOf(sessions []models.Session, programs []string) []models.Session {
+	var open []models.Session
+	for _, session := range sessions {
+		if session.Open && slices.Contains(programs, session.Program) {
+			open = append(open, session)
+		}
+	}
+	return open
+}
+
+// recordHistory appends the check result to the history store, if one is set
+func (e *Engine) recordHistory(result CheckResult) {
+	if e.history == nil {
+		return
+	}
+
+	entry := history.Entry{
+		Time:        result.CheckedAt,
+		Account:     result.Account,
+		NewlyOpened: result.NewlyOpened,
+		Captcha:     result.CaptchaDetected,
+	}
+	for _, program := range result.Programs {
+		programResult := history.ProgramResult{
+			Name:      program,
+			Available: result.Availability[program],
+		}
+		for _, session := range openSessionsOf(result.Sessions, []string{program}) {
+			programResult.Sessions = append(programResult.Sessions, session.Start)
+		}
+		entry.Programs = append(entry.Programs, programResult)
+	}
+
+	if err := e.history.Append(entry); err != nil {
+		e.emit(result.Account, EventWarning, fmt.Sprintf(i18n.T("monitor.history_save_failed"), err))
+	}
+}
+
+// relogin logs the account in again, backing off exponentially on repeated failures
+func (e *Engine) relogin(account *Account) error {
+	name := account.Config.Name
+
+	err := account.client.Login(account.Config.Username, account.Config.Password)
+	if err == nil {
+		if account.loginFailures > 0 {
+			e.emit(name, EventSession, fmt.Sprintf(i18n.T("monitor.relogin_ok_after"), account.loginFailures))
+		} else {
+			e.emit(name, EventSession, i18n.T("monitor.relogin_ok"))
+		}
+		account.needsLogin = false
+		account.loginFailures = 0
+		account.sessionAlertSent = false
+		return nil
+	}
+
+	account.needsLogin = true
+	account.loginFailures++
+	delay := reloginBaseDelay << (account.loginFailures - 1)
+	if delay > reloginMaxDelay || delay <= 0 {
+		delay = reloginMaxDelay
+	}
+	account.nextLoginAttempt = time.Now().Add(delay)
+	e.emit(name, EventError, fmt.Sprintf(i18n.T("monitor.relogin_failed"), account.loginFailures, err, delay))
+
+	if account.loginFailures >= reloginAlertAfter && !account.sessionAlertSent {
+		e.emit(name, EventSession, i18n.T("monitor.session_lost_sending"))
+		if sendErr := account.notifier.SendSessionLostAlert(name, err); sendErr != nil {
+			e.emit(name, EventError, fmt.Sprintf(i18n.T("monitor.session_lost_failed"), sendErr))
+		} else {
+			account.sessionAlertSent = true
+		}
+	}
+
+	return err
+}
+
+// Close shuts down every account's browser
+func (e *Engine) Close() {
+	for _, account := range e.accounts {
+		if account.client != nil {
+			account.client.Close()
+			account.client = nil
+		}
+		account.ready = false
+	}
+}
@@ -0,0 +1,50 @@
+package monitor
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/models"
+	"fmt"
+	"time"
+)
+
+// applyFilters drops sessions that do not pass their program's filter and, for programs
+// with a filter, counts the program as available only if a matching session is open.
+// availability is updated in place; the matching sessions are returned.
+func (e *Engine) applyFilters(account string, programs []models.Program, availability map[string]bool, sessions []models.Session, now time.Time) []models.Session {
+	filters := make(map[string]models.SessionFilter)
+	for _, program := range programs {
+		if program.Filter.Active() {
+			filters[program.Name] = program.Filter
+		}
+	}
+	if len(filters) == 0 {
+		return sessions
+	}
+
+	var matched []models.Session
+	parsed := make(map[string]bool)
+	openMatch := make(map[string]bool)
+	for _, session := range sessions {
+		parsed[session.Program] = true
+		filter, filtered := filters[session.Program]
+		if filtered && !filter.Match(session, now) {
+			continue
+		}
+		matched = append(matched, session)
+		if session.Open {
+			openMatch[session.Program] = true
+		}
+	}
+
+	for name := range filters {
+		if !availability[name] {
+			continue
+		}
+		if !parsed[name] {
+			// 회차 날짜를 읽지 못하면 조건을 확인할 수 없으므로 알리지 않음
+			e.emit(account, EventWarning, fmt.Sprintf(i18n.T("monitor.filter_unknown_sessions"), name))
+		}
+		availability[name] = openMatch[name]
+	}
+	return matched
+}
@@ -0,0 +1,54 @@
+package monitor
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// handoffPeriod is how long checks of an account pause after its browser is handed to a person
+const handoffPeriod = 10 * time.Minute
+
+// ErrBrowserHidden is returned by OpenInBrowser when the account's browser runs headless
+var ErrBrowserHidden = i18n.NewError("monitor.handoff_headless")
+
+// OpenInBrowser shows a booking page in the account's logged-in browser window, so a person
+// can finish the booking there. 그동안 확인이 페이지를 바꾸지 않도록 이 계정의 확인을 잠시 멈춥니다.
+// account가 비어있으면 첫 번째로 준비된 계정을 사용합니다.
+func (e *Engine) OpenInBrowser(account, pageURL string) error {
+	if pageURL == "" {
+		return errors.New(i18n.T("monitor.handoff_no_url"))
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, a := range e.accounts {
+		if account != "" && a.Config.Name != account || account == "" && !a.ready {
+			continue
+		}
+		if !a.ready || a.client == nil {
+			return fmt.Errorf(i18n.T("monitor.handoff_not_ready"), a.Config.Name)
+		}
+		if a.client.Headless() {
+			return ErrBrowserHidden
+		}
+		if err := a.client.Open(pageURL); err != nil {
+			return err
+		}
+		a.handoffUntil = time.Now().Add(handoffPeriod)
+		e.emit(a.Config.Name, EventInfo, fmt.Sprintf(i18n.T("monitor.handoff_opened"),
+			a.handoffUntil.Format("15:04")))
+		return nil
+	}
+	if account != "" {
+		return fmt.Errorf(i18n.T("monitor.unknown_account"), account)
+	}
+	return errors.New(i18n.T("monitor.handoff_no_browser"))
+}
+
+// handedOff reports whether the account's browser is being used by a person
+func (a *Account) handedOff(now time.Time) bool {
+	return now.Before(a.handoffUntil)
+}
@@ -0,0 +1,113 @@
+package monitor
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/mute"
+	"bmw-driving-center-alter/internal/scraper"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SetMutes sets the store of muted programs.
+// 음소거된 프로그램도 확인은 계속하지만 알림은 보내지 않습니다.
+func (e *Engine) SetMutes(store *mute.Store) {
+	e.mutes = store
+}
+
+// Mute silences a program's notifications until the given time.
+// account가 비어있으면 그 프로그램을 감시하는 모든 계정에 적용합니다.
+func (e *Engine) Mute(account, program string, until time.Time, by string) ([]mute.Mute, error) {
+	if e.mutes == nil {
+		return nil, errors.New(i18n.T("monitor.mutes_not_set"))
+	}
+	if !until.After(time.Now()) {
+		return nil, fmt.Errorf(i18n.T("monitor.mute_past"), until.Format("2006-01-02 15:04"))
+	}
+	targets, err := e.findPrograms(account, program)
+	if err != nil {
+		return nil, err
+	}
+
+	var muted []mute.Mute
+	for _, target := range targets {
+		m := mute.Mute{Account: target.Account, Program: target.Program, Until: until, By: by, Created: time.Now()}
+		if err := e.mutes.Mute(m); err != nil {
+			return muted, err
+		}
+		muted = append(muted, m)
+		e.emit(target.Account, EventInfo, fmt.Sprintf(i18n.T("monitor.muted"), target.Program, until.Format("01-02 15:04"), by))
+	}
+	return muted, nil
+}
+
+// Unmute removes the mute of a program and returns the number of mutes removed
+func (e *Engine) Unmute(account, program string) (int, error) {
+	if e.mutes == nil {
+		return 0, errors.New(i18n.T("monitor.mutes_not_set"))
+	}
+	targets, err := e.findPrograms(account, program)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, target := range targets {
+		ok, err := e.mutes.Unmute(target.Account, target.Program)
+		if err != nil {
+			return removed, err
+		}
+		if ok {
+			removed++
+			e.emit(target.Account, EventInfo, fmt.Sprintf(i18n.T("monitor.unmuted"), target.Program))
+		}
+	}
+	return removed, nil
+}
+
+// Mutes returns the active mutes (nil when no store is set)
+func (e *Engine) Mutes() []mute.Mute {
+	if e.mutes == nil {
+		return nil
+	}
+	list, err := e.mutes.List()
+	if err != nil {
+		e.emit("", EventWarning, fmt.Sprintf("⚠️ %v", err))
+	}
+	return list
+}
+
+// muted reports whether the program's notifications are muted
+func (e *Engine) muted(account, program string) bool {
+	if e.mutes == nil {
+		return false
+	}
+	_, ok := e.mutes.Muted(account, program, time.Now())
+	return ok
+}
+
+// findPrograms finds the watched programs matching the name (English or Korean, case-insensitive)
+func (e *Engine) findPrograms(account, program string) ([]ProgramState, error) {
+	name := strings.TrimSpace(program)
+	if name == "" {
+		return nil, errors.New(i18n.T("monitor.program_required"))
+	}
+
+	var found []ProgramState
+	for _, state := range e.ProgramStates() {
+		if account != "" && state.Account != account {
+			continue
+		}
+		if scraper.NamesProgram(name, state.Program) {
+			found = append(found, state)
+		}
+	}
+	if len(found) == 0 {
+		if account != "" {
+			return nil, fmt.Errorf(i18n.T("monitor.not_watched_account"), account, program)
+		}
+		return nil, fmt.Errorf(i18n.T("monitor.not_watched"), program)
+	}
+	return found, nil
+}
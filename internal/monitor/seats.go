@@ -0,0 +1,129 @@
+package monitor
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/models"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Seat tracking settings
+const (
+	seatRateWindow  = 30 * time.Minute // 감소 속도를 계산할 최근 구간
+	seatRateMinSpan = time.Minute      // 이보다 짧은 구간으로는 속도를 추정하지 않음
+	seatForget      = 2 * time.Hour    // 이만큼 보이지 않은 회차는 기록을 지움
+)
+
+// seatSample is a session's remaining seats at one check
+type seatSample struct {
+	time  time.Time
+	seats int
+}
+
+// trackSeats records the remaining seats of the sessions between checks and fills in
+// their SeatRate. 잔여석이 threshold 이하로 막 줄어든 회차를 함께 반환합니다 (계정과 회차마다 한 번).
+func (e *Engine) trackSeats(account string, sessions []models.Session, threshold int, now time.Time) ([]models.Session, []models.Session) {
+	if e.seatSamples == nil {
+		e.seatSamples = make(map[string][]seatSample)
+		e.seatsSeen = make(map[string]seatSample)
+		e.lastSeatsSent = make(map[string]bool)
+	}
+
+	tracked := slices.Clone(sessions)
+	var crossed []models.Session
+	for i, session := range tracked {
+		if session.Seats < 0 {
+			continue
+		}
+		key := session.Key()
+		samples := e.seatSamples[key]
+		// 같은 회차를 여러 계정이 확인해도 같은 시각의 기록은 하나만
+		if n := len(samples); n == 0 || now.After(samples[n-1].time) {
+			samples = append(samples, seatSample{time: now, seats: session.Seats})
+		}
+		for len(samples) > 1 && now.Sub(samples[0].time) > seatRateWindow {
+			samples = samples[1:]
+		}
+		e.seatSamples[key] = samples
+		tracked[i].SeatRate = seatRate(samples)
+
+		// 임계값을 넘었는지는 계정마다 직전 확인과 비교 (각 계정의 수신자에게 따로 알리도록)
+		seenKey := accountSessionKey(account, session)
+		previous, seen := e.seatsSeen[seenKey]
+		e.seatsSeen[seenKey] = seatSample{time: now, seats: session.Seats}
+		if threshold > 0 && session.Open && session.Seats > 0 && session.Seats <= threshold &&
+			seen && previous.seats > threshold && !e.lastSeatsSent[seenKey] {
+			e.lastSeatsSent[seenKey] = true
+			crossed = append(crossed, tracked[i])
+		}
+	}
+
+	for key, samples := range e.seatSamples {
+		if now.Sub(samples[len(samples)-1].time) > seatForget {
+			delete(e.seatSamples, key)
+		}
+	}
+	for key, sample := range e.seatsSeen {
+		if now.Sub(sample.time) > seatForget {
+			delete(e.seatsSeen, key)
+			delete(e.lastSeatsSent, key)
+		}
+	}
+	return tracked, crossed
+}
+
+// accountSessionKey keys the alert state of a session for one account
+func accountSessionKey(account string, session models.Session) string {
+	return account + "|" + session.Key()
+}
+
+// seatRate estimates how many seats are taken per minute from the samples (0 if not dropping)
+func seatRate(samples []seatSample) float64 {
+	if len(samples) < 2 {
+		return 0
+	}
+	first, last := samples[0], samples[len(samples)-1]
+	span := last.time.Sub(first.time)
+	if span < seatRateMinSpan || last.seats >= first.seats {
+		return 0
+	}
+	return float64(first.seats-last.seats) / span.Minutes()
+}
+
+// notifyLastSeats sends the "last seats" follow-up for sessions that crossed the threshold,
+// skipping programs that are muted, never announced, or sessions someone already claimed
+func (e *Engine) notifyLastSeats(account *Account, crossed []models.Session, now time.Time) {
+	name := account.Config.Name
+
+	var programs []models.Program
+	var sessions []models.Session
+	for _, program := range account.Config.Programs {
+		if _, announced := account.lastNotified[program.Name]; !announced || e.muted(name, program.Name) {
+			continue
+		}
+		matched := e.unclaimed(openSessionsOf(crossed, []string{program.Name}))
+		if len(matched) == 0 {
+			continue
+		}
+		programs = append(programs, program)
+		sessions = append(sessions, matched...)
+	}
+	if len(sessions) == 0 {
+		return
+	}
+
+	var lines []string
+	for _, session := range sessions {
+		lines = append(lines, fmt.Sprintf("%s %s - %s", session.Program, formatSessionTime(session), session.SeatStatus()))
+	}
+	e.emit(name, EventLastSeats, i18n.T("monitor.last_seats")+strings.Join(lines, ", "))
+
+	if !e.notify {
+		return
+	}
+	if delivery, sent := account.policy.LastSeats(programs, sessions, now); sent {
+		e.reportDelivery(name, delivery)
+	}
+}
@@ -0,0 +1,140 @@
+package monitor
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/models"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ProgramState is the last known availability of one watched program
+type ProgramState struct {
+	Account     string
+	Program     string
+	Available   bool
+	Known       bool      // 한 번 이상 확인되었는지
+	LastChange  time.Time // 예약 가능 여부가 마지막으로 바뀐 시각
+	LastChecked time.Time
+	Disabled    bool             // 실행 중 확인 대상에서 제외됨
+	Sessions    []models.Session // 마지막 확인에서 파싱한 회차
+	BookingURL  string           // 예약 페이지에서 찾은 이 프로그램의 예약 링크 (없으면 "")
+}
+
+func stateKey(account, program string) string {
+	return account + "\x00" + program
+}
+
+// syncStates rebuilds the program order from the current account configs,
+// keeping the state of programs that are still watched
+func (e *Engine) syncStates() {
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+
+	if e.states == nil {
+		e.states = make(map[string]*ProgramState)
+	}
+
+	e.stateOrder = e.stateOrder[:0]
+	for _, account := range e.accounts {
+		for _, program := range account.Config.Programs {
+			key := stateKey(account.Config.Name, program.Name)
+			if _, exists := e.states[key]; !exists {
+				e.states[key] = &ProgramState{
+					Account: account.Config.Name,
+					Program: program.Name,
+				}
+			}
+			e.stateOrder = append(e.stateOrder, key)
+		}
+	}
+}
+
+// updateStates records the availability, parsed sessions and booking links of the checked programs
+func (e *Engine) updateStates(account string, programs []string, availability map[string]bool, sessions []models.Session, links map[string]string, checkedAt time.Time) {
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+
+	for _, program := range programs {
+		state, exists := e.states[stateKey(account, program)]
+		if !exists {
+			continue
+		}
+		available := availability[program]
+		if !state.Known || state.Available != available {
+			state.LastChange = checkedAt
+		}
+		state.Available = available
+		state.Known = true
+		state.LastChecked = checkedAt
+		if link, ok := links[program]; ok {
+			state.BookingURL = link
+		}
+
+		state.Sessions = nil
+		for _, session := range sessions {
+			if session.Program == program {
+				state.Sessions = append(state.Sessions, session)
+			}
+		}
+	}
+}
+
+// OpenSessions returns the open sessions of all enabled programs, earliest first
+func (e *Engine) OpenSessions() []models.Session {
+	e.stateMu.RLock()
+	defer e.stateMu.RUnlock()
+
+	seen := make(map[string]bool)
+	var open []models.Session
+	for _, key := range e.stateOrder {
+		state := e.states[key]
+		if state.Disabled {
+			continue
+		}
+		for _, session := range state.Sessions {
+			// 같은 프로그램을 여러 계정이 확인하면 한 번만
+			if session.Open && !seen[session.Key()] {
+				seen[session.Key()] = true
+				open = append(open, session)
+			}
+		}
+	}
+	sort.SliceStable(open, func(i, j int) bool { return open[i].Start.Before(open[j].Start) })
+	return open
+}
+
+// programEnabled reports whether a program should be checked
+func (e *Engine) programEnabled(account, program string) bool {
+	e.stateMu.RLock()
+	defer e.stateMu.RUnlock()
+
+	state, exists := e.states[stateKey(account, program)]
+	return !exists || !state.Disabled
+}
+
+// ProgramStates returns the state of every watched program in config order
+func (e *Engine) ProgramStates() []ProgramState {
+	e.stateMu.RLock()
+	defer e.stateMu.RUnlock()
+
+	states := make([]ProgramState, 0, len(e.stateOrder))
+	for _, key := range e.stateOrder {
+		states = append(states, *e.states[key])
+	}
+	return states
+}
+
+// SetProgramEnabled includes or excludes a program from checks until the engine stops.
+// 설정 파일은 변경하지 않습니다.
+func (e *Engine) SetProgramEnabled(account, program string, enabled bool) error {
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+
+	state, exists := e.states[stateKey(account, program)]
+	if !exists {
+		return fmt.Errorf(i18n.T("monitor.not_watched_state"), account, program)
+	}
+	state.Disabled = !enabled
+	return nil
+}
@@ -0,0 +1,175 @@
+package monitor
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/models"
+	"bmw-driving-center-alter/internal/watch"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SetWatches sets the store of watched sessions.
+// 감시 중인 회차는 프로그램 알림과 별도로, 빈자리가 생기는 순간 바로 알립니다.
+func (e *Engine) SetWatches(store *watch.Store) {
+	e.watches = store
+}
+
+// AddWatch watches one session of a program until it has a free seat or starts.
+// account가 비어있으면 그 프로그램을 감시하는 모든 계정의 확인 결과를 사용합니다.
+func (e *Engine) AddWatch(account, program string, start time.Time, allDay bool, by string) (watch.Watch, error) {
+	if e.watches == nil {
+		return watch.Watch{}, errors.New(i18n.T("monitor.watches_not_set"))
+	}
+	targets, err := e.findPrograms(account, program)
+	if err != nil {
+		return watch.Watch{}, err
+	}
+
+	w := watch.Watch{Account: account, Program: targets[0].Program, Start: start, AllDay: allDay, By: by, Created: time.Now()}
+	if w.Expired(time.Now()) {
+		return watch.Watch{}, fmt.Errorf(i18n.T("monitor.watch_past"), w)
+	}
+	if err := e.watches.Add(w); err != nil {
+		return watch.Watch{}, err
+	}
+	e.emit(account, EventWatch, fmt.Sprintf(i18n.T("monitor.watch_added"), w, by))
+	return w, nil
+}
+
+// RemoveWatch stops watching a session by its ID and reports whether it was watched
+func (e *Engine) RemoveWatch(id string) (bool, error) {
+	if e.watches == nil {
+		return false, errors.New(i18n.T("monitor.watches_not_set"))
+	}
+	ok, err := e.watches.Remove(id)
+	if ok {
+		e.emit("", EventWatch, fmt.Sprintf(i18n.T("monitor.watch_removed"), id))
+	}
+	return ok, err
+}
+
+// UnwatchSession stops watching a session of a program, finding the program by the same
+// names as AddWatch, and reports whether it was watched
+func (e *Engine) UnwatchSession(account, program string, start time.Time, allDay bool) (bool, error) {
+	if e.watches == nil {
+		return false, errors.New(i18n.T("monitor.watches_not_set"))
+	}
+	name := strings.TrimSpace(program)
+	// 설정에서 빠진 프로그램의 감시도 지울 수 있도록 찾지 못하면 입력한 이름 그대로
+	if targets, err := e.findPrograms(account, program); err == nil {
+		name = targets[0].Program
+	}
+	return e.RemoveWatch(watch.Watch{Account: account, Program: name, Start: start, AllDay: allDay}.ID())
+}
+
+// Watches returns the watched sessions that have not started yet (nil when no store is set)
+func (e *Engine) Watches() []watch.Watch {
+	if e.watches == nil {
+		return nil
+	}
+	list, err := e.watches.List()
+	if err != nil {
+		e.emit("", EventWarning, fmt.Sprintf("⚠️ %v", err))
+	}
+	return list
+}
+
+// checkWatches alerts when a watched session goes from full to having a free seat.
+// 필터와 상관없이 페이지에서 읽은 모든 회차를 봅니다 (감시는 사용자가 직접 고른 회차이므로).
+func (e *Engine) checkWatches(account *Account, sessions []models.Session, now time.Time) {
+	if e.watches == nil {
+		return
+	}
+	watches, err := e.watches.List()
+	if err != nil {
+		e.emit(account.Config.Name, EventWarning, fmt.Sprintf("⚠️ %v", err))
+		return
+	}
+	if e.watchOpen == nil {
+		e.watchOpen = make(map[string]bool)
+	}
+
+	name := account.Config.Name
+	var freed []models.Session
+	for _, w := range watches {
+		if w.Account != "" && w.Account != name {
+			continue
+		}
+		for _, session := range sessions {
+			if !w.Matches(session) {
+				continue
+			}
+			// 계정마다 따로 기록 ("모든 계정" 감시는 각 계정의 수신자에게 한 번씩)
+			key := w.ID() + "|" + accountSessionKey(name, session)
+			available := session.Open && session.Seats != 0
+			if available && !e.watchOpen[key] && !e.muted(name, session.Program) {
+				freed = append(freed, session)
+			}
+			e.watchOpen[key] = available
+		}
+	}
+	// 삭제되거나 지난 감시의 기록은 지움
+	for key := range e.watchOpen {
+		id, _, _ := strings.Cut(key, "|")
+		if !watchListed(watches, id) {
+			delete(e.watchOpen, key)
+		}
+	}
+	if len(freed) == 0 {
+		return
+	}
+
+	var lines []string
+	for _, session := range freed {
+		line := fmt.Sprintf("%s %s", session.Program, formatSessionTime(session))
+		if status := session.SeatStatus(); status != "" {
+			line += " - " + status
+		}
+		lines = append(lines, line)
+	}
+	e.emitEvent(Event{
+		Time:    time.Now(),
+		Account: name,
+		Type:    EventWatch,
+		Message: i18n.T("monitor.watch_open") + strings.Join(lines, ", "),
+	})
+
+	if !e.notify {
+		return
+	}
+	if err := account.notifier.SendWatchAlert(freed); err != nil {
+		e.emit(name, EventError, fmt.Sprintf(i18n.T("monitor.watch_alert_failed"), err))
+		return
+	}
+	e.emit(name, EventInfo, fmt.Sprintf(i18n.T("monitor.watch_alert_sent"), len(freed)))
+}
+
+func watchListed(watches []watch.Watch, id string) bool {
+	for _, w := range watches {
+		if w.ID() == id {
+			return true
+		}
+	}
+	return false
+}
+
+// activeWatches returns the watched sessions without reporting errors (nil when none)
+func (e *Engine) activeWatches() []watch.Watch {
+	if e.watches == nil {
+		return nil
+	}
+	list, _ := e.watches.List()
+	return list
+}
+
+// watchedBy reports whether the session is on the watchlist for the account
+func watchedBy(watches []watch.Watch, account string, session models.Session) bool {
+	for _, w := range watches {
+		if (w.Account == "" || w.Account == account) && w.Matches(session) {
+			return true
+		}
+	}
+	return false
+}
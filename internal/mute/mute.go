@@ -0,0 +1,179 @@
+package mute
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mute silences the notifications of one program until it expires
+type Mute struct {
+	Account string    `json:"account"`
+	Program string    `json:"program"`
+	Until   time.Time `json:"until"`
+	By      string    `json:"by,omitempty"` // 음소거를 요청한 사람/시스템
+	Created time.Time `json:"created"`
+}
+
+// Active reports whether the mute is still in effect at t
+func (m Mute) Active(t time.Time) bool {
+	return t.Before(m.Until)
+}
+
+// Store keeps the mutes in a JSON file so they survive restarts
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// DefaultPath returns the default mutes file path
+func DefaultPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".bmw-driving-center", "mutes.json")
+}
+
+// NewStore creates a mutes store (empty path = default path)
+func NewStore(path string) *Store {
+	if path == "" {
+		path = DefaultPath()
+	}
+	return &Store{path: path}
+}
+
+// Mute adds or replaces the mute of a program
+func (s *Store) Mute(m Mute) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mutes, err := s.load()
+	if err != nil {
+		return err
+	}
+	if m.Created.IsZero() {
+		m.Created = time.Now()
+	}
+	mutes[key(m.Account, m.Program)] = m
+	return s.save(mutes)
+}
+
+// Unmute removes the mute of a program. 음소거되어 있지 않으면 false를 반환합니다.
+func (s *Store) Unmute(account, program string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mutes, err := s.load()
+	if err != nil {
+		return false, err
+	}
+	if _, ok := mutes[key(account, program)]; !ok {
+		return false, nil
+	}
+	delete(mutes, key(account, program))
+	return true, s.save(mutes)
+}
+
+// Muted returns the active mute of a program
+func (s *Store) Muted(account, program string, now time.Time) (Mute, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mutes, err := s.load()
+	if err != nil {
+		return Mute{}, false
+	}
+	m, ok := mutes[key(account, program)]
+	if !ok || !m.Active(now) {
+		return Mute{}, false
+	}
+	return m, true
+}
+
+// List returns the active mutes, soonest to expire first
+func (s *Store) List() ([]Mute, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mutes, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]Mute, 0, len(mutes))
+	for _, m := range mutes {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Until.Before(list[j].Until) })
+	return list, nil
+}
+
+// ParseDuration is time.ParseDuration with an extra "d" (days) unit, for mute periods like 3d
+func ParseDuration(text string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(text, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(text)
+}
+
+func key(account, program string) string {
+	return account + "\x00" + program
+}
+
+// load reads the mutes file, dropping expired mutes
+func (s *Store) load() (map[string]Mute, error) {
+	mutes := make(map[string]Mute)
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return mutes, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("mute.read_failed"), err)
+	}
+
+	var list []Mute
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf(i18n.T("mute.parse_failed"), err)
+	}
+	now := time.Now()
+	for _, m := range list {
+		if m.Active(now) {
+			mutes[key(m.Account, m.Program)] = m
+		}
+	}
+	return mutes, nil
+}
+
+// save writes the mutes through a temporary file so readers never see a partial file
+func (s *Store) save(mutes map[string]Mute) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf(i18n.T("mute.dir_failed"), err)
+	}
+
+	list := make([]Mute, 0, len(mutes))
+	for _, m := range mutes {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Until.Before(list[j].Until) })
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return fmt.Errorf(i18n.T("mute.encode_failed"), err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf(i18n.T("mute.save_failed"), err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf(i18n.T("mute.save_failed"), err)
+	}
+	return nil
+}
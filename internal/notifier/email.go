@@ -1,18 +1,25 @@
 package notifier
 
 import (
+	"bmw-driving-center-alter/internal/calendar"
 	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/i18n"
 	"bmw-driving-center-alter/internal/models"
+	"encoding/base64"
 	"fmt"
 	"net/smtp"
 	"strings"
 	"time"
 )
 
+// icsFileName is the name of the calendar file attached to opening alerts
+const icsFileName = "bmw-driving-center-sessions.ics"
+
 // EmailNotifier handles email notifications
 type EmailNotifier struct {
-	config config.EmailConfig
-	auth   smtp.Auth
+	config   config.EmailConfig
+	auth     smtp.Auth
+	linkBase string // 확인/예약 담당 링크의 서버 주소 (비어있으면 링크 없음)
 }
 
 // NewEmailNotifier creates a new email notifier
@@ -25,10 +32,28 @@ func NewEmailNotifier(cfg config.EmailConfig) *EmailNotifier {
 	}
 }
 
+// forward returns a notifier that sends to other recipients with another subject
+func (e *EmailNotifier) forward(to []string, subject string) *EmailNotifier {
+	cfg := e.config
+	cfg.To = to
+	cfg.Subject = subject
+	return &EmailNotifier{config: cfg, auth: e.auth, linkBase: e.linkBase}
+}
+
+// SetLinkBase sets the address of the local HTTP server used for acknowledge/claim links
+func (e *EmailNotifier) SetLinkBase(base string) {
+	e.linkBase = strings.TrimRight(base, "/")
+}
+
+// AlertURL returns the page where an alert can be acknowledged or claimed
+func AlertURL(base, alertID string) string {
+	return strings.TrimRight(base, "/") + "/alerts/" + alertID
+}
+
 // SendNotification sends an email notification about available programs
 func (e *EmailNotifier) SendNotification(status *models.ReservationStatus) error {
 	// If HasOpenings is true, use all programs (backward compatibility)
-	var openPrograms []models.Program
+	var openPrograms []models.ProgramStatus
 	if status.HasOpenings {
 		openPrograms = status.Programs
 	} else {
@@ -46,56 +71,106 @@ func (e *EmailNotifier) SendNotification(status *models.ReservationStatus) error
 
 	// Build email body
 	body := e.buildEmailBody(openPrograms, status.CheckedAt)
+	body += buildSessionList(status.Sessions)
+	if e.linkBase != "" && status.AlertID != "" {
+		body += buildAlertLinks(AlertURL(e.linkBase, status.AlertID))
+	}
 	
-	// Build the email message
+	// Build the email message (회차를 파싱했으면 달력 파일 첨부)
 	message := e.buildMessage(body)
+	if len(status.Sessions) > 0 {
+		ics := calendar.Render(i18n.T("notifier.calendar_name"), status.Sessions, status.CheckedAt)
+		message = e.buildMessageWithAttachment(e.config.Subject, body, icsFileName, calendar.ContentType, ics)
+	}
 
 	// Send to all recipients
 	addr := fmt.Sprintf("%s:%d", e.config.SMTP.Host, e.config.SMTP.Port)
 	err := smtp.SendMail(addr, e.auth, e.config.From, e.config.To, []byte(message))
 	
 	if err != nil {
-		return fmt.Errorf("이메일 전송 실패 (failed to send email): %w", err)
+		return fmt.Errorf(i18n.T("notifier.send_failed"), err)
 	}
 
 	return nil
 }
 
 // buildEmailBody creates the email body content
-func (e *EmailNotifier) buildEmailBody(programs []models.Program, checkedAt time.Time) string {
+func (e *EmailNotifier) buildEmailBody(programs []models.ProgramStatus, checkedAt time.Time) string {
 	var sb strings.Builder
 	
-	sb.WriteString("BMW 드라이빙 센터 예약이 오픈되었습니다!\n")
-	sb.WriteString("BMW Driving Center reservations are now open!\n\n")
+	sb.WriteString(i18n.T("notifier.opened.title"))
 	
 	sb.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
 	
-	sb.WriteString("🚗 예약 가능한 프로그램 (Available Programs):\n\n")
+	sb.WriteString(i18n.T("notifier.opened.programs"))
 	
 	for _, program := range programs {
 		sb.WriteString(fmt.Sprintf("  ✅ %s\n", program.Name))
+		if program.BookingURL != "" {
+			sb.WriteString(fmt.Sprintf(i18n.T("notifier.book_now"), program.BookingURL))
+		}
 	}
 	
 	sb.WriteString("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
 	
-	sb.WriteString("📅 예약 페이지 (Reservation Page):\n")
-	sb.WriteString("   https://driving-center.bmw.co.kr/orders/programs/products/view\n\n")
+	sb.WriteString(i18n.T("notifier.reservation_page"))
+	sb.WriteString(fmt.Sprintf("   %s\n\n", models.ReservationPageURL))
 	
-	sb.WriteString(fmt.Sprintf("🕐 확인 시간 (Checked at): %s\n", 
+	sb.WriteString(fmt.Sprintf(i18n.T("notifier.checked_at"), 
 		checkedAt.Format("2006-01-02 15:04:05")))
 	
 	sb.WriteString("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-	sb.WriteString("⚡ 빠른 예약을 권장합니다! (Book quickly before it fills up!)\n")
+	sb.WriteString(i18n.T("notifier.opened.hurry"))
 	
 	return sb.String()
 }
 
+// buildSessionList lists the open sessions with their booking links
+func buildSessionList(sessions []models.Session) string {
+	if len(sessions) == 0 {
+		return ""
+	}
+	
+	var sb strings.Builder
+	sb.WriteString(i18n.T("notifier.sessions"))
+	for _, session := range sessions {
+		when := i18n.FormatTime(session.Start, "2006-01-02 (Mon) 15:04")
+		if session.AllDay {
+			when = i18n.FormatTime(session.Start, "2006-01-02 (Mon)")
+		} else if !session.End.IsZero() {
+			when += "~" + session.End.Format("15:04")
+		}
+		sb.WriteString(fmt.Sprintf("  • %s  %s\n", when, session.Program))
+		if seats := session.SeatStatus(); seats != "" {
+			sb.WriteString(fmt.Sprintf("    💺 %s\n", seats))
+		}
+		if session.URL != "" {
+			sb.WriteString(fmt.Sprintf("    %s\n", session.URL))
+		}
+	}
+	return sb.String()
+}
+
+// buildAlertLinks points to the page for acknowledging the alert and claiming sessions
+func buildAlertLinks(alertURL string) string {
+	var sb strings.Builder
+	sb.WriteString("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
+	sb.WriteString(i18n.T("notifier.claim_hint"))
+	sb.WriteString(fmt.Sprintf("   %s\n", alertURL))
+	return sb.String()
+}
+
 // buildMessage creates the full email message with headers
 func (e *EmailNotifier) buildMessage(body string) string {
+	return e.buildMessageWithSubject(e.config.Subject, body)
+}
+
+// buildMessageWithSubject creates the full email message with a custom subject
+func (e *EmailNotifier) buildMessageWithSubject(subject, body string) string {
 	headers := make(map[string]string)
 	headers["From"] = e.config.From
 	headers["To"] = strings.Join(e.config.To, ", ")
-	headers["Subject"] = e.config.Subject
+	headers["Subject"] = subject
 	headers["MIME-Version"] = "1.0"
 	headers["Content-Type"] = "text/plain; charset=UTF-8"
 	
@@ -109,23 +184,55 @@ func (e *EmailNotifier) buildMessage(body string) string {
 	return message.String()
 }
 
+// buildMessageWithAttachment creates a multipart message with the body and one attached file
+func (e *EmailNotifier) buildMessageWithAttachment(subject, body, fileName, contentType string, data []byte) string {
+	boundary := fmt.Sprintf("bmw-monitor-%d", time.Now().UnixNano())
+	
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("From: %s\r\n", e.config.From))
+	message.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(e.config.To, ", ")))
+	message.WriteString(fmt.Sprintf("Subject: %s\r\n", subject))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString(fmt.Sprintf("Content-Type: multipart/mixed; boundary=\"%s\"\r\n", boundary))
+	message.WriteString("\r\n")
+	
+	message.WriteString(fmt.Sprintf("--%s\r\n", boundary))
+	message.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	message.WriteString("\r\n")
+	message.WriteString(body)
+	message.WriteString("\r\n")
+	
+	message.WriteString(fmt.Sprintf("--%s\r\n", boundary))
+	message.WriteString(fmt.Sprintf("Content-Type: %s; name=\"%s\"\r\n", contentType, fileName))
+	message.WriteString(fmt.Sprintf("Content-Disposition: attachment; filename=\"%s\"\r\n", fileName))
+	message.WriteString("Content-Transfer-Encoding: base64\r\n")
+	message.WriteString("\r\n")
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		message.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	message.WriteString(encoded + "\r\n")
+	message.WriteString(fmt.Sprintf("--%s--\r\n", boundary))
+	
+	return message.String()
+}
+
 // SendCaptchaAlert sends an email notification when CAPTCHA is detected
 func (e *EmailNotifier) SendCaptchaAlert() error {
-	body := "🚨 hCAPTCHA 감지 알림 🚨\n\n"
+	body := i18n.T("notifier.captcha.title")
 	body += "━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n"
-	body += "BMW 드라이빙 센터 예약 페이지에서 hCAPTCHA가 감지되었습니다!\n"
-	body += "hCAPTCHA has been detected on the BMW Driving Center reservation page!\n\n"
-	body += "⚠️ 브라우저에서 수동으로 CAPTCHA를 해결해주세요.\n"
-	body += "⚠️ Please solve the CAPTCHA manually in the browser.\n\n"
+	body += i18n.T("notifier.captcha.detected")
+	body += i18n.T("notifier.captcha.solve")
 	body += "━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n"
-	body += fmt.Sprintf("🕐 감지 시간 (Detected at): %s\n", time.Now().Format("2006-01-02 15:04:05"))
-	body += "\n⚡ 빠른 조치가 필요합니다! (Quick action required!)\n"
+	body += fmt.Sprintf(i18n.T("notifier.detected_at"), time.Now().Format("2006-01-02 15:04:05"))
+	body += i18n.T("notifier.captcha.hurry")
 	
 	// Build message with CAPTCHA subject
 	headers := make(map[string]string)
 	headers["From"] = e.config.From
 	headers["To"] = strings.Join(e.config.To, ", ")
-	headers["Subject"] = "🚨 [긴급] BMW 드라이빙 센터 - CAPTCHA 감지됨"
+	headers["Subject"] = i18n.T("notifier.captcha.subject")
 	headers["MIME-Version"] = "1.0"
 	headers["Content-Type"] = "text/plain; charset=UTF-8"
 	
@@ -141,7 +248,93 @@ func (e *EmailNotifier) SendCaptchaAlert() error {
 	err := smtp.SendMail(addr, e.auth, e.config.From, e.config.To, []byte(message.String()))
 	
 	if err != nil {
-		return fmt.Errorf("CAPTCHA 알림 이메일 전송 실패: %w", err)
+		return fmt.Errorf(i18n.T("notifier.captcha.send_failed"), err)
+	}
+	
+	return nil
+}
+
+// SendLastSeatsAlert sends a follow-up when few seats are left in sessions that were already announced
+func (e *EmailNotifier) SendLastSeatsAlert(sessions []models.Session) error {
+	body := i18n.T("notifier.last_seats.title")
+	body += "━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n"
+	body += i18n.T("notifier.last_seats.body")
+	body += buildSessionList(sessions)
+	body += i18n.T("notifier.reservation_page_section")
+	body += fmt.Sprintf("   %s\n\n", models.ReservationPageURL)
+	body += "━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n"
+	body += fmt.Sprintf(i18n.T("notifier.checked_at"), time.Now().Format("2006-01-02 15:04:05"))
+
+	message := e.buildMessageWithSubject(i18n.T("notifier.last_seats.subject"), body)
+
+	addr := fmt.Sprintf("%s:%d", e.config.SMTP.Host, e.config.SMTP.Port)
+	if err := smtp.SendMail(addr, e.auth, e.config.From, e.config.To, []byte(message)); err != nil {
+		return fmt.Errorf(i18n.T("notifier.last_seats.send_failed"), err)
+	}
+	return nil
+}
+
+// SendCancellationAlert sends an alert when a seat reappears in a sold-out session, usually a cancellation.
+// 새 회차 공개 알림과 달리 이미 알던 매진 회차에 한두 자리가 다시 생긴 경우입니다.
+func (e *EmailNotifier) SendCancellationAlert(sessions []models.Session) error {
+	body := i18n.T("notifier.cancellation.title")
+	body += "━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n"
+	body += i18n.T("notifier.cancellation.body")
+	body += buildSessionList(sessions)
+	body += i18n.T("notifier.reservation_page_section")
+	body += fmt.Sprintf("   %s\n\n", models.ReservationPageURL)
+	body += i18n.T("notifier.cancellation.hurry")
+	body += "━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n"
+	body += fmt.Sprintf(i18n.T("notifier.checked_at"), time.Now().Format("2006-01-02 15:04:05"))
+
+	message := e.buildMessageWithSubject(i18n.T("notifier.cancellation.subject"), body)
+
+	addr := fmt.Sprintf("%s:%d", e.config.SMTP.Host, e.config.SMTP.Port)
+	if err := smtp.SendMail(addr, e.auth, e.config.From, e.config.To, []byte(message)); err != nil {
+		return fmt.Errorf(i18n.T("notifier.cancellation.send_failed"), err)
+	}
+	return nil
+}
+
+// SendWatchAlert sends an alert when a watched session that was full has a free seat
+func (e *EmailNotifier) SendWatchAlert(sessions []models.Session) error {
+	body := i18n.T("notifier.watch.title")
+	body += "━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n"
+	body += i18n.T("notifier.watch.body")
+	body += buildSessionList(sessions)
+	body += i18n.T("notifier.reservation_page_section")
+	body += fmt.Sprintf("   %s\n\n", models.ReservationPageURL)
+	body += i18n.T("notifier.watch.hurry")
+	body += "━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n"
+	body += fmt.Sprintf(i18n.T("notifier.checked_at"), time.Now().Format("2006-01-02 15:04:05"))
+
+	message := e.buildMessageWithSubject(i18n.T("notifier.watch.subject"), body)
+
+	addr := fmt.Sprintf("%s:%d", e.config.SMTP.Host, e.config.SMTP.Port)
+	if err := smtp.SendMail(addr, e.auth, e.config.From, e.config.To, []byte(message)); err != nil {
+		return fmt.Errorf(i18n.T("notifier.watch.send_failed"), err)
+	}
+	return nil
+}
+
+// SendSessionLostAlert sends an email notification when automatic re-login has failed
+func (e *EmailNotifier) SendSessionLostAlert(account string, cause error) error {
+	body := i18n.T("notifier.session_lost.title")
+	body += "━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n"
+	body += fmt.Sprintf(i18n.T("notifier.session_lost.body"), account)
+	body += fmt.Sprintf(i18n.T("notifier.error"), cause)
+	body += i18n.T("notifier.session_lost.retrying")
+	body += i18n.T("notifier.session_lost.hint")
+	body += "━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n"
+	body += fmt.Sprintf(i18n.T("notifier.detected_at"), time.Now().Format("2006-01-02 15:04:05"))
+	
+	message := e.buildMessageWithSubject(i18n.T("notifier.session_lost.subject"), body)
+	
+	addr := fmt.Sprintf("%s:%d", e.config.SMTP.Host, e.config.SMTP.Port)
+	err := smtp.SendMail(addr, e.auth, e.config.From, e.config.To, []byte(message))
+	
+	if err != nil {
+		return fmt.Errorf(i18n.T("notifier.session_lost.send_failed"), err)
 	}
 	
 	return nil
@@ -149,9 +342,8 @@ func (e *EmailNotifier) SendCaptchaAlert() error {
 
 // TestConnection tests the email configuration
 func (e *EmailNotifier) TestConnection() error {
-	testBody := "BMW 드라이빙 센터 모니터 테스트 이메일입니다.\n"
-	testBody += "This is a test email from BMW Driving Center Monitor.\n"
-	testBody += fmt.Sprintf("Time: %s", time.Now().Format("2006-01-02 15:04:05"))
+	testBody := i18n.T("notifier.test.body")
+	testBody += fmt.Sprintf(i18n.T("notifier.test.time"), time.Now().Format("2006-01-02 15:04:05"))
 	
 	message := e.buildMessage(testBody)
 	addr := fmt.Sprintf("%s:%d", e.config.SMTP.Host, e.config.SMTP.Port)
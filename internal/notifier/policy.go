@@ -0,0 +1,450 @@
+package notifier
+
+import (
+	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/models"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+	_ "time/tzdata" // Windows 등 시간대 데이터가 없는 시스템에서도 quiet_hours.timezone 사용
+)
+
+// defaultDigestInterval is used when notify.digest_minutes is not set
+const defaultDigestInterval = 60 * time.Minute
+
+// alertRetention is how long sent alerts are kept for acknowledgement and escalation
+const alertRetention = 24 * time.Hour
+
+// Delivery kinds reported by the policy
+const (
+	DeliveryImmediate    = "immediate"    // 바로 전송
+	DeliveryDigest       = "digest"       // 모아서 요약 전송
+	DeliveryEscalation   = "escalation"   // 확인이 없어 다음 수신자에게 전송
+	DeliveryLastSeats    = "last_seats"   // 잔여석이 얼마 남지 않았다는 후속 알림
+	DeliveryCancellation = "cancellation" // 매진된 회차에 다시 생긴 자리 (취소석)
+)
+
+// Delivery is one notification the policy sent (or failed to send)
+type Delivery struct {
+	Kind       string
+	Programs   []string
+	Recipients []string
+	AlertID    string // 확인(ack)/예약 담당 링크에 사용할 알림 ID
+	Err        error
+}
+
+// Outcome is what the policy did with a status passed to Submit
+type Outcome struct {
+	Deliveries []Delivery
+	Held       []string // 조용한 시간 또는 요약 대기로 보류된 프로그램
+}
+
+// Alert is a sent opening notification that can be acknowledged
+type Alert struct {
+	ID           string
+	Programs     []string
+	Sessions     []models.Session // 알림에 포함된 회차 (예약 담당 등록 대상)
+	Severity     string
+	SentAt       time.Time
+	Escalations  int // 지금까지 전송한 에스컬레이션 단계 수
+	Acknowledged bool
+	AckedBy      string
+	AckedAt      time.Time
+	status       *models.ReservationStatus
+}
+
+type heldOpening struct {
+	program  models.ProgramStatus
+	sessions []models.Session
+	heldAt   time.Time
+}
+
+// Policy sits in front of an EmailNotifier and decides when and to whom openings are sent:
+// quiet hours, per-program severity, low-severity digests and escalation of unacknowledged alerts
+type Policy struct {
+	mu         sync.Mutex
+	cfg        config.NotifyConfig
+	notifier   *EmailNotifier
+	location   *time.Location
+	held       []heldOpening
+	nextDigest time.Time
+	alerts     []*Alert
+	open       map[string]bool
+}
+
+// NewPolicy creates a notification policy that sends through the given notifier
+func NewPolicy(cfg config.NotifyConfig, n *EmailNotifier) *Policy {
+	p := &Policy{open: make(map[string]bool)}
+	p.Update(cfg, n)
+	return p
+}
+
+// Update replaces the policy settings and notifier, keeping held openings and alerts
+func (p *Policy) Update(cfg config.NotifyConfig, n *EmailNotifier) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.cfg = cfg
+	p.notifier = n
+	p.location = time.Local
+	if cfg.QuietHours.Timezone != "" {
+		if location, err := time.LoadLocation(cfg.QuietHours.Timezone); err == nil {
+			p.location = location
+		}
+	}
+}
+
+// Observe records the latest availability, so held openings and escalations
+// of programs that closed again are dropped
+func (p *Policy) Observe(availability map[string]bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for program, available := range availability {
+		p.open[program] = available
+	}
+}
+
+// Submit applies the policy to newly opened programs: high-severity openings and
+// normal ones outside quiet hours are sent now, the rest are held for the next digest
+func (p *Policy) Submit(status *models.ReservationStatus, now time.Time) Outcome {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	quiet := p.inQuietHours(now)
+	var outcome Outcome
+	var immediate []models.ProgramStatus
+	for _, program := range status.Programs {
+		severity := program.SeverityLevel()
+		switch {
+		case severity == models.SeverityHigh, severity == models.SeverityNormal && !quiet:
+			immediate = append(immediate, program)
+		default:
+			p.hold(program, sessionsOf(status.Sessions, program.Name), severity, now)
+			outcome.Held = append(outcome.Held, program.Name)
+		}
+	}
+
+	if len(immediate) > 0 {
+		sub := &models.ReservationStatus{
+			Programs:    immediate,
+			CheckedAt:   status.CheckedAt,
+			HasOpenings: true,
+			Sessions:    sessionsOf(status.Sessions, programNames(immediate)...),
+		}
+		outcome.Deliveries = append(outcome.Deliveries, p.sendAlert(sub, now))
+	}
+	return outcome
+}
+
+// Tick sends due digests and escalations. 엔진이 주기적으로 (1분마다) 호출합니다.
+func (p *Policy) Tick(now time.Time) []Delivery {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var deliveries []Delivery
+	quiet := p.inQuietHours(now)
+	if !quiet && len(p.held) > 0 && !now.Before(p.nextDigest) {
+		if delivery, ok := p.sendDigest(now); ok {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	kept := p.alerts[:0]
+	for _, alert := range p.alerts {
+		if now.Sub(alert.SentAt) > alertRetention {
+			continue
+		}
+		kept = append(kept, alert)
+		if alert.Acknowledged || alert.Escalations >= len(p.cfg.Escalation) || !p.anyOpen(alert.Programs) {
+			continue
+		}
+		// 요약 알림은 에스컬레이션하지 않고, 조용한 시간에는 high 알림만
+		if alert.Severity == models.SeverityLow || quiet && alert.Severity != models.SeverityHigh {
+			continue
+		}
+		step := p.cfg.Escalation[alert.Escalations]
+		if now.Sub(alert.SentAt) < time.Duration(step.AfterMinutes)*time.Minute {
+			continue
+		}
+		alert.Escalations++
+		subject := fmt.Sprintf(i18n.T("notifier.escalation_subject"), step.AfterMinutes, p.notifier.config.Subject)
+		delivery := Delivery{
+			Kind:       DeliveryEscalation,
+			Programs:   alert.Programs,
+			Recipients: step.Recipients,
+			AlertID:    alert.ID,
+		}
+		delivery.Err = p.notifier.forward(step.Recipients, subject).SendNotification(alert.status)
+		deliveries = append(deliveries, delivery)
+	}
+	p.alerts = kept
+	return deliveries
+}
+
+// LastSeats sends the "last seats" follow-up for sessions of the given programs.
+// 바로 알리는 기준과 같이 high는 항상, normal은 조용한 시간이 아닐 때만 보내고 low는 보내지 않습니다.
+func (p *Policy) LastSeats(programs []models.Program, sessions []models.Session, now time.Time) (Delivery, bool) {
+	return p.sendNow(DeliveryLastSeats, programs, sessions, now, p.notifier.SendLastSeatsAlert)
+}
+
+// Cancellation sends the alert for seats that reappeared in sold-out sessions.
+// 취소석은 금방 다시 차므로 요약에 모으지 않고, LastSeats와 같은 기준으로 바로 보내거나 버립니다.
+func (p *Policy) Cancellation(programs []models.Program, sessions []models.Session, now time.Time) (Delivery, bool) {
+	return p.sendNow(DeliveryCancellation, programs, sessions, now, p.notifier.SendCancellationAlert)
+}
+
+// sendNow sends a session alert right away for the programs whose severity allows it at this time
+func (p *Policy) sendNow(kind string, programs []models.Program, sessions []models.Session, now time.Time, send func([]models.Session) error) (Delivery, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	quiet := p.inQuietHours(now)
+	var allowed []string
+	for _, program := range programs {
+		severity := program.SeverityLevel()
+		if severity == models.SeverityHigh || severity == models.SeverityNormal && !quiet {
+			allowed = append(allowed, program.Name)
+		}
+	}
+	sessions = sessionsOf(sessions, allowed...)
+	if len(sessions) == 0 {
+		return Delivery{}, false
+	}
+
+	var names []string
+	for _, session := range sessions {
+		if !slices.Contains(names, session.Program) {
+			names = append(names, session.Program)
+		}
+	}
+	delivery := Delivery{
+		Kind:       kind,
+		Programs:   names,
+		Recipients: p.notifier.config.To,
+	}
+	delivery.Err = send(sessions)
+	return delivery, true
+}
+
+// Acknowledge marks an alert as handled, stopping its escalation
+func (p *Policy) Acknowledge(id, by string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, alert := range p.alerts {
+		if alert.ID != id {
+			continue
+		}
+		if !alert.Acknowledged {
+			alert.Acknowledged = true
+			alert.AckedBy = by
+			alert.AckedAt = time.Now()
+		}
+		return nil
+	}
+	return fmt.Errorf(i18n.T("notifier.alert_not_found"), id)
+}
+
+// Alert returns a recently sent alert by ID
+func (p *Policy) Alert(id string) (Alert, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, alert := range p.alerts {
+		if alert.ID == id {
+			copied := *alert
+			copied.status = nil
+			return copied, true
+		}
+	}
+	return Alert{}, false
+}
+
+// Alerts returns the recently sent alerts, newest first
+func (p *Policy) Alerts() []Alert {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	alerts := make([]Alert, 0, len(p.alerts))
+	for i := len(p.alerts) - 1; i >= 0; i-- {
+		alert := *p.alerts[i]
+		alert.status = nil
+		alerts = append(alerts, alert)
+	}
+	return alerts
+}
+
+// Held returns the names of programs waiting for the next digest
+func (p *Policy) Held() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var names []string
+	for _, opening := range p.held {
+		names = append(names, opening.program.Name)
+	}
+	return names
+}
+
+// hold queues an opening for the digest. normal 중요도는 조용한 시간이 끝나면 바로 전송됩니다.
+func (p *Policy) hold(program models.ProgramStatus, sessions []models.Session, severity string, now time.Time) {
+	if len(p.held) == 0 {
+		interval := defaultDigestInterval
+		if p.cfg.DigestMinutes > 0 {
+			interval = time.Duration(p.cfg.DigestMinutes) * time.Minute
+		}
+		p.nextDigest = now.Add(interval)
+	}
+	if severity == models.SeverityNormal {
+		p.nextDigest = now
+	}
+
+	for i, opening := range p.held {
+		if opening.program.Name == program.Name {
+			p.held[i].sessions = sessions
+			return
+		}
+	}
+	p.held = append(p.held, heldOpening{program: program, sessions: sessions, heldAt: now})
+}
+
+// sendDigest sends the held openings that are still open in one email
+func (p *Policy) sendDigest(now time.Time) (Delivery, bool) {
+	held := p.held
+	p.held = nil
+
+	status := &models.ReservationStatus{HasOpenings: true}
+	for _, opening := range held {
+		// 그 사이 다시 마감된 프로그램은 제외
+		if !p.anyOpen([]string{opening.program.Name}) {
+			continue
+		}
+		status.Programs = append(status.Programs, opening.program)
+		status.Sessions = append(status.Sessions, opening.sessions...)
+		if opening.heldAt.After(status.CheckedAt) {
+			status.CheckedAt = opening.heldAt
+		}
+	}
+	if len(status.Programs) == 0 {
+		return Delivery{}, false
+	}
+	sort.SliceStable(status.Sessions, func(i, j int) bool { return status.Sessions[i].Start.Before(status.Sessions[j].Start) })
+
+	// 요약 알림도 확인/예약 담당 링크를 위해 기록 (에스컬레이션은 하지 않음)
+	alert := newAlert(status, models.SeverityLow, now)
+	subject := i18n.T("notifier.digest_prefix") + p.notifier.config.Subject
+	delivery := Delivery{
+		Kind:       DeliveryDigest,
+		Programs:   alert.Programs,
+		Recipients: p.notifier.config.To,
+		AlertID:    alert.ID,
+	}
+	delivery.Err = p.notifier.forward(p.notifier.config.To, subject).SendNotification(status)
+	if delivery.Err == nil {
+		p.alerts = append(p.alerts, alert)
+	}
+	return delivery, true
+}
+
+// sendAlert sends an immediate alert and keeps it for acknowledgement and escalation
+func (p *Policy) sendAlert(status *models.ReservationStatus, now time.Time) Delivery {
+	severity := models.SeverityNormal
+	for _, program := range status.Programs {
+		if program.SeverityLevel() == models.SeverityHigh {
+			severity = models.SeverityHigh
+		}
+	}
+	alert := newAlert(status, severity, now)
+
+	delivery := Delivery{
+		Kind:       DeliveryImmediate,
+		Programs:   alert.Programs,
+		Recipients: p.notifier.config.To,
+		AlertID:    alert.ID,
+	}
+	delivery.Err = p.notifier.SendNotification(status)
+	if delivery.Err == nil {
+		p.alerts = append(p.alerts, alert)
+	}
+	return delivery
+}
+
+// inQuietHours reports whether now falls in the configured quiet hours
+func (p *Policy) inQuietHours(now time.Time) bool {
+	quiet := p.cfg.QuietHours
+	if !quiet.Enabled() {
+		return false
+	}
+	start, err := time.Parse("15:04", quiet.Start)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse("15:04", quiet.End)
+	if err != nil {
+		return false
+	}
+
+	local := now.In(p.location)
+	minute := local.Hour()*60 + local.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+	if from <= to {
+		return minute >= from && minute < to
+	}
+	// 자정을 넘기는 구간 (예: 23:00 ~ 07:00)
+	return minute >= from || minute < to
+}
+
+// anyOpen reports whether any of the programs is still available (unknown counts as open)
+func (p *Policy) anyOpen(programs []string) bool {
+	for _, program := range programs {
+		if open, known := p.open[program]; !known || open {
+			return true
+		}
+	}
+	return false
+}
+
+// sessionsOf returns the sessions of the given programs
+func sessionsOf(sessions []models.Session, programs ...string) []models.Session {
+	var matched []models.Session
+	for _, session := range sessions {
+		if slices.Contains(programs, session.Program) {
+			matched = append(matched, session)
+		}
+	}
+	return matched
+}
+
+func programNames(programs []models.ProgramStatus) []string {
+	names := make([]string, 0, len(programs))
+	for _, program := range programs {
+		names = append(names, program.Name)
+	}
+	return names
+}
+
+// newAlert creates an alert for the status and stamps the status with its ID for the links
+func newAlert(status *models.ReservationStatus, severity string, sentAt time.Time) *Alert {
+	status.AlertID = newAlertID()
+	return &Alert{
+		ID:       status.AlertID,
+		Programs: programNames(status.Programs),
+		Sessions: status.Sessions,
+		Severity: severity,
+		SentAt:   sentAt,
+		status:   status,
+	}
+}
+
+func newAlertID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,10 @@
+//go:build !unix && !windows
+
+package pidfile
+
+import "os"
+
+// tryLock is a no-op on platforms without file locking
+func tryLock(file *os.File) error {
+	return nil
+}
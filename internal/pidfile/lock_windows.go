@@ -0,0 +1,24 @@
+//go:build windows
+
+package pidfile
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockOffsetHigh places the locked byte far past the PID text.
+// Windows 잠금은 강제 잠금이라 PID가 기록된 영역을 잠그면 다른 프로세스가 PID를 읽을 수 없습니다.
+const lockOffsetHigh = 1
+
+func tryLock(file *os.File) error {
+	overlapped := &windows.Overlapped{OffsetHigh: lockOffsetHigh}
+	err := windows.LockFileEx(windows.Handle(file.Fd()),
+		windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, overlapped)
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return errWouldBlock
+	}
+	return err
+}
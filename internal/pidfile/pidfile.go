@@ -0,0 +1,118 @@
+package pidfile
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// errWouldBlock is returned by tryLock when another process holds the lock
+var errWouldBlock = errors.New("lock is held by another process")
+
+// LockedError is returned when another running process holds the PID file
+type LockedError struct {
+	Path string
+	PID  int // 0이면 PID를 읽을 수 없음
+}
+
+func (e *LockedError) Error() string {
+	if e.PID > 0 {
+		return fmt.Sprintf(i18n.T("pidfile.running_pid"), e.PID, e.Path)
+	}
+	return fmt.Sprintf(i18n.T("pidfile.running"), e.Path)
+}
+
+// File is an acquired PID file. 프로세스가 종료되면 OS가 잠금을 자동으로 해제합니다.
+type File struct {
+	path string
+	file *os.File
+}
+
+// DefaultPath returns the PID file used by the monitor (CLI run, GUI, daemon)
+func DefaultPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".bmw-driving-center", "monitor.pid")
+}
+
+// Acquire locks the PID file and writes the current process ID to it.
+// 다른 프로세스가 잠금을 가지고 있으면 *LockedError를 반환합니다.
+func Acquire(path string) (*File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf(i18n.T("pidfile.dir_failed"), err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("pidfile.open_failed"), err)
+	}
+
+	if err := tryLock(file); err != nil {
+		file.Close()
+		if errors.Is(err, errWouldBlock) {
+			pid, _ := readPID(path)
+			return nil, &LockedError{Path: path, PID: pid}
+		}
+		return nil, fmt.Errorf(i18n.T("pidfile.lock_failed"), err)
+	}
+
+	// 잠금을 얻은 뒤 이전 내용을 지우고 현재 PID 기록
+	if err := file.Truncate(0); err == nil {
+		file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+		file.Sync()
+	}
+
+	return &File{path: path, file: file}, nil
+}
+
+// Path returns the PID file path
+func (f *File) Path() string {
+	return f.path
+}
+
+// Release clears the PID and releases the lock.
+// 파일은 지우지 않습니다 - 지우는 사이에 다른 프로세스가 같은 경로를 잠글 수 있기 때문입니다.
+func (f *File) Release() error {
+	if f == nil || f.file == nil {
+		return nil
+	}
+	f.file.Truncate(0)
+	err := f.file.Close() // 파일을 닫으면 잠금도 해제됨
+	f.file = nil
+	return err
+}
+
+// Status reports the PID recorded in the file and whether that process still holds the lock
+func Status(path string) (pid int, running bool, err error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0644)
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf(i18n.T("pidfile.open_failed"), err)
+	}
+	defer file.Close()
+
+	pid, _ = readPID(path)
+
+	// 잠글 수 있으면 잠금을 가진 프로세스가 없음 (비정상 종료 후 남은 파일)
+	lockErr := tryLock(file)
+	if lockErr == nil {
+		return pid, false, nil
+	}
+	if errors.Is(lockErr, errWouldBlock) {
+		return pid, true, nil
+	}
+	return pid, false, fmt.Errorf(i18n.T("pidfile.lock_check_failed"), lockErr)
+}
+
+func readPID(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
@@ -0,0 +1,253 @@
+package scraper
+
+import (
+	"bmw-driving-center-alter/internal/models"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+// Matcher finds programs in page text by their names, keywords and Korean names.
+// 대소문자, 전각/반각, 공백과 하이픈 차이를 무시하고, 단어 경계가 맞는 이름만 찾습니다.
+// 여러 이름이 겹치면 가장 긴 이름이 이깁니다 ("M Drift II" 안의 "M Drift I"은 세지 않음).
+type Matcher struct {
+	terms []matchTerm // 긴 것부터
+}
+
+// matchTerm is one name or keyword of a program
+type matchTerm struct {
+	program string // 비어있으면 감시하지 않는 알려진 프로그램 이름 (더 짧은 이름을 가리기만 함)
+	pattern *regexp.Regexp
+	first   rune // 경계 확인용 첫 글자와 마지막 글자
+	last    rune
+	length  int // 공백을 뺀 글자 수
+}
+
+// Match is one occurrence of a program, as byte offsets into NormalizeText(text)
+type Match struct {
+	Program    string
+	Start, End int
+}
+
+// romanNumerals are the Unicode Roman numeral characters sometimes used in program names (Ⅱ)
+var romanNumerals = strings.NewReplacer(
+	"Ⅰ", "I", "Ⅱ", "II", "Ⅲ", "III", "Ⅳ", "IV", "Ⅴ", "V",
+	"ⅰ", "i", "ⅱ", "ii", "ⅲ", "iii", "ⅳ", "iv", "ⅴ", "v",
+)
+
+// NormalizeText folds text for matching: full-width to half-width, lower case,
+// Roman numeral characters to letters, and hyphens and every kind of space to a single space
+func NormalizeText(text string) string {
+	text = romanNumerals.Replace(width.Fold.String(text))
+	var sb strings.Builder
+	space := true // 앞뒤 공백 제거
+	for _, r := range text {
+		if unicode.IsSpace(r) || isSeparator(r) {
+			if !space {
+				sb.WriteByte(' ')
+				space = true
+			}
+			continue
+		}
+		sb.WriteRune(unicode.ToLower(r))
+		space = false
+	}
+	return strings.TrimSuffix(sb.String(), " ")
+}
+
+// isSeparator reports whether the rune only separates words in a name (Off-Road, X-Bus)
+func isSeparator(r rune) bool {
+	switch r {
+	case '-', '_', '‐', '‑', '–', '—', '·', ' ':
+		return true
+	}
+	return false
+}
+
+// NewMatcher creates a matcher for the programs' names, keywords and known Korean names.
+// 감시하지 않는 알려진 프로그램 이름도 함께 비교해, 더 긴 다른 프로그램 이름 안에서 잘못 찾지 않도록 합니다.
+func NewMatcher(programs []models.Program) *Matcher {
+	m := &Matcher{}
+	seen := make(map[string]bool)
+	for _, program := range programs {
+		names := append([]string{program.Name}, program.Keywords...)
+		if korean, ok := models.ProgramNameMap[program.Name]; ok {
+			names = append(names, korean)
+		}
+		for _, name := range names {
+			term, ok := newMatchTerm(program.Name, name)
+			if !ok {
+				continue
+			}
+			if key := program.Name + "\x00" + term.pattern.String(); !seen[key] {
+				seen[key] = true
+				m.terms = append(m.terms, term)
+			}
+		}
+	}
+
+	for _, name := range models.GetAllProgramNames() {
+		for _, known := range []string{name, models.ProgramNameMap[name]} {
+			if term, ok := newMatchTerm("", known); ok && !m.hasPattern(term.pattern.String()) {
+				m.terms = append(m.terms, term)
+			}
+		}
+	}
+
+	sort.SliceStable(m.terms, func(i, j int) bool { return m.terms[i].length > m.terms[j].length })
+	return m
+}
+
+// NamesProgram reports whether user input (명령, API 요청) names the program by its English
+// or Korean name, ignoring case, width, spaces and hyphens. 키워드는 여러 프로그램이 함께 쓸 수 있어 비교하지 않습니다.
+func NamesProgram(input, program string) bool {
+	compact := func(text string) string { return strings.ReplaceAll(NormalizeText(text), " ", "") }
+	wanted := compact(input)
+	if wanted == "" {
+		return false
+	}
+	return compact(program) == wanted || compact(models.ProgramNameMap[program]) == wanted
+}
+
+func (m *Matcher) hasPattern(pattern string) bool {
+	for _, term := range m.terms {
+		if term.pattern.String() == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// newMatchTerm compiles a name into a pattern in which the spaces between words
+// (and between Korean and Latin letters or digits) are optional
+func newMatchTerm(program, name string) (matchTerm, bool) {
+	normalized := NormalizeText(name)
+	if normalized == "" {
+		return matchTerm{}, false
+	}
+
+	var pattern strings.Builder
+	var previous rune
+	length := 0
+	for i, r := range normalized {
+		if r == ' ' {
+			pattern.WriteString(" ?")
+			previous = 0
+			continue
+		}
+		if i > 0 && previous != 0 && runeClass(previous) != runeClass(r) {
+			pattern.WriteString(" ?")
+		}
+		pattern.WriteString(regexp.QuoteMeta(string(r)))
+		previous = r
+		length++
+	}
+
+	first, _ := utf8.DecodeRuneInString(normalized)
+	last, _ := utf8.DecodeLastRuneInString(normalized)
+	return matchTerm{
+		program: program,
+		pattern: regexp.MustCompile(pattern.String()),
+		first:   first,
+		last:    last,
+		length:  length,
+	}, true
+}
+
+// Rune classes for word boundaries
+const (
+	classOther = iota // 공백, 문장 부호 - 항상 경계
+	classLetter
+	classDigit
+	classHangul
+)
+
+func runeClass(r rune) int {
+	switch {
+	case unicode.Is(unicode.Hangul, r):
+		return classHangul
+	case unicode.IsDigit(r):
+		return classDigit
+	case unicode.IsLetter(r):
+		return classLetter
+	default:
+		return classOther
+	}
+}
+
+// bounded reports whether a name found at text[start:end] stands as its own word:
+// the letters around it must not continue the name's first or last word
+// ("M Drift I" in "M Drift II", "Taxi" in "Taxis"). 한국어 이름 뒤의 조사("드리프트는")는 허용합니다.
+func (t matchTerm) bounded(text string, start, end int) bool {
+	if start > 0 {
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		if class := runeClass(t.first); class != classOther && runeClass(before) == class {
+			return false
+		}
+	}
+	if end < len(text) {
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if class := runeClass(t.last); class != classOther && class != classHangul && runeClass(after) == class {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches returns the program names found in already normalized text, in order of appearance.
+// 겹치는 이름은 긴 것만 남기므로 한 위치는 한 프로그램에만 속합니다.
+func (m *Matcher) Matches(normalized string) []Match {
+	var matches []Match
+	for _, match := range m.allMatches(normalized) {
+		if match.Program != "" {
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+// allMatches is Matches including the known programs that are not watched (Program == ""),
+// so that callers can tell text about another program from text about none
+func (m *Matcher) allMatches(normalized string) []Match {
+	var taken []Match
+	for _, term := range m.terms {
+		for _, loc := range term.pattern.FindAllStringIndex(normalized, -1) {
+			if !term.bounded(normalized, loc[0], loc[1]) || overlaps(taken, loc[0], loc[1]) {
+				continue
+			}
+			taken = append(taken, Match{Program: term.program, Start: loc[0], End: loc[1]})
+		}
+	}
+	sort.Slice(taken, func(i, j int) bool { return taken[i].Start < taken[j].Start })
+	return taken
+}
+
+func overlaps(taken []Match, start, end int) bool {
+	for _, match := range taken {
+		if start < match.End && match.Start < end {
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns the distinct programs named in the text, in order of first appearance
+func (m *Matcher) Find(text string) []string {
+	var found []string
+	for _, match := range m.Matches(NormalizeText(text)) {
+		if !slices.Contains(found, match.Program) {
+			found = append(found, match.Program)
+		}
+	}
+	return found
+}
+
+// Contains reports whether the text names the program
+func (m *Matcher) Contains(text, program string) bool {
+	return slices.Contains(m.Find(text), program)
+}
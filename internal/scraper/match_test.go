@@ -0,0 +1,165 @@
+package scraper
+
+import (
+	"bmw-driving-center-alter/internal/models"
+	"slices"
+	"testing"
+	"time"
+)
+
+func programsNamed(names ...string) []models.Program {
+	var programs []models.Program
+	for _, name := range names {
+		programs = append(programs, models.Program{Name: name})
+	}
+	return programs
+}
+
+func TestMatcherFind(t *testing.T) {
+	drifts := []string{"M Drift I", "M Drift II", "M Drift III"}
+	tests := []struct {
+		name     string
+		programs []string
+		text     string
+		want     []string
+	}{
+		// M Drift I / II / III
+		{"drift I alone", []string{"M Drift I"}, "M Drift I 예약 가능", []string{"M Drift I"}},
+		{"drift I not in II", []string{"M Drift I"}, "M Drift II 예약 가능", nil},
+		{"drift I not in III", []string{"M Drift I"}, "M Drift III 예약 가능", nil},
+		{"drift II not in III", []string{"M Drift II"}, "M Drift III", nil},
+		{"drifts in page order", drifts, "M Drift III · M Drift I · M Drift II", []string{"M Drift III", "M Drift I", "M Drift II"}},
+		{"drift korean", []string{"M Drift II"}, "M 드리프트 II 모집", []string{"M Drift II"}},
+
+		// Starter Pack / i Starter Pack / MINI Starter Pack
+		{"starter pack alone", []string{"Starter Pack"}, "Starter Pack 예약", []string{"Starter Pack"}},
+		{"starter pack not in i", []string{"Starter Pack"}, "i Starter Pack 예약", nil},
+		{"starter pack not in MINI", []string{"Starter Pack"}, "MINI Starter Pack 예약", nil},
+		{"starter pack korean not in i", []string{"Starter Pack"}, "i 스타터 팩", nil},
+		{"starter packs side by side", []string{"Starter Pack", "i Starter Pack", "MINI Starter Pack"},
+			"MINI Starter Pack, Starter Pack, i Starter Pack", []string{"MINI Starter Pack", "Starter Pack", "i Starter Pack"}},
+
+		// Intensive / M Intensive / JCW Intensive
+		{"intensive alone", []string{"Intensive"}, "Intensive 과정", []string{"Intensive"}},
+		{"intensive not in M", []string{"Intensive"}, "M Intensive 과정", nil},
+		{"intensive not in JCW", []string{"Intensive"}, "JCW Intensive 과정", nil},
+		{"M intensive", []string{"Intensive", "M Intensive"}, "M Intensive 과정", []string{"M Intensive"}},
+		{"intensive korean", []string{"Intensive"}, "인텐시브 과정", []string{"Intensive"}},
+		{"intensive korean not in M", []string{"Intensive"}, "M 인텐시브 과정", nil},
+
+		// Taxi / Taxis
+		{"taxi", []string{"Taxi"}, "BMW M Taxi", []string{"Taxi"}},
+		{"taxi not in taxis", []string{"Taxi"}, "Taxis", nil},
+		{"taxi korean", []string{"Taxi"}, "택시 체험", []string{"Taxi"}},
+
+		// 전각, 로마 숫자 문자, 하이픈, 붙여 쓴 한국어 이름과 조사
+		{"full width", []string{"M Drift I"}, "ｍ　ｄｒｉｆｔ　ｉ", []string{"M Drift I"}},
+		{"roman numeral character", []string{"M Drift II"}, "M DRIFT Ⅱ", []string{"M Drift II"}},
+		{"roman numeral character not drift I", []string{"M Drift I"}, "M DRIFT Ⅱ", nil},
+		{"hyphen", []string{"M Drift II"}, "M-Drift II", []string{"M Drift II"}},
+		{"korean without space", []string{"M Core"}, "M코어", []string{"M Core"}},
+		{"korean with particle", []string{"M Core"}, "M 코어는 마감", []string{"M Core"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewMatcher(programsNamed(tt.programs...)).Find(tt.text)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Find(%q) with %v = %v, want %v", tt.text, tt.programs, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamesProgram(t *testing.T) {
+	tests := []struct {
+		input, program string
+		want           bool
+	}{
+		{"m drift ii", "M Drift II", true},
+		{"M-Drift II", "M Drift II", true},
+		{"ｍ ｄｒｉｆｔ Ⅱ", "M Drift II", true},
+		{"M 드리프트 II", "M Drift II", true},
+		{"M Drift I", "M Drift II", false},
+		{"택시", "Taxi", true},
+		{"Taxis", "Taxi", false},
+		{"", "Taxi", false},
+	}
+	for _, tt := range tests {
+		if got := NamesProgram(tt.input, tt.program); got != tt.want {
+			t.Errorf("NamesProgram(%q, %q) = %v, want %v", tt.input, tt.program, got, tt.want)
+		}
+	}
+}
+
+const driftPageURL = "https://driving-center.bmw.co.kr/orders/programs/products/view"
+
+// driftPage lists M Drift I and M Drift II next to each other, as cards and as session rows
+const driftPage = `<html><body>
+<div class="programs">
+  <div class="card"><h3>M Drift I</h3><a href="/orders/programs/101">신청하기</a></div>
+  <div class="card"><h3>M Drift II</h3><a href="/orders/programs/102">신청하기</a></div>
+</div>
+<ul class="schedule-list">
+  <li>M Drift I 2026.11.07 09:00 - 12:00 잔여 3석 1,200,000원</li>
+  <li>M Drift II 2026.11.07 13:00 - 17:00 매진 1,800,000원</li>
+  <li>M Drift I 2026.11.08 09:00 - 12:00 잔여 1석 <a href="/orders/sessions/5">예약</a></li>
+</ul>
+</body></html>`
+
+func TestParseProgramLinksSideBySide(t *testing.T) {
+	links := ParseProgramLinks(driftPage, programsNamed("M Drift I", "M Drift II"), driftPageURL)
+	want := map[string]string{
+		"M Drift I":  "https://driving-center.bmw.co.kr/orders/programs/101",
+		"M Drift II": "https://driving-center.bmw.co.kr/orders/programs/102",
+	}
+	if len(links) != len(want) {
+		t.Fatalf("links = %v, want %v", links, want)
+	}
+	for program, link := range want {
+		if links[program] != link {
+			t.Errorf("link of %s = %q, want %q", program, links[program], link)
+		}
+	}
+
+	// M Drift II만 감시하면 M Drift I 카드의 링크를 가져오지 않음
+	links = ParseProgramLinks(driftPage, programsNamed("M Drift II"), driftPageURL)
+	if len(links) != 1 || links["M Drift II"] != want["M Drift II"] {
+		t.Errorf("links with only M Drift II = %v", links)
+	}
+}
+
+func TestParseSessionsSideBySide(t *testing.T) {
+	sessions := ParseSessions(driftPage, programsNamed("M Drift I", "M Drift II"), driftPageURL)
+	want := []struct {
+		program string
+		start   time.Time
+		open    bool
+		seats   int
+		url     string
+	}{
+		{"M Drift I", time.Date(2026, 11, 7, 9, 0, 0, 0, KST), true, 3, "https://driving-center.bmw.co.kr/orders/programs/101"},
+		{"M Drift II", time.Date(2026, 11, 7, 13, 0, 0, 0, KST), false, -1, "https://driving-center.bmw.co.kr/orders/programs/102"},
+		{"M Drift I", time.Date(2026, 11, 8, 9, 0, 0, 0, KST), true, 1, "https://driving-center.bmw.co.kr/orders/sessions/5"},
+	}
+	if len(sessions) != len(want) {
+		t.Fatalf("got %d sessions, want %d: %+v", len(sessions), len(want), sessions)
+	}
+	for i, w := range want {
+		s := sessions[i]
+		if s.Program != w.program || !s.Start.Equal(w.start) || s.Open != w.open || s.Seats != w.seats || s.URL != w.url {
+			t.Errorf("session %d = {%s %v open=%v seats=%d %s}, want %+v", i, s.Program, s.Start, s.Open, s.Seats, s.URL, w)
+		}
+	}
+
+	// M Drift I만 감시하면 바로 옆의 M Drift II 회차를 M Drift I로 세지 않음
+	only := ParseSessions(driftPage, programsNamed("M Drift I"), driftPageURL)
+	for _, s := range only {
+		if s.Program != "M Drift I" || s.Start.Hour() == 13 {
+			t.Errorf("M Drift II session counted as %s: %v", s.Program, s.Start)
+		}
+	}
+	if len(only) != 2 {
+		t.Errorf("got %d M Drift I sessions, want 2", len(only))
+	}
+}
@@ -1,8 +1,12 @@
 package scraper
 
 import (
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/models"
 	"bytes"
 	"fmt"
+	"html"
+	"slices"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
@@ -12,7 +16,7 @@ import (
 func ParseReservationPage(html []byte) (map[string]bool, error) {
 	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(html))
 	if err != nil {
-		return nil, fmt.Errorf("HTML 파싱 실패 (failed to parse HTML): %w", err)
+		return nil, fmt.Errorf(i18n.T("scraper.html_parse_failed"), err)
 	}
 
 	availablePrograms := make(map[string]bool)
@@ -48,7 +52,7 @@ func ParseReservationPage(html []byte) (map[string]bool, error) {
 func ParseProgramListPage(html []byte) ([]string, error) {
 	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(html))
 	if err != nil {
-		return nil, fmt.Errorf("HTML 파싱 실패 (failed to parse HTML): %w", err)
+		return nil, fmt.Errorf(i18n.T("scraper.html_parse_failed"), err)
 	}
 
 	var programs []string
@@ -77,4 +81,15 @@ func ParseProgramListPage(html []byte) ([]string, error) {
 	})
 
 	return programs, nil
-}
\ No newline at end of file
+}
+
+// ParseProgramAvailability finds each program on the reservation page by its name, keywords
+// and Korean name (see Matcher). 페이지에 이름이 있으면 예약 가능으로 봅니다.
+func ParseProgramAvailability(pageSource string, programs []models.Program) map[string]bool {
+	found := NewMatcher(programs).Find(html.UnescapeString(pageSource))
+	result := make(map[string]bool)
+	for _, program := range programs {
+		result[program.Name] = slices.Contains(found, program.Name)
+	}
+	return result
+}
@@ -1,19 +1,24 @@
 package scraper
 
 import (
+	"bmw-driving-center-alter/internal/capture"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/logging"
 	"bmw-driving-center-alter/internal/models"
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
 	"time"
 )
 
+var logger = logging.Component("scraper")
+
 // Scraper handles web scraping operations
 type Scraper struct {
 	client         *http.Client
 	reservationURL string
 	programListURL string
+	recorder       *capture.Recorder
 }
 
 // New creates a new Scraper instance
@@ -27,60 +32,84 @@ func New(reservationURL, programListURL string) *Scraper {
 	}
 }
 
+// SetRecorder enables capture mode - every fetched reservation page is stored with its parse result
+func (s *Scraper) SetRecorder(recorder *capture.Recorder) {
+	s.recorder = recorder
+}
+
+// SetClient replaces the HTTP client (예: 세션 쿠키를 가진 인증 클라이언트 사용)
+func (s *Scraper) SetClient(client *http.Client) {
+	s.client = client
+}
+
 // CheckReservationStatus checks if programs are available for reservation
 func (s *Scraper) CheckReservationStatus(programs []models.Program) (*models.ReservationStatus, error) {
 	// Fetch the reservation page
 	resp, err := s.client.Get(s.reservationURL)
 	if err != nil {
-		return nil, fmt.Errorf("예약 페이지 요청 실패 (failed to fetch reservation page): %w", err)
+		return nil, fmt.Errorf(i18n.T("scraper.fetch_failed"), err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("응답 읽기 실패 (failed to read response): %w", err)
+		return nil, fmt.Errorf(i18n.T("scraper.read_failed"), err)
 	}
 
 	content := string(body)
 	status := &models.ReservationStatus{
-		Programs:  make([]models.Program, len(programs)),
+		Programs:  make([]models.ProgramStatus, len(programs)),
 		CheckedAt: time.Now(),
 	}
 
 	// Check each program
+	availability := ParseProgramAvailability(content, programs)
 	for i, program := range programs {
-		status.Programs[i] = program
-		status.Programs[i].LastChecked = time.Now()
-		
-		// Check if any keyword matches and reservation is available
-		for _, keyword := range program.Keywords {
-			if strings.Contains(content, keyword) {
-				// Check if the program is available (not sold out)
-				// This logic will need to be adjusted based on actual HTML structure
-				if !strings.Contains(content, keyword + ".*매진") && 
-				   !strings.Contains(content, keyword + ".*마감") {
-					status.Programs[i].IsOpen = true
-					status.HasOpenings = true
-					break
-				}
-			}
+		status.Programs[i] = models.ProgramStatus{Program: program, LastChecked: time.Now()}
+		if availability[program.Name] {
+			status.Programs[i].IsOpen = true
+			status.HasOpenings = true
 		}
 	}
 
+	if s.recorder != nil {
+		s.recordCapture(content, programs, availability, status.CheckedAt)
+	}
+
 	return status, nil
 }
 
+// recordCapture stores the fetched page for later replay
+func (s *Scraper) recordCapture(content string, programs []models.Program, result map[string]bool, fetchedAt time.Time) {
+	inputs := make([]capture.ProgramInput, 0, len(programs))
+	for _, program := range programs {
+		inputs = append(inputs, capture.ProgramInput{Name: program.Name, Keywords: program.Keywords})
+	}
+
+	rec := &capture.Record{
+		Source:    capture.SourceScraper,
+		URL:       s.reservationURL,
+		FetchedAt: fetchedAt,
+		Programs:  inputs,
+		Result:    result,
+		Page:      content,
+	}
+	if err := s.recorder.Save(rec); err != nil {
+		logger.Warnf(i18n.T("scraper.capture_save_failed"), err)
+	}
+}
+
 // FetchProgramList fetches available programs from the program list page
 func (s *Scraper) FetchProgramList() ([]string, error) {
 	resp, err := s.client.Get(s.programListURL)
 	if err != nil {
-		return nil, fmt.Errorf("프로그램 목록 페이지 요청 실패 (failed to fetch program list): %w", err)
+		return nil, fmt.Errorf(i18n.T("scraper.fetch_programs_failed"), err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("응답 읽기 실패 (failed to read response): %w", err)
+		return nil, fmt.Errorf(i18n.T("scraper.read_failed"), err)
 	}
 
 	// Parse the HTML to extract program names
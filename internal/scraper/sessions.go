@@ -0,0 +1,319 @@
+package scraper
+
+import (
+	"bmw-driving-center-alter/internal/models"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// KST is the time zone of the driving center schedule (한국은 서머타임 없음)
+var KST = time.FixedZone("KST", 9*60*60)
+
+var (
+	sessionDatePattern  = regexp.MustCompile(`(20\d{2})\s*[.\-/년]\s*(\d{1,2})\s*[.\-/월]\s*(\d{1,2})`)
+	sessionTimePattern  = regexp.MustCompile(`(?:^|[^\d])([01]?\d|2[0-3]):([0-5]\d)(?:[^\d]|$)`)
+	sessionPricePattern = regexp.MustCompile(`(?:₩\s*(\d[\d,]*)|(\d{1,3}(?:,\d{3})+|\d{4,})\s*원)`)
+)
+
+// sessionSeatPatterns find the number of remaining seats, most specific first
+var sessionSeatPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`잔여\s*(?:석|좌석)?\s*:?\s*(\d+)`),
+	regexp.MustCompile(`(\d+)\s*(?:석|명|자리)\s*남`),
+	regexp.MustCompile(`(?i)(\d+)\s*seats?\s*left`),
+	regexp.MustCompile(`(?i)remaining\s*:?\s*(\d+)`),
+	regexp.MustCompile(`(\d+)\s*석`),
+}
+
+// sessionBlockSelector matches elements that usually hold a single session row
+const sessionBlockSelector = "tr, li, [class*=session], [class*=schedule], [class*=time], [class*=date], [class*=item]"
+
+// maxProgramLookup is how many ancestors to search for the program a session belongs to
+const maxProgramLookup = 8
+
+// soldOutMarkers mark a session that cannot be booked
+var soldOutMarkers = []string{"매진", "마감", "예약불가", "예약 불가", "sold out", "closed"}
+
+// ParseSessions extracts dated sessions of the given programs from the reservation page.
+// pageURL is used to resolve relative booking links and as the fallback link.
+// This selector logic will need to be adjusted based on actual HTML structure
+// (replay -sessions로 저장된 캡처에 대해 확인할 수 있습니다).
+func ParseSessions(pageSource string, programs []models.Program, pageURL string) []models.Session {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(pageSource))
+	if err != nil || len(programs) == 0 {
+		return nil
+	}
+	base, _ := url.Parse(pageURL)
+	index := newProgramIndex(NewMatcher(programs))
+
+	// 날짜를 포함하는 가장 안쪽 블록만 사용
+	blocks := doc.Find(sessionBlockSelector).FilterFunction(func(_ int, s *goquery.Selection) bool {
+		if !sessionDatePattern.MatchString(s.Text()) {
+			return false
+		}
+		inner := s.Find(sessionBlockSelector).FilterFunction(func(_ int, c *goquery.Selection) bool {
+			return sessionDatePattern.MatchString(c.Text())
+		})
+		return inner.Length() == 0
+	})
+
+	links := programLinks(doc, index, base, pageURL)
+
+	seen := make(map[string]bool)
+	var sessions []models.Session
+	blocks.Each(func(_ int, block *goquery.Selection) {
+		text := normalizeSpace(block.Text())
+		program := sessionProgram(block, index)
+		if program == "" {
+			return
+		}
+
+		session, ok := parseSessionText(text)
+		if !ok {
+			return
+		}
+		session.Program = program
+		session.URL = sessionLink(block, base, pageURL)
+		if link, ok := links[program]; ok && session.URL == pageURL {
+			// 회차 행에 링크가 없으면 프로그램의 예약 링크
+			session.URL = link
+		}
+
+		if key := session.Key(); !seen[key] {
+			seen[key] = true
+			sessions = append(sessions, session)
+		}
+	})
+
+	sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].Start.Before(sessions[j].Start) })
+	return sessions
+}
+
+// parseSessionText reads the date, start/end time and sold-out state from a session row
+func parseSessionText(text string) (models.Session, bool) {
+	match := sessionDatePattern.FindStringSubmatch(text)
+	if match == nil {
+		return models.Session{}, false
+	}
+	year, _ := strconv.Atoi(match[1])
+	month, _ := strconv.Atoi(match[2])
+	day, _ := strconv.Atoi(match[3])
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		return models.Session{}, false
+	}
+
+	session := models.Session{
+		Start:   time.Date(year, time.Month(month), day, 0, 0, 0, 0, KST),
+		Open:    true,
+		Seats:   -1,
+		Details: text,
+	}
+
+	// 날짜 뒤쪽에서 시간 찾기 (시작, 종료 순)
+	rest := text[strings.Index(text, match[0])+len(match[0]):]
+	times := sessionTimePattern.FindAllStringSubmatch(rest, 2)
+	if len(times) == 0 {
+		session.AllDay = true
+	} else {
+		hour, _ := strconv.Atoi(times[0][1])
+		minute, _ := strconv.Atoi(times[0][2])
+		session.Start = session.Start.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+		if len(times) > 1 {
+			hour, _ = strconv.Atoi(times[1][1])
+			minute, _ = strconv.Atoi(times[1][2])
+			end := time.Date(year, time.Month(month), day, hour, minute, 0, 0, KST)
+			if end.After(session.Start) {
+				session.End = end
+			}
+		}
+	}
+
+	if price := sessionPricePattern.FindStringSubmatch(text); price != nil {
+		digits := strings.ReplaceAll(price[1]+price[2], ",", "")
+		session.Price, _ = strconv.Atoi(digits)
+	}
+	for _, pattern := range sessionSeatPatterns {
+		if seats := pattern.FindStringSubmatch(text); seats != nil {
+			session.Seats, _ = strconv.Atoi(seats[1])
+			break
+		}
+	}
+	if session.Seats == 0 {
+		session.Open = false
+	}
+
+	lower := strings.ToLower(text)
+	for _, marker := range soldOutMarkers {
+		if strings.Contains(lower, marker) {
+			session.Open = false
+			break
+		}
+	}
+	return session, true
+}
+
+// programIndex remembers the programs named in each element, since session rows
+// and links look through the same ancestors
+type programIndex struct {
+	matcher  *Matcher
+	elements map[*html.Node]indexedText
+}
+
+// indexedText is the normalized text of an element and the programs named in it
+type indexedText struct {
+	text    string
+	matches []Match // 감시하지 않는 알려진 프로그램 (Program == "") 포함
+}
+
+func newProgramIndex(matcher *Matcher) *programIndex {
+	return &programIndex{matcher: matcher, elements: make(map[*html.Node]indexedText)}
+}
+
+// lookup returns the normalized text of the element and the programs named in it
+func (p *programIndex) lookup(s *goquery.Selection) indexedText {
+	node := s.Get(0)
+	if indexed, ok := p.elements[node]; ok {
+		return indexed
+	}
+	text := NormalizeText(s.Text())
+	indexed := indexedText{text: text, matches: p.matcher.allMatches(text)}
+	p.elements[node] = indexed
+	return indexed
+}
+
+// programs returns the distinct programs named in the element ("" for a program that is not watched)
+func (p *programIndex) programs(s *goquery.Selection) []string {
+	var found []string
+	for _, match := range p.lookup(s).matches {
+		if !slices.Contains(found, match.Program) {
+			found = append(found, match.Program)
+		}
+	}
+	return found
+}
+
+// sessionProgram finds the program a session row belongs to: a name inside the row,
+// or else the closest name written before the row in an enclosing element.
+// 그 이름이 감시하지 않는 프로그램이면 ""을 반환합니다 (옆의 다른 프로그램으로 세지 않도록).
+func sessionProgram(block *goquery.Selection, index *programIndex) string {
+	row := index.lookup(block)
+	if len(row.matches) > 0 {
+		return row.matches[0].Program
+	}
+
+	ancestor := block.Parent()
+	for depth := 0; depth < maxProgramLookup && ancestor.Length() > 0; depth++ {
+		enclosing := index.lookup(ancestor)
+		if at := strings.Index(enclosing.text, row.text); at > 0 {
+			best, found := "", false
+			for _, match := range enclosing.matches {
+				if match.End <= at {
+					best, found = match.Program, true
+				}
+			}
+			if found {
+				return best
+			}
+		}
+		ancestor = ancestor.Parent()
+	}
+	return ""
+}
+
+// linkSelector matches elements that may lead to a booking page
+const linkSelector = "a[href], [data-href], [data-url], [onclick]"
+
+// scriptLinkPattern finds the address in onclick handlers like location.href='...' or window.open("...")
+var scriptLinkPattern = regexp.MustCompile(`(?:location(?:\.href)?\s*=|window\.open\(|location\.assign\()\s*['"]([^'"]+)['"]`)
+
+// sessionLink returns the first usable link in the row, or the reservation page
+func sessionLink(block *goquery.Selection, base *url.URL, pageURL string) string {
+	link := pageURL
+	block.Find(linkSelector).AddBackFiltered(linkSelector).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		if resolved, ok := elementLink(s, base); ok {
+			link = resolved
+			return false
+		}
+		return true
+	})
+	return link
+}
+
+// elementLink reads the address an element links to (href, data-href, data-url or onclick)
+func elementLink(s *goquery.Selection, base *url.URL) (string, bool) {
+	candidates := []string{s.AttrOr("href", ""), s.AttrOr("data-href", ""), s.AttrOr("data-url", "")}
+	if match := scriptLinkPattern.FindStringSubmatch(s.AttrOr("onclick", "")); match != nil {
+		candidates = append(candidates, match[1])
+	}
+
+	for _, href := range candidates {
+		href = strings.TrimSpace(href)
+		if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+			continue
+		}
+		ref, err := url.Parse(href)
+		if err != nil {
+			continue
+		}
+		if base != nil {
+			ref = base.ResolveReference(ref)
+		}
+		return ref.String(), true
+	}
+	return "", false
+}
+
+// ParseProgramLinks finds the booking link of each program on the reservation page.
+// 링크를 찾지 못한 프로그램은 결과에 없습니다 (예약 페이지로 대신 안내).
+func ParseProgramLinks(pageSource string, programs []models.Program, pageURL string) map[string]string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(pageSource))
+	if err != nil || len(programs) == 0 {
+		return map[string]string{}
+	}
+	base, _ := url.Parse(pageURL)
+	return programLinks(doc, newProgramIndex(NewMatcher(programs)), base, pageURL)
+}
+
+// programLinks assigns each link to the program named in the closest enclosing element
+// that names exactly one program. 예약 페이지 자신을 가리키는 링크는 제외합니다.
+func programLinks(doc *goquery.Document, index *programIndex, base *url.URL, pageURL string) map[string]string {
+	links := make(map[string]string)
+	doc.Find(linkSelector).Each(func(_ int, s *goquery.Selection) {
+		link, ok := elementLink(s, base)
+		if !ok || link == pageURL {
+			return
+		}
+
+		element := s
+		for depth := 0; depth <= maxProgramLookup && element.Length() > 0; depth++ {
+			found := index.programs(element)
+			if len(found) > 1 {
+				// 여러 프로그램이 함께 있는 목록까지 올라옴 - 어느 프로그램인지 알 수 없음
+				return
+			}
+			if len(found) == 1 {
+				if found[0] == "" {
+					return // 감시하지 않는 프로그램의 링크
+				}
+				if _, exists := links[found[0]]; !exists {
+					links[found[0]] = link
+				}
+				return
+			}
+			element = element.Parent()
+		}
+	})
+	return links
+}
+
+// normalizeSpace collapses runs of whitespace into single spaces
+func normalizeSpace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,194 @@
+package server
+
+import (
+	"bmw-driving-center-alter/internal/claims"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/models"
+	"bmw-driving-center-alter/internal/monitor"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// nameCookie remembers the name entered on the alert page
+const nameCookie = "bmw_monitor_name"
+
+// maxNameLength limits the name stored with acknowledgements and claims
+const maxNameLength = 40
+
+// alertPage is shown from the link in alert emails. 변경은 모두 POST로 - 메일 보안 검사기가
+// 링크를 미리 열어도 확인/담당 처리되지 않도록.
+var alertPage = template.Must(template.New("alert").Funcs(template.FuncMap{
+	"when": func(session models.Session) string {
+		if session.AllDay {
+			return i18n.FormatTime(session.Start, "2006-01-02 (Mon)")
+		}
+		return i18n.FormatTime(session.Start, "2006-01-02 (Mon) 15:04")
+	},
+	"time": func(t time.Time) string { return t.Format("01-02 15:04") },
+	"t":    i18n.Tf,
+	"lang": i18n.Language,
+}).Parse(`<!DOCTYPE html>
+<html lang="{{lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{t "server.alert_page.title"}}</title>
+<style>
+body { font-family: sans-serif; max-width: 640px; margin: 2em auto; padding: 0 1em; }
+table { border-collapse: collapse; width: 100%; }
+td, th { padding: .4em; border-bottom: 1px solid #ddd; text-align: left; }
+.notice { padding: .6em; background: #eef; }
+.error { padding: .6em; background: #fee; }
+.claimed { color: #555; }
+</style>
+</head>
+<body>
+<h2>🚗 {{range $i, $p := .Alert.Programs}}{{if $i}}, {{end}}{{$p}}{{end}}</h2>
+<p>{{t "server.alert_page.sent_at" (time .Alert.SentAt)}}{{if .Alert.Account}}{{t "server.alert_page.account" .Alert.Account}}{{end}}</p>
+{{if .Notice}}<p class="notice">{{.Notice}}</p>{{end}}
+{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+
+{{if .Alert.Acknowledged}}
+<p>{{t "server.alert_page.acked_by" .Alert.AckedBy (time .Alert.AckedAt)}}</p>
+{{else}}
+<form method="post" action="{{.Base}}/ack"><p>{{template "name" .}} <button type="submit">{{t "server.alert_page.ack"}}</button></p></form>
+{{end}}
+
+{{if .Alert.Sessions}}
+<table>
+<tr><th>{{t "server.alert_page.session"}}</th><th>{{t "server.alert_page.booker"}}</th><th></th></tr>
+{{range .Alert.Sessions}}
+{{$claim := index $.Alert.Claims .ID}}
+<tr>
+<td>{{.Program}}<br>{{when .}}{{if .URL}} · <a href="{{.URL}}">{{t "server.alert_page.booking_page"}}</a>{{end}}</td>
+{{if $claim.By}}
+<td class="claimed">🙋 {{$claim.By}} ({{time $claim.ClaimedAt}})</td>
+<td>{{if eq $claim.By $.Name}}
+<form method="post" action="{{$.Base}}/release"><input type="hidden" name="session" value="{{.ID}}">{{template "name" $}}<button type="submit">{{t "server.alert_page.release"}}</button></form>
+{{end}}</td>
+{{else}}
+<td>-</td>
+<td><form method="post" action="{{$.Base}}/claim"><input type="hidden" name="session" value="{{.ID}}">{{template "name" $}}<button type="submit">{{t "server.alert_page.claim"}}</button></form></td>
+{{end}}
+</tr>
+{{end}}
+</table>
+{{else}}
+<p>{{t "server.alert_page.no_sessions"}}</p>
+{{end}}
+</body>
+</html>
+{{define "name"}}{{if .Name}}<input type="hidden" name="by" value="{{.Name}}">{{else}}<input name="by" placeholder="{{t "server.alert_page.name"}}" maxlength="40" required> {{end}}{{end}}
+`))
+
+type alertPageData struct {
+	Alert  monitor.AlertStatus
+	Base   string
+	Name   string
+	Notice string
+	Error  string
+}
+
+// handleAlert shows an alert with acknowledge and claim buttons
+func (s *Server) handleAlert(w http.ResponseWriter, r *http.Request) {
+	alert, ok := s.engine.Alert(r.PathValue("id"))
+	if !ok {
+		http.Error(w, i18n.T("server.alert_not_found"), http.StatusNotFound)
+		return
+	}
+
+	data := alertPageData{
+		Alert:  alert,
+		Base:   alertPath(alert.ID),
+		Notice: r.URL.Query().Get("notice"),
+		Error:  r.URL.Query().Get("error"),
+	}
+	if cookie, err := r.Cookie(nameCookie); err == nil {
+		data.Name, _ = url.QueryUnescape(cookie.Value)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := alertPage.Execute(w, data); err != nil {
+		logger.Warnf(i18n.T("server.alert_page_failed"), err)
+	}
+}
+
+// handleAck acknowledges an alert, stopping its escalation
+func (s *Server) handleAck(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	by, ok := s.formName(w, r)
+	if !ok {
+		return
+	}
+	if err := s.engine.Acknowledge(id, by); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	redirectAlert(w, r, id, "notice", i18n.T("server.acked"))
+}
+
+// handleClaim records who is booking a session of the alert
+func (s *Server) handleClaim(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	by, ok := s.formName(w, r)
+	if !ok {
+		return
+	}
+	_, err := s.engine.Claim(id, r.PostFormValue("session"), by)
+	switch {
+	case errors.Is(err, claims.ErrClaimed):
+		redirectAlert(w, r, id, "error", err.Error())
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		redirectAlert(w, r, id, "notice", i18n.T("server.claimed"))
+	}
+}
+
+// handleRelease removes the caller's claim
+func (s *Server) handleRelease(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	by, ok := s.formName(w, r)
+	if !ok {
+		return
+	}
+	if err := s.engine.Release(id, r.PostFormValue("session"), by); err != nil {
+		redirectAlert(w, r, id, "error", err.Error())
+		return
+	}
+	redirectAlert(w, r, id, "notice", i18n.T("server.unclaimed"))
+}
+
+// formName reads the name from the form and remembers it in a cookie
+func (s *Server) formName(w http.ResponseWriter, r *http.Request) (string, bool) {
+	by := strings.TrimSpace(r.PostFormValue("by"))
+	if by == "" {
+		http.Error(w, i18n.T("server.name_required"), http.StatusBadRequest)
+		return "", false
+	}
+	if runes := []rune(by); len(runes) > maxNameLength {
+		by = string(runes[:maxNameLength])
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     nameCookie,
+		Value:    url.QueryEscape(by),
+		Path:     "/",
+		MaxAge:   365 * 24 * 60 * 60,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return by, true
+}
+
+func alertPath(id string) string {
+	return "/alerts/" + url.PathEscape(id)
+}
+
+func redirectAlert(w http.ResponseWriter, r *http.Request, id, key, message string) {
+	http.Redirect(w, r, alertPath(id)+"?"+key+"="+url.QueryEscape(message), http.StatusSeeOther)
+}
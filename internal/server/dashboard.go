@@ -0,0 +1,296 @@
+package server
+
+import (
+	"bmw-driving-center-alter/internal/history"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/monitor"
+	"crypto/subtle"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+//go:embed web
+var webFiles embed.FS
+
+const (
+	maxRecentEvents  = 200                    // 대시보드에 처음 보여줄 최근 이벤트 수
+	subscriberBuffer = 64                     // 느린 브라우저는 이 이상 밀리면 메시지를 건너뜀
+	statusDebounce   = 300 * time.Millisecond // 연달아 오는 이벤트를 모아 한 번만 상태 전송
+	statusRefresh    = 30 * time.Second       // 변화가 없어도 상태 전송 (연결 유지 겸)
+	historyGap       = time.Hour              // 이보다 오래 기록이 없으면 타임라인을 끊음
+	defaultDays      = 7
+	maxDays          = 90
+)
+
+// sseMessage is one Server-Sent Event
+type sseMessage struct {
+	event string
+	data  []byte
+}
+
+// eventJSON is an engine event shown in the dashboard's event list
+type eventJSON struct {
+	Time    time.Time `json:"time"`
+	Account string    `json:"account,omitempty"`
+	Type    string    `json:"type"`
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
+}
+
+// timelineJSON is the availability of one program over the requested period
+type timelineJSON struct {
+	Account  string        `json:"account"`
+	Program  string        `json:"program"`
+	Checks   int           `json:"checks"`
+	Segments []segmentJSON `json:"segments"`
+}
+
+type segmentJSON struct {
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	Available bool      `json:"available"`
+}
+
+// dashboard wraps a dashboard handler with HTTP basic auth when server.dashboard_password is set
+func (s *Server) dashboard(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.password != "" {
+			_, password, ok := r.BasicAuth()
+			if !ok || subtle.ConstantTimeCompare([]byte(password), []byte(s.password)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Basic realm="BMW monitor", charset="UTF-8"`)
+				http.Error(w, i18n.T("server.password_required"), http.StatusUnauthorized)
+				return
+			}
+		}
+		handler(w, r)
+	}
+}
+
+// assets serves the embedded dashboard files under /assets/
+func (s *Server) assets() http.Handler {
+	sub, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		panic(err)
+	}
+	return http.StripPrefix("/assets/", http.FileServerFS(sub))
+}
+
+// handleDashboard serves the dashboard page
+func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-cache")
+	http.ServeFileFS(w, r, webFiles, "web/index.html")
+}
+
+// handleEvents streams status snapshots ("status") and engine events ("log") as Server-Sent Events
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, i18n.T("server.no_streaming"), http.StatusInternalServerError)
+		return
+	}
+
+	messages := s.subscribe()
+	defer s.unsubscribe(messages)
+
+	w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // 프록시 버퍼링 끄기
+	if data, err := json.Marshal(s.status()); err == nil {
+		writeSSE(w, sseMessage{"status", data})
+	}
+	for _, data := range s.recentEvents() {
+		writeSSE(w, sseMessage{"log", data})
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.closing:
+			return
+		case msg := <-messages:
+			writeSSE(w, msg)
+			flusher.Flush()
+		}
+	}
+}
+
+func writeSSE(w http.ResponseWriter, msg sseMessage) {
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", msg.event, msg.data)
+}
+
+// handleEngineEvent records an engine event and forwards it to the dashboards
+func (s *Server) handleEngineEvent(event monitor.Event) {
+	message := event.Message
+	if event.Type == monitor.EventCheck && event.Result != nil {
+		available := 0
+		for _, program := range event.Result.Programs {
+			if event.Result.Availability[program] {
+				available++
+			}
+		}
+		message = fmt.Sprintf(i18n.T("server.check_done"), len(event.Result.Programs), available)
+	}
+	data, err := json.Marshal(eventJSON{
+		Time:    event.Time,
+		Account: event.Account,
+		Type:    string(event.Type),
+		Level:   event.Level().String(),
+		Message: message,
+	})
+	if err != nil {
+		return
+	}
+
+	s.eventsMu.Lock()
+	s.recent = append(s.recent, data)
+	if len(s.recent) > maxRecentEvents {
+		s.recent = s.recent[len(s.recent)-maxRecentEvents:]
+	}
+	s.eventsMu.Unlock()
+
+	s.publish(sseMessage{"log", data})
+	// 엔진 안에서 상태를 만들지 않도록 상태 전송은 statusLoop에 맡김
+	select {
+	case s.statusDirty <- struct{}{}:
+	default:
+	}
+}
+
+// statusLoop sends a status snapshot after events settle, and periodically
+func (s *Server) statusLoop() {
+	ticker := time.NewTicker(statusRefresh)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-s.statusDirty:
+			time.Sleep(statusDebounce)
+		case <-ticker.C:
+		}
+		if data, err := json.Marshal(s.status()); err == nil {
+			s.publish(sseMessage{"status", data})
+		}
+	}
+}
+
+func (s *Server) subscribe() chan sseMessage {
+	messages := make(chan sseMessage, subscriberBuffer)
+	s.eventsMu.Lock()
+	s.subscribers[messages] = struct{}{}
+	s.eventsMu.Unlock()
+	return messages
+}
+
+func (s *Server) unsubscribe(messages chan sseMessage) {
+	s.eventsMu.Lock()
+	delete(s.subscribers, messages)
+	s.eventsMu.Unlock()
+}
+
+func (s *Server) publish(msg sseMessage) {
+	s.eventsMu.Lock()
+	defer s.eventsMu.Unlock()
+	for messages := range s.subscribers {
+		select {
+		case messages <- msg:
+		default:
+		}
+	}
+}
+
+func (s *Server) recentEvents() [][]byte {
+	s.eventsMu.Lock()
+	defer s.eventsMu.Unlock()
+	return append([][]byte(nil), s.recent...)
+}
+
+// handleHistory serves the availability timelines of the last ?days=N days
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	store := s.engine.History()
+	if store == nil {
+		writeError(w, http.StatusNotFound, errors.New(i18n.T("server.history_not_set")))
+		return
+	}
+	days := defaultDays
+	if text := r.URL.Query().Get("days"); text != "" {
+		n, err := strconv.Atoi(text)
+		if err != nil || n < 1 || n > maxDays {
+			writeError(w, http.StatusBadRequest, fmt.Errorf(i18n.T("server.days_range"), maxDays))
+			return
+		}
+		days = n
+	}
+
+	now := time.Now()
+	from := now.AddDate(0, 0, -days)
+	entries, err := store.Read(history.Filter{Since: from})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"from":      from,
+		"to":        now,
+		"timelines": timelines(entries),
+	})
+}
+
+// timelines turns check results into availability segments per account and program
+func timelines(entries []history.Entry) []timelineJSON {
+	var list []*timelineJSON
+	index := make(map[string]*timelineJSON)
+	for _, entry := range entries {
+		for _, result := range entry.Programs {
+			key := entry.Account + "\x00" + result.Name
+			timeline, exists := index[key]
+			if !exists {
+				timeline = &timelineJSON{Account: entry.Account, Program: result.Name, Segments: []segmentJSON{}}
+				index[key] = timeline
+				list = append(list, timeline)
+			}
+			timeline.Checks++
+
+			segments := timeline.Segments
+			if n := len(segments); n > 0 && entry.Time.Sub(segments[n-1].To) <= historyGap {
+				last := &segments[n-1]
+				if last.Available == result.Available {
+					last.To = entry.Time
+					continue
+				}
+				// 상태가 바뀐 시점까지 이전 구간을 늘림
+				last.To = entry.Time
+			}
+			timeline.Segments = append(segments, segmentJSON{From: entry.Time, To: entry.Time, Available: result.Available})
+		}
+	}
+
+	out := make([]timelineJSON, 0, len(list))
+	for _, timeline := range list {
+		out = append(out, *timeline)
+	}
+	return out
+}
+
+// canEdit reports whether the request may change settings: 비밀번호를 설정했거나
+// 이 컴퓨터에서 접속한 경우만 (같은 네트워크의 누구나 로그인 정보를 바꾸지 못하도록)
+func (s *Server) canEdit(r *http.Request) bool {
+	if s.password != "" {
+		return true
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"bmw-driving-center-alter/internal/calendar"
+	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/logging"
+	"bmw-driving-center-alter/internal/monitor"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CalendarPath is the URL path of the ICS feed of open sessions
+const CalendarPath = "/calendar.ics"
+
+// StatusPath is the URL path of the JSON status API
+const StatusPath = "/status"
+
+// EventsPath is the URL path of the dashboard's Server-Sent Events stream
+const EventsPath = "/events"
+
+const shutdownTimeout = 5 * time.Second
+
+var logger = logging.Component("server")
+
+// Server is the monitor's local HTTP server
+type Server struct {
+	engine        *monitor.Engine
+	webhookConfig config.WebhookConfig
+	password      string // 대시보드 비밀번호
+	configPath    string // 대시보드에서 바꾼 설정을 저장할 파일
+	listener      net.Listener
+	httpServer    *http.Server
+
+	// 대시보드 실시간 전송 (SSE)
+	eventsMu    sync.Mutex
+	recent      [][]byte
+	subscribers map[chan sseMessage]struct{}
+	statusDirty chan struct{}
+	closing     chan struct{}
+}
+
+// Start opens the local HTTP server in the background.
+// server.listen이 비어있으면 서버를 열지 않고 nil을 반환합니다.
+// 대시보드에서 바꾼 설정은 configPath의 설정 파일에도 저장합니다.
+func Start(cfg config.ServerConfig, engine *monitor.Engine, configPath string) (*Server, error) {
+	if cfg.Listen == "" {
+		return nil, nil
+	}
+
+	listener, err := net.Listen("tcp", cfg.Listen)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("server.listen_failed"), cfg.Listen, err)
+	}
+
+	s := &Server{
+		engine:        engine,
+		webhookConfig: cfg.Webhook,
+		password:      cfg.DashboardPassword,
+		configPath:    configPath,
+		listener:      listener,
+		subscribers:   make(map[chan sseMessage]struct{}),
+		statusDirty:   make(chan struct{}, 1),
+		closing:       make(chan struct{}),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.dashboard(s.handleDashboard))
+	mux.Handle("GET /assets/", s.dashboard(s.assets().ServeHTTP))
+	mux.HandleFunc("GET "+EventsPath, s.dashboard(s.handleEvents))
+	mux.HandleFunc("GET /history.json", s.dashboard(s.handleHistory))
+	mux.HandleFunc("GET /settings", s.dashboard(s.handleSettings))
+	mux.HandleFunc("POST /settings", s.dashboard(s.handleSaveSettings))
+	mux.HandleFunc("GET "+CalendarPath, s.handleCalendar)
+	mux.HandleFunc("GET "+StatusPath, s.dashboard(s.handleStatus)) // 알림 ID와 예약 담당이 들어 있으므로 대시보드 비밀번호로 보호
+	mux.HandleFunc("GET /alerts/{id}", s.handleAlert)
+	mux.HandleFunc("POST /alerts/{id}/ack", s.handleAck)
+	mux.HandleFunc("POST /alerts/{id}/claim", s.handleClaim)
+	mux.HandleFunc("POST /alerts/{id}/release", s.handleRelease)
+	mux.HandleFunc("POST /api/check", s.webhook(s.handleAPICheck))
+	mux.HandleFunc("POST /api/mute", s.webhook(s.handleAPIMute))
+	mux.HandleFunc("POST /api/unmute", s.webhook(s.handleAPIUnmute))
+	mux.HandleFunc("GET /api/status", s.webhook(s.handleAPIStatus))
+	mux.HandleFunc("POST /api/watch", s.webhook(s.handleAPIWatch))
+	mux.HandleFunc("POST /api/unwatch", s.webhook(s.handleAPIUnwatch))
+	mux.HandleFunc("GET /api/watches", s.webhook(s.handleAPIWatches))
+	s.httpServer = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	engine.OnEvent(s.handleEngineEvent)
+	go s.statusLoop()
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Errorf(i18n.T("server.server_error"), err)
+		}
+	}()
+	logger.Infof(i18n.T("server.started"), s.Addr(), CalendarPath, StatusPath)
+	if cfg.Webhook.Enabled() {
+		logger.Info(i18n.T("server.webhook_enabled"))
+	}
+	return s, nil
+}
+
+// Addr returns the address the server listens on
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Close stops the server, waiting briefly for requests in progress
+func (s *Server) Close() error {
+	if s == nil {
+		return nil
+	}
+	close(s.closing) // 열려 있는 이벤트 스트림 종료
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return s.httpServer.Shutdown(ctx)
+}
+
+// handleCalendar serves the currently open sessions as an iCalendar feed
+func (s *Server) handleCalendar(w http.ResponseWriter, r *http.Request) {
+	body := calendar.Render(i18n.T("server.calendar_name"), s.engine.OpenSessions(), time.Now())
+
+	w.Header().Set("Content-Type", calendar.ContentType)
+	w.Header().Set("Content-Disposition", `inline; filename="bmw-driving-center.ics"`)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(body)
+}
@@ -0,0 +1,261 @@
+package server
+
+import (
+	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// settingsJSON mirrors the GUI's settings and programs tabs.
+// 비밀번호와 API 키는 내려보내지 않고 설정 여부만 알려주며, 비워서 저장하면 기존 값을 유지합니다.
+type settingsJSON struct {
+	Username         string   `json:"username"`
+	Password         string   `json:"password,omitempty"`
+	PasswordSet      bool     `json:"password_set"`
+	Interval         int      `json:"interval"`
+	Headless         bool     `json:"headless"`
+	CaptchaService   string   `json:"captcha_service"` // "", "solvecaptcha", "2captcha"
+	CaptchaAPIKey    string   `json:"captcha_api_key,omitempty"`
+	CaptchaAPIKeySet bool     `json:"captcha_api_key_set"`
+	EmailFrom        string   `json:"email_from"`
+	EmailTo          []string `json:"email_to"`
+	SMTPHost         string   `json:"smtp_host"`
+	SMTPPort         int      `json:"smtp_port"`
+	SMTPUsername     string   `json:"smtp_username"`
+	SMTPPassword     string   `json:"smtp_password,omitempty"`
+	SMTPPasswordSet  bool     `json:"smtp_password_set"`
+
+	// 계정별 감시 프로그램 (accounts가 없으면 "default" 하나)
+	Programs map[string][]string `json:"programs"`
+}
+
+// settingsResponse is the response of GET /settings
+type settingsResponse struct {
+	Settings settingsJSON             `json:"settings"`
+	Accounts []string                 `json:"accounts"`
+	Catalog  []models.ProgramCategory `json:"catalog"`
+	Korean   map[string]string        `json:"korean"`
+	Editable bool                     `json:"editable"`
+}
+
+// handleSettings serves the running settings
+func (s *Server) handleSettings(w http.ResponseWriter, r *http.Request) {
+	cfg, err := s.engine.Config()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := settingsResponse{
+		Settings: settingsOf(cfg),
+		Catalog:  models.AllPrograms,
+		Korean:   models.ProgramNameMap,
+		Editable: s.canEdit(r),
+	}
+	for _, account := range cfg.GetAccounts() {
+		response.Accounts = append(response.Accounts, account.Name)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// handleSaveSettings applies the settings to the running monitor and the config file
+func (s *Server) handleSaveSettings(w http.ResponseWriter, r *http.Request) {
+	if !s.canEdit(r) {
+		writeError(w, http.StatusForbidden, errors.New(i18n.T("server.remote_password")))
+		return
+	}
+	// JSON만 받아서 다른 사이트의 폼 전송(CSRF)으로는 바꿀 수 없도록
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+		writeError(w, http.StatusUnsupportedMediaType, errors.New(i18n.T("server.json_required")))
+		return
+	}
+	var req settingsJSON
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf(i18n.T("server.body_parse_failed"), err))
+		return
+	}
+	if req.Interval <= 0 {
+		writeError(w, http.StatusBadRequest, errors.New(i18n.T("server.interval_min")))
+		return
+	}
+	if req.SMTPPort < 0 || req.SMTPPort > 65535 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf(i18n.T("server.smtp_port"), req.SMTPPort))
+		return
+	}
+	switch req.CaptchaService {
+	case "", "solvecaptcha", "2captcha":
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Errorf(i18n.T("server.unknown_captcha"), req.CaptchaService))
+		return
+	}
+
+	before, err := s.engine.Config()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	apply := func(cfg *config.Config) error { return applySettings(cfg, req) }
+	if err := s.engine.UpdateConfig(apply); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	after, err := s.engine.Config()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]any{"ok": true, "message": i18n.T("server.settings_saved"), "restart": restartNeeded(before, after)}
+	if err := config.Update(s.configPath, apply); err != nil {
+		logger.Warnf(i18n.T("server.config_save_failed"), err)
+		response["message"] = fmt.Sprintf(i18n.T("server.settings_save_failed"), err)
+	}
+	logger.Infof(i18n.T("server.settings_changed"), r.RemoteAddr)
+	writeJSON(w, http.StatusOK, response)
+}
+
+// settingsOf reads the settings shown on the dashboard
+func settingsOf(cfg *config.Config) settingsJSON {
+	settings := settingsJSON{
+		Username:         cfg.Auth.Username,
+		PasswordSet:      cfg.Auth.Password != "",
+		Interval:         cfg.Monitor.Interval,
+		Headless:         cfg.Monitor.Headless,
+		CaptchaService:   cfg.CaptchaSolver.Service,
+		CaptchaAPIKeySet: cfg.CaptchaSolver.APIKey != "",
+		EmailFrom:        cfg.Email.From,
+		EmailTo:          cfg.Email.To,
+		SMTPHost:         cfg.Email.SMTP.Host,
+		SMTPPort:         cfg.Email.SMTP.Port,
+		SMTPUsername:     cfg.Email.SMTP.Username,
+		SMTPPasswordSet:  cfg.Email.SMTP.Password != "",
+		Programs:         make(map[string][]string),
+	}
+	if settings.EmailTo == nil {
+		settings.EmailTo = []string{}
+	}
+	for _, account := range cfg.GetAccounts() {
+		names := []string{}
+		for _, program := range account.Programs {
+			names = append(names, program.Name)
+		}
+		settings.Programs[account.Name] = names
+	}
+	return settings
+}
+
+// applySettings writes the dashboard settings into a config, like the GUI's save button
+func applySettings(cfg *config.Config, settings settingsJSON) error {
+	cfg.Auth.Username = strings.TrimSpace(settings.Username)
+	if settings.Password != "" {
+		cfg.Auth.Password = settings.Password
+	}
+	cfg.Monitor.Interval = settings.Interval
+	cfg.Monitor.Headless = settings.Headless
+	cfg.CaptchaSolver.Service = settings.CaptchaService
+	if settings.CaptchaAPIKey != "" {
+		cfg.CaptchaSolver.APIKey = settings.CaptchaAPIKey
+	}
+	cfg.Email.From = strings.TrimSpace(settings.EmailFrom)
+	cfg.Email.To = nil
+	for _, to := range settings.EmailTo {
+		if to = strings.TrimSpace(to); to != "" {
+			cfg.Email.To = append(cfg.Email.To, to)
+		}
+	}
+	cfg.Email.SMTP.Host = strings.TrimSpace(settings.SMTPHost)
+	cfg.Email.SMTP.Port = settings.SMTPPort
+	cfg.Email.SMTP.Username = strings.TrimSpace(settings.SMTPUsername)
+	if settings.SMTPPassword != "" {
+		cfg.Email.SMTP.Password = settings.SMTPPassword
+	}
+
+	for account, names := range settings.Programs {
+		if len(cfg.Accounts) == 0 {
+			if account != config.DefaultAccountName {
+				return fmt.Errorf(i18n.T("server.unknown_account"), account)
+			}
+			programs, err := selectPrograms(cfg.Programs, names)
+			if err != nil {
+				return err
+			}
+			cfg.Programs = programs
+			continue
+		}
+
+		found := false
+		for i := range cfg.Accounts {
+			if cfg.Accounts[i].Name == account {
+				programs, err := selectPrograms(cfg.Accounts[i].Programs, names)
+				if err != nil {
+					return err
+				}
+				cfg.Accounts[i].Programs = programs
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf(i18n.T("server.unknown_account"), account)
+		}
+	}
+	return nil
+}
+
+// selectPrograms builds the program list for the selected names,
+// keeping the keywords, filter and severity of programs that were already watched
+func selectPrograms(existing []models.Program, names []string) ([]models.Program, error) {
+	known := make(map[string]bool)
+	for _, name := range models.GetAllProgramNames() {
+		known[name] = true
+	}
+
+	var programs []models.Program
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		program := models.Program{Name: name, Keywords: []string{name}}
+		if korean, ok := models.ProgramNameMap[name]; ok {
+			program.Keywords = append(program.Keywords, korean)
+		}
+		kept := false
+		for _, current := range existing {
+			if current.Name == name {
+				program = current
+				kept = true
+				break
+			}
+		}
+		if !kept && !known[name] {
+			return nil, fmt.Errorf(i18n.T("server.unknown_program"), name)
+		}
+		programs = append(programs, program)
+	}
+	return programs, nil
+}
+
+// restartNeeded lists the changed settings that only take effect after restarting the monitor
+func restartNeeded(before, after *config.Config) []string {
+	restart := []string{}
+	if before.Auth != after.Auth {
+		restart = append(restart, i18n.T("server.restart.auth"))
+	}
+	if before.Monitor.Headless != after.Monitor.Headless {
+		restart = append(restart, i18n.T("server.restart.headless"))
+	}
+	if before.CaptchaSolver != after.CaptchaSolver {
+		restart = append(restart, i18n.T("server.restart.captcha"))
+	}
+	return restart
+}
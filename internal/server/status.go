@@ -0,0 +1,132 @@
+package server
+
+import (
+	"bmw-driving-center-alter/internal/claims"
+	"bmw-driving-center-alter/internal/models"
+	"bmw-driving-center-alter/internal/mute"
+	"bmw-driving-center-alter/internal/watch"
+	"net/http"
+	"time"
+)
+
+// statusJSON is the response of the status API
+type statusJSON struct {
+	Paused    bool           `json:"paused"`
+	NextCheck *time.Time     `json:"next_check,omitempty"`
+	Programs  []programJSON  `json:"programs"`
+	Alerts    []alertJSON    `json:"alerts"`
+	Claims    []claims.Claim `json:"claims"`
+	Mutes     []mute.Mute    `json:"mutes"`
+	Watches   []watchJSON    `json:"watches"`
+}
+
+type programJSON struct {
+	Account     string        `json:"account"`
+	Name        string        `json:"name"`
+	Available   bool          `json:"available"`
+	Known       bool          `json:"known"`
+	Disabled    bool          `json:"disabled,omitempty"`
+	LastChecked *time.Time    `json:"last_checked,omitempty"`
+	BookingURL  string        `json:"booking_url,omitempty"`
+	Sessions    []sessionJSON `json:"sessions,omitempty"`
+}
+
+type sessionJSON struct {
+	models.Session
+	ID        string `json:"id"`
+	ClaimedBy string `json:"claimed_by,omitempty"`
+}
+
+type watchJSON struct {
+	watch.Watch
+	ID string `json:"id"`
+}
+
+type alertJSON struct {
+	ID           string        `json:"id"`
+	Account      string        `json:"account"`
+	Programs     []string      `json:"programs"`
+	Severity     string        `json:"severity"`
+	SentAt       time.Time     `json:"sent_at"`
+	Escalations  int           `json:"escalations,omitempty"`
+	Acknowledged bool          `json:"acknowledged"`
+	AckedBy      string        `json:"acked_by,omitempty"`
+	Sessions     []sessionJSON `json:"sessions,omitempty"`
+	URL          string        `json:"url"`
+}
+
+// handleStatus serves the program states, recent alerts, claims, mutes and watches as JSON
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.status())
+}
+
+// status collects the current monitoring status
+func (s *Server) status() statusJSON {
+	claimList := s.engine.Claims()
+	claimedBy := make(map[string]string)
+	for _, claim := range claimList {
+		claimedBy[claim.Session] = claim.By
+	}
+	sessionsOf := func(sessions []models.Session) []sessionJSON {
+		var list []sessionJSON
+		for _, session := range sessions {
+			list = append(list, sessionJSON{Session: session, ID: session.ID(), ClaimedBy: claimedBy[session.ID()]})
+		}
+		return list
+	}
+
+	status := statusJSON{
+		Paused:   s.engine.Paused(),
+		Programs: []programJSON{},
+		Alerts:   []alertJSON{},
+		Claims:   claimList,
+	}
+	if status.Claims == nil {
+		status.Claims = []claims.Claim{}
+	}
+	status.Mutes = s.engine.Mutes()
+	if status.Mutes == nil {
+		status.Mutes = []mute.Mute{}
+	}
+	status.Watches = []watchJSON{}
+	for _, w := range s.engine.Watches() {
+		status.Watches = append(status.Watches, watchJSON{Watch: w, ID: w.ID()})
+	}
+	if next := s.engine.NextCheck(); !next.IsZero() {
+		status.NextCheck = &next
+	}
+
+	for _, state := range s.engine.ProgramStates() {
+		program := programJSON{
+			Account:    state.Account,
+			Name:       state.Program,
+			Available:  state.Available,
+			Known:      state.Known,
+			Disabled:   state.Disabled,
+			BookingURL: state.BookingURL,
+			Sessions:   sessionsOf(state.Sessions),
+		}
+		if !state.LastChecked.IsZero() {
+			lastChecked := state.LastChecked
+			program.LastChecked = &lastChecked
+		}
+		status.Programs = append(status.Programs, program)
+	}
+
+	for _, alert := range s.engine.Alerts() {
+		status.Alerts = append(status.Alerts, alertJSON{
+			ID:           alert.ID,
+			Account:      alert.Account,
+			Programs:     alert.Programs,
+			Severity:     alert.Severity,
+			SentAt:       alert.SentAt,
+			Escalations:  alert.Escalations,
+			Acknowledged: alert.Acknowledged,
+			AckedBy:      alert.AckedBy,
+			Sessions:     sessionsOf(alert.Sessions),
+			URL:          alertPath(alert.ID),
+		})
+	}
+
+	return status
+}
@@ -0,0 +1,291 @@
+// BMW 드라이빙 센터 모니터 대시보드
+"use strict";
+
+const maxEvents = 500;
+let korean = {};
+let settingsData = null;
+let events = [];
+
+const $ = (selector) => document.querySelector(selector);
+
+function el(tag, attrs, ...children) {
+  const node = document.createElement(tag);
+  for (const [key, value] of Object.entries(attrs || {})) {
+    if (key === "class") node.className = value;
+    else node.setAttribute(key, value);
+  }
+  for (const child of children) {
+    if (child === null || child === undefined) continue;
+    node.append(child instanceof Node ? child : String(child));
+  }
+  return node;
+}
+
+function pad(n) { return String(n).padStart(2, "0"); }
+
+function formatTime(value, withDate) {
+  const d = new Date(value);
+  const time = `${pad(d.getHours())}:${pad(d.getMinutes())}`;
+  return withDate === false ? time : `${pad(d.getMonth() + 1)}-${pad(d.getDate())} ${time}`;
+}
+
+function programName(name) {
+  return korean[name] ? `${korean[name]} (${name})` : name;
+}
+
+function showMessage(text, isError) {
+  const message = $("#message");
+  message.textContent = text;
+  message.className = isError ? "error" : "";
+  message.hidden = false;
+  clearTimeout(showMessage.timer);
+  showMessage.timer = setTimeout(() => { message.hidden = true; }, 8000);
+}
+
+// ----- 탭 -----
+
+function selectTab(name) {
+  document.querySelectorAll("nav button").forEach((b) => b.classList.toggle("active", b.dataset.tab === name));
+  document.querySelectorAll(".tab").forEach((t) => t.classList.toggle("active", t.id === `tab-${name}`));
+  if (name === "history") loadHistory();
+  location.hash = name;
+}
+
+document.querySelectorAll("nav button").forEach((b) => b.addEventListener("click", () => selectTab(b.dataset.tab)));
+
+// ----- 실시간 상태 (SSE) -----
+
+function renderStatus(status) {
+  const next = $("#next-check");
+  if (status.paused) next.textContent = "⏸️ 일시정지됨";
+  else if (status.next_check) next.textContent = `다음 확인 ${formatTime(status.next_check, false)}`;
+  else next.textContent = "";
+
+  const mutes = {};
+  for (const m of status.mutes || []) mutes[`${m.account}\u0000${m.program}`] = m;
+  const multiAccount = new Set((status.programs || []).map((p) => p.account)).size > 1;
+
+  const body = $("#programs-body");
+  body.replaceChildren();
+  for (const program of status.programs || []) {
+    let badge = el("span", { class: "badge closed" }, "마감");
+    if (program.disabled) badge = el("span", { class: "badge" }, "일시 제외");
+    else if (!program.known) badge = el("span", { class: "badge" }, "확인 전");
+    else if (program.available) badge = el("span", { class: "badge open" }, "예약 가능");
+
+    const state = el("td", {}, badge);
+    const mute = mutes[`${program.account}\u0000${program.name}`];
+    if (mute) state.append(" ", el("span", { class: "badge muted" }, `🔇 ${formatTime(mute.until)}까지`));
+
+    const sessions = el("td", {});
+    for (const session of (program.sessions || []).filter((s) => s.open)) {
+      // 날짜만 있는 회차는 MM-DD
+      let text = session.all_day ? formatTime(session.start).slice(0, 5) : formatTime(session.start);
+      if (session.seats >= 0) text += ` · ${session.seats}석`;
+      if (session.seat_rate > 0) text += ` (분당 ${session.seat_rate.toFixed(1)}석 감소)`;
+      if (session.claimed_by) text += ` · 🙋 ${session.claimed_by}`;
+      sessions.append(el("div", {}, session.url ? el("a", { href: session.url, target: "_blank", rel: "noopener" }, text) : text));
+    }
+    if (!sessions.childNodes.length) sessions.append(el("span", { class: "muted-text" }, "-"));
+
+    const name = el("td", {}, program.booking_url
+      ? el("a", { href: program.booking_url, target: "_blank", rel: "noopener" }, programName(program.name))
+      : programName(program.name));
+    if (multiAccount) name.append(el("div", { class: "muted-text" }, program.account));
+    body.append(el("tr", {}, name, state, sessions,
+      el("td", {}, program.last_checked ? formatTime(program.last_checked) : "-")));
+  }
+  if (!body.childNodes.length) body.append(el("tr", {}, el("td", { colspan: 4 }, "감시 중인 프로그램이 없습니다")));
+
+  const watches = $("#watches");
+  watches.replaceChildren();
+  for (const watch of status.watches || []) {
+    const when = watch.all_day ? formatTime(watch.start).slice(0, 5) : formatTime(watch.start);
+    const account = watch.account ? ` · ${watch.account}` : "";
+    watches.append(el("li", {}, `👀 ${programName(watch.program)} ${when}`,
+      el("span", { class: "muted-text" }, ` ${watch.id}${account}`)));
+  }
+  if (!watches.childNodes.length) watches.append(el("li", { class: "muted-text" }, "감시 중인 회차가 없습니다"));
+
+  const alerts = $("#alerts");
+  alerts.replaceChildren();
+  for (const alert of (status.alerts || []).slice(0, 20)) {
+    const acked = alert.acknowledged ? ` · ✅ ${alert.acked_by}` : "";
+    alerts.append(el("li", {},
+      el("a", { href: alert.url }, alert.programs.map(programName).join(", ")),
+      el("span", { class: "muted-text" }, ` ${formatTime(alert.sent_at)} · ${alert.severity}${acked}`)));
+  }
+  if (!alerts.childNodes.length) alerts.append(el("li", { class: "muted-text" }, "아직 보낸 알림이 없습니다"));
+}
+
+function renderEvents() {
+  const problemsOnly = $("#problems-only").checked;
+  const list = $("#events");
+  list.replaceChildren();
+  for (const event of events.slice().reverse()) {
+    const level = event.level === "ERROR" ? "error" : event.level === "WARN" ? "warn" : "";
+    if (problemsOnly && !level) continue;
+    const account = event.account ? `[${event.account}] ` : "";
+    list.append(el("li", { class: level }, `${formatTime(event.time)}  ${account}${event.message}`));
+  }
+}
+
+$("#problems-only").addEventListener("change", renderEvents);
+
+function connect() {
+  const source = new EventSource("/events");
+  const connection = $("#connection");
+  source.onopen = () => {
+    connection.textContent = "실시간 연결됨";
+    connection.className = "badge on";
+    events = [];
+  };
+  source.onerror = () => {
+    connection.textContent = "연결 끊김 - 다시 연결 중...";
+    connection.className = "badge off";
+  };
+  source.addEventListener("status", (e) => renderStatus(JSON.parse(e.data)));
+  source.addEventListener("log", (e) => {
+    events.push(JSON.parse(e.data));
+    if (events.length > maxEvents) events.shift();
+    renderEvents();
+  });
+}
+
+// ----- 기록 타임라인 -----
+
+async function loadHistory() {
+  const days = $("#history-days").value;
+  const container = $("#timelines");
+  try {
+    const response = await fetch(`/history.json?days=${days}`);
+    const data = await response.json();
+    if (!response.ok) throw new Error(data.error);
+
+    const from = new Date(data.from).getTime();
+    const span = new Date(data.to).getTime() - from;
+    container.replaceChildren();
+    const multiAccount = new Set(data.timelines.map((t) => t.account)).size > 1;
+    for (const timeline of data.timelines) {
+      const bar = el("div", { class: "bar" });
+      for (const segment of timeline.segments) {
+        const left = Math.max(0, (new Date(segment.from).getTime() - from) / span * 100);
+        const width = Math.max(0.2, (new Date(segment.to).getTime() - new Date(segment.from).getTime()) / span * 100);
+        bar.append(el("span", {
+          class: segment.available ? "open" : "closed",
+          style: `left:${left}%;width:${width}%`,
+          title: `${formatTime(segment.from)} ~ ${formatTime(segment.to)} ${segment.available ? "예약 가능" : "마감"}`,
+        }));
+      }
+      const label = `${programName(timeline.program)}${multiAccount ? ` [${timeline.account}]` : ""}`;
+      container.append(el("div", { class: "timeline" },
+        el("div", { class: "label" }, label, el("span", { class: "muted-text" }, ` · 확인 ${timeline.checks}회`)),
+        bar,
+        el("div", { class: "axis" }, el("span", {}, formatTime(data.from)), el("span", {}, formatTime(data.to)))));
+    }
+    if (!data.timelines.length) container.append(el("p", { class: "muted-text" }, "이 기간의 확인 기록이 없습니다"));
+  } catch (err) {
+    container.replaceChildren(el("p", { class: "muted-text" }, `기록을 불러오지 못했습니다: ${err.message}`));
+  }
+}
+
+$("#history-days").addEventListener("change", loadHistory);
+
+// ----- 설정 / 프로그램 -----
+
+async function loadSettings() {
+  const response = await fetch("/settings");
+  settingsData = await response.json();
+  if (!response.ok) throw new Error(settingsData.error);
+  korean = settingsData.korean || {};
+  const settings = settingsData.settings;
+
+  const form = $("#settings-form");
+  for (const input of form.elements) {
+    if (!input.name) continue;
+    if (input.dataset.secret) {
+      input.value = "";
+      input.placeholder = settings[input.dataset.secret] ? "설정됨 (바꿀 때만 입력)" : "";
+    } else if (input.type === "checkbox") {
+      input.checked = !!settings[input.name];
+    } else if (input.name === "email_to") {
+      input.value = (settings.email_to || []).join(", ");
+    } else {
+      input.value = settings[input.name] ?? "";
+    }
+  }
+
+  const accounts = $("#program-accounts");
+  accounts.replaceChildren();
+  for (const account of settingsData.accounts) {
+    const selected = new Set(settings.programs[account] || []);
+    const box = el("div", { class: "account", "data-account": account });
+    if (settingsData.accounts.length > 1) box.append(el("h3", {}, `계정: ${account}`));
+    for (const category of settingsData.catalog) {
+      const group = el("div", { class: "category" }, el("h4", {}, category.name));
+      for (const name of category.programs) {
+        const checkbox = el("input", { type: "checkbox", value: name });
+        checkbox.checked = selected.has(name);
+        group.append(el("label", {}, checkbox, " ", programName(name)));
+      }
+      box.append(group);
+    }
+    accounts.append(box);
+  }
+
+  if (!settingsData.editable) {
+    document.querySelectorAll("#settings-form input, #settings-form select, #settings-form button, #program-accounts input, #save-programs")
+      .forEach((input) => { input.disabled = true; });
+    showMessage("다른 컴퓨터에서는 설정을 볼 수만 있습니다 (server.dashboard_password를 설정하면 변경 가능)");
+  }
+}
+
+function collectSettings() {
+  const form = $("#settings-form");
+  const settings = {
+    username: form.username.value,
+    interval: Number(form.interval.value),
+    headless: form.headless.checked,
+    captcha_service: form.captcha_service.value,
+    email_from: form.email_from.value,
+    email_to: form.email_to.value.split(",").map((s) => s.trim()).filter(Boolean),
+    smtp_host: form.smtp_host.value,
+    smtp_port: Number(form.smtp_port.value || 0),
+    smtp_username: form.smtp_username.value,
+    programs: {},
+  };
+  for (const name of ["password", "captcha_api_key", "smtp_password"]) {
+    if (form[name].value) settings[name] = form[name].value;
+  }
+  document.querySelectorAll("#program-accounts .account").forEach((box) => {
+    settings.programs[box.dataset.account] = Array.from(box.querySelectorAll("input:checked")).map((c) => c.value);
+  });
+  return settings;
+}
+
+async function saveSettings() {
+  try {
+    const response = await fetch("/settings", {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify(collectSettings()),
+    });
+    const result = await response.json();
+    if (!response.ok) throw new Error(result.error);
+    let text = `✅ ${result.message}`;
+    if (result.restart && result.restart.length) text += ` (모니터를 다시 시작해야 적용: ${result.restart.join(", ")})`;
+    showMessage(text);
+    await loadSettings();
+  } catch (err) {
+    showMessage(`❌ ${err.message}`, true);
+  }
+}
+
+$("#settings-form").addEventListener("submit", (e) => { e.preventDefault(); saveSettings(); });
+$("#save-programs").addEventListener("click", saveSettings);
+
+// ----- 시작 -----
+
+loadSettings().catch((err) => showMessage(`❌ 설정을 불러오지 못했습니다: ${err.message}`, true)).finally(connect);
+const initialTab = location.hash.slice(1);
+if (document.getElementById(`tab-${initialTab}`)) selectTab(initialTab);
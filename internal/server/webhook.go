@@ -0,0 +1,259 @@
+package server
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/mute"
+	"bmw-driving-center-alter/internal/watch"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Webhook request headers
+const (
+	TimestampHeader = "X-Timestamp" // 요청 시각 (유닉스 초)
+	SignatureHeader = "X-Signature" // sha256=<hex(HMAC-SHA256(secret, timestamp + "." + body))>
+)
+
+const (
+	maxWebhookBody  = 64 * 1024
+	signatureMaxAge = 5 * time.Minute // 서명 재사용(replay) 방지
+	signaturePrefix = "sha256="
+)
+
+// muteRequest is the body of POST /api/mute and /api/unmute
+type muteRequest struct {
+	Account string `json:"account,omitempty"` // 비어있으면 그 프로그램을 감시하는 모든 계정
+	Program string `json:"program"`           // 영문 또는 한국어 이름
+	Until   string `json:"until,omitempty"`   // 2006-01-02T15:04:05+09:00, 2006-01-02T15:04 또는 2006-01-02 (그날 0시)
+	For     string `json:"for,omitempty"`     // 기간 (예: 90m, 12h, 3d)
+	By      string `json:"by,omitempty"`      // 요청한 사람/시스템
+}
+
+// watchRequest is the body of POST /api/watch and /api/unwatch
+type watchRequest struct {
+	Account string `json:"account,omitempty"` // 비어있으면 그 프로그램을 감시하는 모든 계정
+	Program string `json:"program"`           // 영문 또는 한국어 이름
+	Date    string `json:"date"`              // 2006-01-02
+	Time    string `json:"time,omitempty"`    // 15:04 (비어있으면 그날의 모든 회차)
+	ID      string `json:"id,omitempty"`      // unwatch: 감시 ID (program/date/time 대신)
+	By      string `json:"by,omitempty"`      // 요청한 사람/시스템
+}
+
+// webhook wraps an /api handler with authentication and reads the request body
+func (s *Server) webhook(handler func(w http.ResponseWriter, r *http.Request, body []byte)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.webhookConfig.Enabled() {
+			writeError(w, http.StatusNotFound, errors.New(i18n.T("server.webhook_not_set")))
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+		if err != nil {
+			writeError(w, http.StatusRequestEntityTooLarge, err)
+			return
+		}
+		if err := s.authorize(r, body, time.Now()); err != nil {
+			logger.Warnf(i18n.T("server.webhook_auth_failed"), r.Method, r.URL.Path, r.RemoteAddr, err)
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+		handler(w, r, body)
+	}
+}
+
+// authorize accepts a matching bearer token or a fresh HMAC signature of the body
+func (s *Server) authorize(r *http.Request, body []byte, now time.Time) error {
+	cfg := s.webhookConfig
+	if cfg.Token != "" {
+		if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(cfg.Token)) == 1 {
+				return nil
+			}
+			return errors.New(i18n.T("server.bad_token"))
+		}
+	}
+
+	if cfg.Secret != "" && r.Header.Get(SignatureHeader) != "" {
+		timestamp := r.Header.Get(TimestampHeader)
+		seconds, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return fmt.Errorf(i18n.T("server.bad_header"), TimestampHeader)
+		}
+		if age := now.Sub(time.Unix(seconds, 0)); age > signatureMaxAge || age < -signatureMaxAge {
+			return errors.New(i18n.T("server.stale_signature"))
+		}
+		signature, ok := strings.CutPrefix(r.Header.Get(SignatureHeader), signaturePrefix)
+		if !ok {
+			return fmt.Errorf(i18n.T("server.header_prefix"), SignatureHeader, signaturePrefix)
+		}
+		if !hmac.Equal([]byte(signature), []byte(Sign(cfg.Secret, timestamp, body))) {
+			return errors.New(i18n.T("server.bad_signature"))
+		}
+		return nil
+	}
+
+	return errors.New(i18n.T("server.no_credentials"))
+}
+
+// Sign returns the hex HMAC-SHA256 signature of a webhook request
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// handleAPICheck asks the engine to check all accounts right away
+func (s *Server) handleAPICheck(w http.ResponseWriter, r *http.Request, body []byte) {
+	s.engine.TriggerCheck()
+	logger.Infof(i18n.T("server.check_requested"), r.RemoteAddr)
+	writeJSON(w, http.StatusAccepted, map[string]any{"ok": true, "message": i18n.T("server.check_requested_reply")})
+}
+
+// handleAPIMute mutes a program until the requested time
+func (s *Server) handleAPIMute(w http.ResponseWriter, r *http.Request, body []byte) {
+	var req muteRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf(i18n.T("server.body_parse_failed"), err))
+		return
+	}
+	until, err := muteUntil(req, time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.By == "" {
+		req.By = "webhook"
+	}
+
+	muted, err := s.engine.Mute(req.Account, req.Program, until, req.By)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"ok": true, "mutes": muted})
+}
+
+// handleAPIUnmute removes the mute of a program
+func (s *Server) handleAPIUnmute(w http.ResponseWriter, r *http.Request, body []byte) {
+	var req muteRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf(i18n.T("server.body_parse_failed"), err))
+		return
+	}
+	removed, err := s.engine.Unmute(req.Account, req.Program)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"ok": true, "removed": removed})
+}
+
+// handleAPIWatch watches one session until it has a free seat
+func (s *Server) handleAPIWatch(w http.ResponseWriter, r *http.Request, body []byte) {
+	var req watchRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf(i18n.T("server.body_parse_failed"), err))
+		return
+	}
+	start, allDay, err := watch.ParseStart(req.Date, req.Time)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.By == "" {
+		req.By = "webhook"
+	}
+
+	added, err := s.engine.AddWatch(req.Account, req.Program, start, allDay, req.By)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"ok": true, "id": added.ID(), "watch": added})
+}
+
+// handleAPIUnwatch removes the watch of a session, by ID or by program/date/time
+func (s *Server) handleAPIUnwatch(w http.ResponseWriter, r *http.Request, body []byte) {
+	var req watchRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf(i18n.T("server.body_parse_failed"), err))
+		return
+	}
+	var removed bool
+	var err error
+	if req.ID != "" {
+		removed, err = s.engine.RemoveWatch(req.ID)
+	} else {
+		start, allDay, parseErr := watch.ParseStart(req.Date, req.Time)
+		if parseErr != nil {
+			writeError(w, http.StatusBadRequest, parseErr)
+			return
+		}
+		removed, err = s.engine.UnwatchSession(req.Account, req.Program, start, allDay)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"ok": true, "removed": removed})
+}
+
+// handleAPIWatches lists the watched sessions
+func (s *Server) handleAPIWatches(w http.ResponseWriter, r *http.Request, body []byte) {
+	writeJSON(w, http.StatusOK, map[string]any{"ok": true, "watches": s.status().Watches})
+}
+
+// handleAPIStatus serves the same status as /status to authenticated callers
+func (s *Server) handleAPIStatus(w http.ResponseWriter, r *http.Request, body []byte) {
+	writeJSON(w, http.StatusOK, s.status())
+}
+
+// muteUntil reads the expiry of a mute request: an absolute time or a duration from now
+func muteUntil(req muteRequest, now time.Time) (time.Time, error) {
+	switch {
+	case req.Until != "" && req.For != "":
+		return time.Time{}, errors.New(i18n.T("server.until_or_for"))
+	case req.For != "":
+		duration, err := mute.ParseDuration(req.For)
+		if err != nil || duration <= 0 {
+			return time.Time{}, fmt.Errorf(i18n.T("server.bad_for"), req.For)
+		}
+		return now.Add(duration), nil
+	case req.Until != "":
+		if until, err := time.Parse(time.RFC3339, req.Until); err == nil {
+			return until, nil
+		}
+		for _, layout := range []string{"2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"} {
+			if until, err := time.ParseInLocation(layout, req.Until, time.Local); err == nil {
+				return until, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf(i18n.T("server.bad_until"), req.Until)
+	default:
+		return time.Time{}, errors.New(i18n.T("server.mute_duration_required"))
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]any{"ok": false, "error": err.Error()})
+}
@@ -0,0 +1,208 @@
+package session
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PassphraseEnv is the environment variable used for the session file passphrase
+const PassphraseEnv = "BMW_SESSION_PASSPHRASE"
+
+const (
+	fileFormat    = "bmw-driving-center-session"
+	fileVersion   = 1
+	kdfName       = "pbkdf2-sha256"
+	kdfIterations = 600000
+	keyLength     = 32 // AES-256
+	saltLength    = 16
+	minPassphrase = 8
+	pendingFile   = "imported-session.json"
+)
+
+// ErrWrongPassphrase is returned when a session file cannot be decrypted
+var ErrWrongPassphrase = i18n.NewError("session.decrypt_failed")
+
+// Cookie is a portable browser cookie
+type Cookie struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Domain string `json:"domain"`
+	Path   string `json:"path"`
+	Secure bool   `json:"secure"`
+	Expiry int64  `json:"expiry,omitempty"` // Unix 초, 0이면 브라우저 세션 쿠키
+}
+
+// MatchesHost reports whether the cookie belongs to the given host
+func (c Cookie) MatchesHost(host string) bool {
+	domain := strings.TrimPrefix(c.Domain, ".")
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// Snapshot is the portable login state of one account
+type Snapshot struct {
+	Account      string                       `json:"account"`
+	ExportedAt   time.Time                    `json:"exported_at"`
+	Cookies      []Cookie                     `json:"cookies"`
+	LocalStorage map[string]map[string]string `json:"local_storage,omitempty"` // origin -> key -> value
+}
+
+// envelope is the encrypted on-disk format
+type envelope struct {
+	Format     string `json:"format"`
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Export encrypts the snapshot with the passphrase and writes it to path
+func Export(path string, snap *Snapshot, passphrase string) error {
+	if len(passphrase) < minPassphrase {
+		return fmt.Errorf(i18n.T("session.passphrase_length"), minPassphrase)
+	}
+
+	plaintext, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf(i18n.T("session.encode_failed"), err)
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf(i18n.T("session.random_failed"), err)
+	}
+
+	gcm, err := newCipher(passphrase, salt, kdfIterations)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf(i18n.T("session.random_failed"), err)
+	}
+
+	env := envelope{
+		Format:     fileFormat,
+		Version:    fileVersion,
+		KDF:        kdfName,
+		Iterations: kdfIterations,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, []byte(fileFormat)),
+	}
+
+	data, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return fmt.Errorf(i18n.T("session.file_encode_failed"), err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf(i18n.T("session.file_save_failed"), err)
+	}
+	return nil
+}
+
+// Import reads and decrypts a session file
+func Import(path, passphrase string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("session.file_read_failed"), err)
+	}
+
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf(i18n.T("session.file_parse_failed"), err)
+	}
+	if env.Format != fileFormat {
+		return nil, fmt.Errorf(i18n.T("session.not_session_file"), path)
+	}
+	if env.Version > fileVersion {
+		return nil, fmt.Errorf(i18n.T("session.unsupported_version"), env.Version)
+	}
+
+	// 반복 횟수가 낮게 조작된 파일로 키 유도를 건너뛰지 못하도록 알려진 설정만 허용
+	if env.KDF != kdfName || env.Iterations < kdfIterations {
+		return nil, fmt.Errorf(i18n.T("session.unsupported_kdf"), env.KDF, env.Iterations)
+	}
+
+	gcm, err := newCipher(passphrase, env.Salt, env.Iterations)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, env.Nonce, env.Ciphertext, []byte(fileFormat))
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(plaintext, &snap); err != nil {
+		return nil, fmt.Errorf(i18n.T("session.parse_failed"), err)
+	}
+	return &snap, nil
+}
+
+func newCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, keyLength)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("session.key_failed"), err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("session.cipher_failed"), err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("session.cipher_failed"), err)
+	}
+	return gcm, nil
+}
+
+// SavePending stores an imported snapshot in a browser state directory.
+// 브라우저가 로그인되어 있지 않을 때 다음 시작 시 이 세션을 적용합니다.
+func SavePending(stateDir string, snap *Snapshot) error {
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		return fmt.Errorf(i18n.T("session.dir_failed"), err)
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf(i18n.T("session.encode_failed"), err)
+	}
+
+	if err := os.WriteFile(filepath.Join(stateDir, pendingFile), data, 0600); err != nil {
+		return fmt.Errorf(i18n.T("session.imported_save_failed"), err)
+	}
+	return nil
+}
+
+// LoadPending returns the imported snapshot stored in a state directory, or nil if there is none
+func LoadPending(stateDir string) (*Snapshot, error) {
+	data, err := os.ReadFile(filepath.Join(stateDir, pendingFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("session.imported_read_failed"), err)
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf(i18n.T("session.imported_parse_failed"), err)
+	}
+	return &snap, nil
+}
@@ -0,0 +1,239 @@
+package telegram
+
+import (
+	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/history"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/logging"
+	"bmw-driving-center-alter/internal/models"
+	"bmw-driving-center-alter/internal/monitor"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	pollTimeout  = 30 * time.Second // getUpdates 롱 폴링 대기 시간
+	retryDelay   = 5 * time.Second  // 연결 실패 후 다시 시도할 때까지
+	sendTimeout  = 15 * time.Second
+	staleMessage = 5 * time.Minute // 모니터가 꺼져 있는 동안 쌓인 오래된 명령은 무시
+)
+
+var logger = logging.Component("telegram")
+
+// Bot sends monitor alerts to Telegram chats and answers commands from them.
+// 롱 폴링(getUpdates)을 사용하므로 외부에서 들어오는 포트가 필요 없습니다.
+type Bot struct {
+	client     *Client
+	engine     *monitor.Engine
+	history    *history.Store
+	configPath string
+	chats      []int64
+	allowed    map[int64]bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Start connects the bot and handles commands in the background.
+// telegram.token이 비어있으면 봇을 시작하지 않고 nil을 반환합니다.
+// /watch, /unwatch는 실행 중인 설정과 configPath의 설정 파일을 함께 바꿉니다.
+func Start(cfg config.TelegramConfig, engine *monitor.Engine, store *history.Store, configPath string) (*Bot, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+
+	client := NewClient(cfg.APIURL, cfg.Token)
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	me, err := client.GetMe(ctx)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("telegram.connect_failed"), err)
+	}
+
+	b := &Bot{
+		client:     client,
+		engine:     engine,
+		history:    store,
+		configPath: configPath,
+		chats:      cfg.ChatIDs,
+		allowed:    make(map[int64]bool),
+		done:       make(chan struct{}),
+	}
+	for _, id := range cfg.ChatIDs {
+		b.allowed[id] = true
+	}
+	b.ctx, b.cancel = context.WithCancel(context.Background())
+
+	engine.OnEvent(b.handleEvent)
+	go b.poll()
+	logger.Infof(i18n.T("telegram.started"), me.Username, len(b.chats))
+	return b, nil
+}
+
+// Close stops polling and waits for the command in progress to finish
+func (b *Bot) Close() error {
+	if b == nil {
+		return nil
+	}
+	b.cancel()
+	<-b.done
+	return nil
+}
+
+// poll receives updates until the bot is closed
+func (b *Bot) poll() {
+	defer close(b.done)
+
+	var offset int64
+	for {
+		updates, err := b.client.GetUpdates(b.ctx, offset, pollTimeout)
+		if b.ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			delay := retryDelay
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+				delay = apiErr.RetryAfter
+			}
+			logger.Warnf(i18n.T("telegram.retry"), err, delay)
+			select {
+			case <-b.ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			continue
+		}
+
+		for _, update := range updates {
+			offset = update.UpdateID + 1
+			if update.Message != nil {
+				b.handleMessage(*update.Message)
+			}
+		}
+	}
+}
+
+// handleMessage runs a command from an allowed chat and replies to it
+func (b *Bot) handleMessage(msg Message) {
+	if !b.allowed[msg.Chat.ID] {
+		// 채팅 ID를 로그로 확인해 telegram.chat_ids에 추가할 수 있도록
+		logger.Warnf(i18n.T("telegram.chat_not_allowed"), msg.Chat.ID, sender(msg))
+		return
+	}
+	command, args := parseCommand(msg.Text)
+	if command == "" {
+		return
+	}
+	if sent := time.Unix(msg.Date, 0); msg.Date > 0 && time.Since(sent) > staleMessage {
+		logger.Infof(i18n.T("telegram.stale_command"), msg.Text, sent.Format("01-02 15:04"))
+		return
+	}
+
+	logger.Infof(i18n.T("telegram.command"), msg.Text, sender(msg))
+	b.send(msg.Chat.ID, b.run(command, args, sender(msg)))
+}
+
+// handleEvent forwards openings, CAPTCHAs, session problems, claims, last-seat, watched-session and cancellation alerts to the allowed chats
+func (b *Bot) handleEvent(event monitor.Event) {
+	if b.ctx.Err() != nil {
+		return
+	}
+
+	var text string
+	switch event.Type {
+	case monitor.EventOpened:
+		text = openingMessage(event)
+	case monitor.EventCaptcha, monitor.EventSession, monitor.EventClaim, monitor.EventLastSeats, monitor.EventWatch,
+		monitor.EventCancellation:
+		text = event.Message
+		if event.Account != "" {
+			text = fmt.Sprintf("[%s] %s", event.Account, text)
+		}
+	default:
+		return
+	}
+
+	// 엔진의 확인을 늦추지 않도록 백그라운드에서 전송
+	go func() {
+		for _, chat := range b.chats {
+			b.send(chat, text)
+		}
+	}()
+}
+
+// send delivers a message, logging failures
+func (b *Bot) send(chat int64, text string) {
+	ctx, cancel := context.WithTimeout(b.ctx, sendTimeout)
+	defer cancel()
+	if err := b.client.SendMessage(ctx, chat, text); err != nil && b.ctx.Err() == nil {
+		logger.Warnf(i18n.T("telegram.send_failed"), chat, err)
+	}
+}
+
+// openingMessage describes newly opened programs and their open sessions
+func openingMessage(event monitor.Event) string {
+	var sb strings.Builder
+	sb.WriteString(i18n.T("telegram.found"))
+	if event.Account != "" {
+		fmt.Fprintf(&sb, " [%s]", event.Account)
+	}
+	sb.WriteString("\n")
+
+	for _, name := range event.Result.NewlyOpened {
+		fmt.Fprintf(&sb, "\n🚗 %s\n", displayName(name))
+		link := event.Result.Links[name]
+		for _, session := range event.Result.Sessions {
+			if session.Program == name && session.Open {
+				fmt.Fprintf(&sb, "   • %s\n", sessionText(session))
+				if session.URL != "" && session.URL != link && session.URL != models.ReservationPageURL {
+					fmt.Fprintf(&sb, "     %s\n", session.URL)
+				}
+			}
+		}
+		if link == "" {
+			link = models.ReservationPageURL
+		}
+		fmt.Fprintf(&sb, "   👉 %s\n", link)
+	}
+	sb.WriteString(i18n.T("telegram.status_hint"))
+	return sb.String()
+}
+
+// sessionText formats a session's date, time, price and seats
+func sessionText(session models.Session) string {
+	text := i18n.FormatTime(session.Start, "01-02 (Mon) 15:04")
+	if session.AllDay {
+		text = i18n.FormatTime(session.Start, "01-02 (Mon)")
+	}
+	if session.Price > 0 {
+		text += fmt.Sprintf(i18n.T("telegram.price"), session.Price)
+	}
+	if seats := session.SeatStatus(); seats != "" {
+		text += " · " + seats
+	}
+	return text
+}
+
+// displayName returns "English (한국어)" for known programs in Korean
+func displayName(program string) string {
+	return i18n.ProgramLabel(program)
+}
+
+// sender describes who sent a message, for logs and mute records
+func sender(msg Message) string {
+	if msg.From == nil {
+		return fmt.Sprintf("chat %d", msg.Chat.ID)
+	}
+	if msg.From.Username != "" {
+		return "@" + msg.From.Username
+	}
+	if msg.From.FirstName != "" {
+		return msg.From.FirstName
+	}
+	return fmt.Sprintf("user %d", msg.From.ID)
+}
@@ -0,0 +1,169 @@
+package telegram
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultAPIURL is the address of the Telegram Bot API
+const DefaultAPIURL = "https://api.telegram.org"
+
+// requestTimeout bounds one API call. 롱 폴링 대기 시간보다 길어야 합니다.
+const requestTimeout = pollTimeout + 30*time.Second
+
+// maxMessageLength is the longest text Telegram accepts in one message
+const maxMessageLength = 4096
+
+// Update is an incoming update from getUpdates (only messages are used)
+type Update struct {
+	UpdateID int64    `json:"update_id"`
+	Message  *Message `json:"message,omitempty"`
+}
+
+// Message is a chat message
+type Message struct {
+	MessageID int64  `json:"message_id"`
+	From      *User  `json:"from,omitempty"`
+	Chat      Chat   `json:"chat"`
+	Date      int64  `json:"date"`
+	Text      string `json:"text,omitempty"`
+}
+
+// User is a Telegram user or bot
+type User struct {
+	ID        int64  `json:"id"`
+	IsBot     bool   `json:"is_bot,omitempty"`
+	FirstName string `json:"first_name,omitempty"`
+	Username  string `json:"username,omitempty"`
+}
+
+// Chat is a private chat, group or channel
+type Chat struct {
+	ID    int64  `json:"id"`
+	Type  string `json:"type,omitempty"`
+	Title string `json:"title,omitempty"`
+}
+
+// APIError is an error returned by the Bot API
+type APIError struct {
+	Method      string
+	Code        int
+	Description string
+	RetryAfter  time.Duration // 429 응답에서 다시 시도할 때까지 기다릴 시간
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf(i18n.T("telegram.api_failed"), e.Method, e.Code, e.Description)
+}
+
+type apiResponse struct {
+	OK          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	ErrorCode   int             `json:"error_code"`
+	Description string          `json:"description"`
+	Parameters  *struct {
+		RetryAfter int `json:"retry_after"`
+	} `json:"parameters,omitempty"`
+}
+
+// Client calls the Telegram Bot API. 주소를 바꾸면 가짜 서버로도 시험할 수 있습니다.
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// NewClient creates a Bot API client (empty apiURL = DefaultAPIURL)
+func NewClient(apiURL, token string) *Client {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	return &Client{
+		baseURL: strings.TrimRight(apiURL, "/"),
+		token:   token,
+		http:    &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// GetMe returns the bot's own user, which also checks the token
+func (c *Client) GetMe(ctx context.Context) (User, error) {
+	var me User
+	err := c.call(ctx, "getMe", struct{}{}, &me)
+	return me, err
+}
+
+// GetUpdates waits up to timeout for updates after offset (long polling)
+func (c *Client) GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]Update, error) {
+	params := map[string]any{
+		"offset":          offset,
+		"timeout":         int(timeout.Seconds()),
+		"allowed_updates": []string{"message"},
+	}
+	var updates []Update
+	err := c.call(ctx, "getUpdates", params, &updates)
+	return updates, err
+}
+
+// SendMessage sends plain text to a chat, cutting it to Telegram's length limit
+func (c *Client) SendMessage(ctx context.Context, chatID int64, text string) error {
+	if runes := []rune(text); len(runes) > maxMessageLength {
+		text = string(runes[:maxMessageLength-1]) + "…"
+	}
+	params := map[string]any{
+		"chat_id":                  chatID,
+		"text":                     text,
+		"disable_web_page_preview": true,
+	}
+	return c.call(ctx, "sendMessage", params, nil)
+}
+
+// call posts the parameters as JSON to a Bot API method and decodes the result
+func (c *Client) call(ctx context.Context, method string, params, result any) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf(i18n.T("telegram.request_failed"), method, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/bot"+c.token+"/"+method, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf(i18n.T("telegram.request_failed"), method, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		// 주소에 토큰이 들어있으므로 로그에 남지 않도록 URL은 빼고 반환
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf(i18n.T("telegram.connection_failed"), method, err)
+	}
+	defer resp.Body.Close()
+
+	var decoded apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return fmt.Errorf(i18n.T("telegram.response_parse_failed"), method, resp.StatusCode, err)
+	}
+	if !decoded.OK {
+		apiErr := &APIError{Method: method, Code: decoded.ErrorCode, Description: decoded.Description}
+		if decoded.Parameters != nil {
+			apiErr.RetryAfter = time.Duration(decoded.Parameters.RetryAfter) * time.Second
+		}
+		return apiErr
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(decoded.Result, result); err != nil {
+		return fmt.Errorf(i18n.T("telegram.result_parse_failed"), method, err)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package telegram
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testToken = "123:secret"
+
+// fakeAPI is a local Bot API server that records the calls it receives
+type fakeAPI struct {
+	mu    sync.Mutex
+	calls []fakeCall
+
+	updates []Update
+	fail    map[string]string // method → 그대로 돌려줄 오류 응답
+}
+
+type fakeCall struct {
+	Method string
+	Params map[string]any
+}
+
+func newFakeAPI(t *testing.T) (*fakeAPI, *Client) {
+	t.Helper()
+	api := &fakeAPI{fail: make(map[string]string)}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	return api, NewClient(server.URL+"/", testToken)
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method, ok := strings.CutPrefix(r.URL.Path, "/bot"+testToken+"/")
+	if !ok || r.Method != http.MethodPost {
+		http.Error(w, `{"ok":false,"error_code":404,"description":"Not Found"}`, http.StatusNotFound)
+		return
+	}
+	var params map[string]any
+	json.NewDecoder(r.Body).Decode(&params)
+
+	f.mu.Lock()
+	f.calls = append(f.calls, fakeCall{Method: method, Params: params})
+	body, failing := f.fail[method]
+	updates := f.updates
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if failing {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(body))
+		return
+	}
+
+	var result any = true
+	switch method {
+	case "getMe":
+		result = User{ID: 1, IsBot: true, FirstName: "Monitor", Username: "bmw_monitor_bot"}
+	case "getUpdates":
+		result = updates
+	case "sendMessage":
+		result = Message{MessageID: 10, Chat: Chat{ID: int64(params["chat_id"].(float64))}, Text: params["text"].(string)}
+	}
+	json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": result})
+}
+
+// sent returns the sendMessage calls received so far
+func (f *fakeAPI) sent() []fakeCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var sent []fakeCall
+	for _, call := range f.calls {
+		if call.Method == "sendMessage" {
+			sent = append(sent, call)
+		}
+	}
+	return sent
+}
+
+func TestClientMethods(t *testing.T) {
+	api, client := newFakeAPI(t)
+	ctx := context.Background()
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		t.Fatalf("GetMe: %v", err)
+	}
+	if me.Username != "bmw_monitor_bot" || !me.IsBot {
+		t.Errorf("GetMe = %+v", me)
+	}
+
+	api.updates = []Update{
+		{UpdateID: 7, Message: &Message{MessageID: 1, Chat: Chat{ID: 42, Type: "private"}, Text: "/status"}},
+		{UpdateID: 8},
+	}
+	updates, err := client.GetUpdates(ctx, 7, pollTimeout)
+	if err != nil {
+		t.Fatalf("GetUpdates: %v", err)
+	}
+	if len(updates) != 2 || updates[0].Message == nil || updates[0].Message.Text != "/status" || updates[1].Message != nil {
+		t.Errorf("GetUpdates = %+v", updates)
+	}
+	call := api.calls[len(api.calls)-1]
+	if call.Params["offset"] != float64(7) || call.Params["timeout"] != float64(30) {
+		t.Errorf("getUpdates params = %v", call.Params)
+	}
+
+	long := strings.Repeat("가", maxMessageLength+10)
+	if err := client.SendMessage(ctx, 42, "hello"); err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	if err := client.SendMessage(ctx, 42, long); err != nil {
+		t.Fatalf("SendMessage (long): %v", err)
+	}
+	sent := api.sent()
+	if len(sent) != 2 {
+		t.Fatalf("sendMessage calls = %d, want 2", len(sent))
+	}
+	if sent[0].Params["chat_id"] != float64(42) || sent[0].Params["text"] != "hello" {
+		t.Errorf("sendMessage params = %v", sent[0].Params)
+	}
+	if text := sent[1].Params["text"].(string); len([]rune(text)) != maxMessageLength || !strings.HasSuffix(text, "…") {
+		t.Errorf("long message not cut to %d runes: %d", maxMessageLength, len([]rune(text)))
+	}
+}
+
+func TestClientAPIError(t *testing.T) {
+	api, client := newFakeAPI(t)
+	api.fail["sendMessage"] = `{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 7","parameters":{"retry_after":7}}`
+	api.fail["getMe"] = `{"ok":false,"error_code":401,"description":"Unauthorized"}`
+
+	err := client.SendMessage(context.Background(), 42, "hello")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("SendMessage error = %v, want *APIError", err)
+	}
+	if apiErr.Method != "sendMessage" || apiErr.Code != 429 || apiErr.RetryAfter != 7*time.Second {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+
+	_, err = client.GetMe(context.Background())
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("GetMe error = %v, want *APIError", err)
+	}
+	if apiErr.Code != 401 || apiErr.RetryAfter != 0 || apiErr.Description != "Unauthorized" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+	if strings.Contains(err.Error(), testToken) {
+		t.Errorf("error leaks the token: %v", err)
+	}
+}
+
+func TestHandleMessageAllowList(t *testing.T) {
+	i18n.SetLanguage(i18n.Korean)
+	api, client := newFakeAPI(t)
+	b := &Bot{client: client, chats: []int64{42}, allowed: map[int64]bool{42: true}}
+	b.ctx, b.cancel = context.WithCancel(context.Background())
+	defer b.cancel()
+
+	now := time.Now().Unix()
+	b.handleMessage(Message{Chat: Chat{ID: 99}, From: &User{ID: 5, Username: "stranger"}, Date: now, Text: "/help"})
+	if sent := api.sent(); len(sent) != 0 {
+		t.Fatalf("replied to a chat that is not allowed: %v", sent)
+	}
+
+	b.handleMessage(Message{Chat: Chat{ID: 42}, Date: now, Text: "hello"})
+	b.handleMessage(Message{Chat: Chat{ID: 42}, Date: now - int64(staleMessage.Seconds()) - 60, Text: "/help"})
+	if sent := api.sent(); len(sent) != 0 {
+		t.Fatalf("replied to plain text or a stale command: %v", sent)
+	}
+
+	b.handleMessage(Message{Chat: Chat{ID: 42}, From: &User{ID: 6, Username: "member"}, Date: now, Text: "/help@bmw_monitor_bot"})
+	sent := api.sent()
+	if len(sent) != 1 {
+		t.Fatalf("sendMessage calls = %d, want 1", len(sent))
+	}
+	if sent[0].Params["chat_id"] != float64(42) || sent[0].Params["text"] != i18n.T("telegram.help") {
+		t.Errorf("reply = %v", sent[0].Params)
+	}
+}
@@ -0,0 +1,387 @@
+package telegram
+
+import (
+	"bmw-driving-center-alter/internal/config"
+	"bmw-driving-center-alter/internal/history"
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/models"
+	"bmw-driving-center-alter/internal/mute"
+	"bmw-driving-center-alter/internal/scraper"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// historyDays is how far back /history looks
+const historyDays = 30
+
+// historyChanges is the number of availability changes /history shows
+const historyChanges = 10
+
+// parseCommand splits "/watch@MyBot M Core" into "watch" and "M Core"
+func parseCommand(text string) (string, string) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "/") {
+		return "", ""
+	}
+	command, args, _ := strings.Cut(text[1:], " ")
+	command, _, _ = strings.Cut(command, "@") // 그룹 채팅에서는 /cmd@봇이름
+	return strings.ToLower(command), strings.TrimSpace(args)
+}
+
+// run executes a command and returns the reply
+func (b *Bot) run(command, args, by string) string {
+	var reply string
+	var err error
+	switch command {
+	case "start", "help":
+		return i18n.T("telegram.help")
+	case "status":
+		return b.status()
+	case "watch":
+		reply, err = b.watch(args)
+	case "unwatch":
+		reply, err = b.unwatch(args)
+	case "mute":
+		reply, err = b.mute(args, by)
+	case "unmute":
+		reply, err = b.unmute(args)
+	case "check":
+		b.engine.TriggerCheck()
+		return i18n.T("telegram.check_now")
+	case "history":
+		reply, err = b.programHistory(args)
+	default:
+		return fmt.Sprintf(i18n.T("telegram.unknown_command"), command)
+	}
+	if err != nil {
+		return "❌ " + err.Error()
+	}
+	return reply
+}
+
+// status lists the watched programs with their availability and mutes
+func (b *Bot) status() string {
+	states := b.engine.ProgramStates()
+	accounts := make(map[string]bool)
+	for _, state := range states {
+		accounts[state.Account] = true
+	}
+	mutedUntil := make(map[string]time.Time)
+	for _, m := range b.engine.Mutes() {
+		mutedUntil[m.Account+"\x00"+m.Program] = m.Until
+	}
+
+	var sb strings.Builder
+	sb.WriteString(i18n.T("telegram.status_title"))
+	if b.engine.Paused() {
+		sb.WriteString(i18n.T("telegram.paused"))
+	} else if next := b.engine.NextCheck(); !next.IsZero() {
+		fmt.Fprintf(&sb, i18n.T("telegram.next_check"), next.Format("15:04:05"))
+	}
+	sb.WriteString("\n")
+
+	for _, state := range states {
+		icon := "⛔"
+		switch {
+		case state.Disabled:
+			icon = "⏸️"
+		case !state.Known:
+			icon = "❔"
+		case state.Available:
+			icon = "✅"
+		}
+		line := icon + " "
+		if len(accounts) > 1 {
+			line += fmt.Sprintf("[%s] ", state.Account)
+		}
+		line += displayName(state.Program)
+		if open := countOpen(state.Sessions); open > 0 {
+			line += fmt.Sprintf(i18n.T("telegram.sessions"), open)
+		}
+		if until, ok := mutedUntil[state.Account+"\x00"+state.Program]; ok {
+			line += fmt.Sprintf(i18n.T("telegram.muted_until"), until.Format("01-02 15:04"))
+		}
+		if !state.LastChecked.IsZero() {
+			line += fmt.Sprintf(" (%s)", state.LastChecked.Format("15:04"))
+		}
+		sb.WriteString(line + "\n")
+	}
+	if len(states) == 0 {
+		sb.WriteString(i18n.T("telegram.no_programs"))
+	}
+
+	if claimList := b.engine.Claims(); len(claimList) > 0 {
+		sb.WriteString(i18n.T("telegram.claims_title"))
+		for _, claim := range claimList {
+			fmt.Fprintf(&sb, "• %s %s - %s\n", claim.Program, claim.Start.Format("01-02 15:04"), claim.By)
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// watch adds a program to every account and enables it if it was paused
+func (b *Bot) watch(args string) (string, error) {
+	program, err := catalogProgram(args)
+	if err != nil {
+		return "", err
+	}
+
+	watched := false
+	for _, state := range b.engine.ProgramStates() {
+		if state.Program == program {
+			watched = true
+			if state.Disabled {
+				b.engine.SetProgramEnabled(state.Account, program, true)
+			}
+		}
+	}
+	if watched {
+		return fmt.Sprintf(i18n.T("telegram.already_watching"), displayName(program)), nil
+	}
+
+	add := func(cfg *config.Config) error {
+		keywords := []string{program}
+		if korean, ok := models.ProgramNameMap[program]; ok {
+			keywords = append(keywords, korean)
+		}
+		entry := models.Program{Name: program, Keywords: keywords}
+		if len(cfg.Accounts) == 0 {
+			if !hasProgram(cfg.Programs, program) {
+				cfg.Programs = append(cfg.Programs, entry)
+			}
+			return nil
+		}
+		for i := range cfg.Accounts {
+			if !hasProgram(cfg.Accounts[i].Programs, program) {
+				cfg.Accounts[i].Programs = append(cfg.Accounts[i].Programs, entry)
+			}
+		}
+		return nil
+	}
+	if err := b.engine.UpdateConfig(add); err != nil {
+		return "", fmt.Errorf(i18n.T("telegram.config_locked"), err)
+	}
+	reply := fmt.Sprintf(i18n.T("telegram.watching"), displayName(program))
+	return reply + b.save(add), nil
+}
+
+// unwatch removes a program from every account
+func (b *Bot) unwatch(args string) (string, error) {
+	program, err := b.watchedProgram(args)
+	if err != nil {
+		return "", err
+	}
+
+	remove := func(cfg *config.Config) error {
+		cfg.Programs = withoutProgram(cfg.Programs, program)
+		for i := range cfg.Accounts {
+			cfg.Accounts[i].Programs = withoutProgram(cfg.Accounts[i].Programs, program)
+		}
+		return nil
+	}
+	if err := b.engine.UpdateConfig(remove); err != nil {
+		return "", fmt.Errorf(i18n.T("telegram.config_locked"), err)
+	}
+	reply := fmt.Sprintf(i18n.T("telegram.unwatched"), displayName(program))
+	return reply + b.save(remove), nil
+}
+
+// save applies the same change to the config file
+func (b *Bot) save(update func(cfg *config.Config) error) string {
+	if err := config.Update(b.configPath, update); err != nil {
+		logger.Warnf(i18n.T("telegram.config_save_failed"), err)
+		return i18n.T("telegram.not_saved")
+	}
+	return ""
+}
+
+// mute silences one program, or every watched program, for a period
+func (b *Bot) mute(args, by string) (string, error) {
+	period, program, _ := strings.Cut(args, " ")
+	duration, err := mute.ParseDuration(period)
+	if err != nil || duration <= 0 {
+		return "", errors.New(i18n.T("telegram.duration_required"))
+	}
+	until := time.Now().Add(duration)
+
+	programs, err := b.targetPrograms(program)
+	if err != nil {
+		return "", err
+	}
+	var names []string
+	for _, name := range programs {
+		if _, err := b.engine.Mute("", name, until, by); err != nil {
+			return "", err
+		}
+		names = append(names, name)
+	}
+	return fmt.Sprintf(i18n.T("telegram.muted"), until.Format("01-02 15:04"), strings.Join(names, ", ")), nil
+}
+
+// unmute turns notifications back on for one program or all of them
+func (b *Bot) unmute(args string) (string, error) {
+	programs, err := b.targetPrograms(args)
+	if err != nil {
+		return "", err
+	}
+	removed := 0
+	for _, name := range programs {
+		n, err := b.engine.Unmute("", name)
+		if err != nil {
+			return "", err
+		}
+		removed += n
+	}
+	if removed == 0 {
+		return i18n.T("telegram.nothing_muted"), nil
+	}
+	return fmt.Sprintf(i18n.T("telegram.unmuted"), removed), nil
+}
+
+// programHistory lists the recent availability changes of a program
+func (b *Bot) programHistory(args string) (string, error) {
+	if b.history == nil {
+		return "", errors.New(i18n.T("telegram.history_not_set"))
+	}
+	program, err := b.watchedProgram(args)
+	if err != nil {
+		if program, err = catalogProgram(args); err != nil {
+			return "", err
+		}
+	}
+
+	entries, err := b.history.Read(history.Filter{Program: program, Since: time.Now().AddDate(0, 0, -historyDays)})
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 0 {
+		return fmt.Sprintf(i18n.T("telegram.history_empty"), displayName(program), historyDays), nil
+	}
+
+	type change struct {
+		time      time.Time
+		account   string
+		available bool
+	}
+	var changes []change
+	last := make(map[string]bool)
+	accounts := make(map[string]bool)
+	for _, entry := range entries {
+		accounts[entry.Account] = true
+		for _, result := range entry.Programs {
+			if result.Name != program {
+				continue
+			}
+			if previous, seen := last[entry.Account]; !seen || previous != result.Available {
+				changes = append(changes, change{entry.Time, entry.Account, result.Available})
+			}
+			last[entry.Account] = result.Available
+		}
+	}
+	if len(changes) > historyChanges {
+		changes = changes[len(changes)-historyChanges:]
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, i18n.T("telegram.history_title"), displayName(program), historyDays, len(entries))
+	for _, c := range changes {
+		state := i18n.T("telegram.closed")
+		if c.available {
+			state = i18n.T("telegram.available")
+		}
+		line := fmt.Sprintf("%s %s", c.time.Format("01-02 15:04"), state)
+		if len(accounts) > 1 {
+			line += fmt.Sprintf(" [%s]", c.account)
+		}
+		sb.WriteString(line + "\n")
+	}
+	fmt.Fprintf(&sb, i18n.T("telegram.last_check"), entries[len(entries)-1].Time.Format("01-02 15:04"))
+	return sb.String(), nil
+}
+
+// targetPrograms returns the named watched program, or every watched program when name is empty
+func (b *Bot) targetPrograms(name string) ([]string, error) {
+	if strings.TrimSpace(name) != "" {
+		program, err := b.watchedProgram(name)
+		if err != nil {
+			return nil, err
+		}
+		return []string{program}, nil
+	}
+
+	var programs []string
+	seen := make(map[string]bool)
+	for _, state := range b.engine.ProgramStates() {
+		if !seen[state.Program] {
+			seen[state.Program] = true
+			programs = append(programs, state.Program)
+		}
+	}
+	if len(programs) == 0 {
+		return nil, errors.New(i18n.T("telegram.no_watched"))
+	}
+	return programs, nil
+}
+
+// watchedProgram finds a watched program by its English or Korean name
+func (b *Bot) watchedProgram(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New(i18n.T("telegram.program_required"))
+	}
+	for _, state := range b.engine.ProgramStates() {
+		if matchesProgram(state.Program, name) {
+			return state.Program, nil
+		}
+	}
+	return "", fmt.Errorf(i18n.T("telegram.not_watched"), name)
+}
+
+// catalogProgram finds a program of the driving center by its English or Korean name
+func catalogProgram(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New(i18n.T("telegram.program_required_example"))
+	}
+	for _, program := range models.GetAllProgramNames() {
+		if matchesProgram(program, name) {
+			return program, nil
+		}
+	}
+	return "", fmt.Errorf(i18n.T("telegram.unknown_program"), name)
+}
+
+func matchesProgram(program, name string) bool {
+	return scraper.NamesProgram(name, program)
+}
+
+func hasProgram(programs []models.Program, name string) bool {
+	for _, program := range programs {
+		if program.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutProgram(programs []models.Program, name string) []models.Program {
+	var kept []models.Program
+	for _, program := range programs {
+		if program.Name != name {
+			kept = append(kept, program)
+		}
+	}
+	return kept
+}
+
+func countOpen(sessions []models.Session) int {
+	open := 0
+	for _, session := range sessions {
+		if session.Open {
+			open++
+		}
+	}
+	return open
+}
@@ -0,0 +1,215 @@
+package watch
+
+import (
+	"bmw-driving-center-alter/internal/i18n"
+	"bmw-driving-center-alter/internal/models"
+	"bmw-driving-center-alter/internal/scraper"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Watch waits for one session (program + date + time) that is currently full to get a free seat
+type Watch struct {
+	Account string    `json:"account,omitempty"` // 비어있으면 그 프로그램을 감시하는 모든 계정
+	Program string    `json:"program"`
+	Start   time.Time `json:"start"`
+	AllDay  bool      `json:"all_day,omitempty"` // 시간 없이 날짜만 지정 (그날의 모든 회차)
+	By      string    `json:"by,omitempty"`      // 감시를 추가한 사람/시스템
+	Created time.Time `json:"created"`
+}
+
+// ID identifies the watch: the session ID used in alerts and claims, followed by the account
+// for a watch of one account, so that several accounts can watch the same session
+func (w Watch) ID() string {
+	if w.Account != "" {
+		return w.session().ID() + "-" + w.Account
+	}
+	return w.session().ID()
+}
+
+func (w Watch) session() models.Session {
+	return models.Session{Program: w.Program, Start: w.Start, AllDay: w.AllDay}
+}
+
+// Matches reports whether a parsed session is the watched one
+func (w Watch) Matches(session models.Session) bool {
+	if session.Program != w.Program {
+		return false
+	}
+	if w.AllDay {
+		y1, m1, d1 := session.Start.In(w.Start.Location()).Date()
+		y2, m2, d2 := w.Start.Date()
+		return y1 == y2 && m1 == m2 && d1 == d2
+	}
+	return session.Start.Equal(w.Start)
+}
+
+// Expired reports whether the watched session has already started (날짜만 지정하면 그날이 지나면)
+func (w Watch) Expired(now time.Time) bool {
+	if w.AllDay {
+		return !now.Before(w.Start.AddDate(0, 0, 1))
+	}
+	return !now.Before(w.Start)
+}
+
+// String formats the watch like "M Core 11/07 (Sat) 10:00"
+func (w Watch) String() string {
+	if w.AllDay {
+		return fmt.Sprintf("%s %s", w.Program, i18n.FormatTime(w.Start, "01/02 (Mon)"))
+	}
+	return fmt.Sprintf("%s %s", w.Program, i18n.FormatTime(w.Start, "01/02 (Mon) 15:04"))
+}
+
+// ParseStart reads a session date (2006-01-02) and optional time (15:04) in the driving center's time zone
+func ParseStart(date, clock string) (time.Time, bool, error) {
+	date, clock = strings.TrimSpace(date), strings.TrimSpace(clock)
+	if clock == "" {
+		start, err := time.ParseInLocation("2006-01-02", date, scraper.KST)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf(i18n.T("watch.bad_date"), date)
+		}
+		return start, true, nil
+	}
+	start, err := time.ParseInLocation("2006-01-02 15:04", date+" "+clock, scraper.KST)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf(i18n.T("watch.bad_datetime"), date, clock)
+	}
+	return start, false, nil
+}
+
+// Store keeps the session watches in a JSON file so they survive restarts
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// DefaultPath returns the default watches file path
+func DefaultPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".bmw-driving-center", "watches.json")
+}
+
+// NewStore creates a watches store (empty path = default path)
+func NewStore(path string) *Store {
+	if path == "" {
+		path = DefaultPath()
+	}
+	return &Store{path: path}
+}
+
+// Add adds or replaces the watch of a session (계정마다 따로)
+func (s *Store) Add(w Watch) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	watches, _, err := s.load()
+	if err != nil {
+		return err
+	}
+	if w.Created.IsZero() {
+		w.Created = time.Now()
+	}
+	watches[w.ID()] = w
+	return s.save(watches)
+}
+
+// Remove removes a watch by ID. 없으면 false를 반환합니다.
+func (s *Store) Remove(id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	watches, _, err := s.load()
+	if err != nil {
+		return false, err
+	}
+	if _, ok := watches[id]; !ok {
+		return false, nil
+	}
+	delete(watches, id)
+	return true, s.save(watches)
+}
+
+// List returns the watches of sessions that have not started yet, earliest first.
+// 지난 회차의 감시는 이때 파일에서도 지웁니다.
+func (s *Store) List() ([]Watch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	watches, expired, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	if expired {
+		if err := s.save(watches); err != nil {
+			return nil, err
+		}
+	}
+	return sorted(watches), nil
+}
+
+// load reads the watches file, dropping watches of sessions that already started
+func (s *Store) load() (map[string]Watch, bool, error) {
+	watches := make(map[string]Watch)
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return watches, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf(i18n.T("watch.read_failed"), err)
+	}
+
+	var list []Watch
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, false, fmt.Errorf(i18n.T("watch.parse_failed"), err)
+	}
+	now := time.Now()
+	expired := false
+	for _, w := range list {
+		if w.Expired(now) {
+			expired = true
+			continue
+		}
+		watches[w.ID()] = w
+	}
+	return watches, expired, nil
+}
+
+// save writes the watches through a temporary file so readers never see a partial file
+func (s *Store) save(watches map[string]Watch) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf(i18n.T("watch.dir_failed"), err)
+	}
+
+	data, err := json.MarshalIndent(sorted(watches), "", "  ")
+	if err != nil {
+		return fmt.Errorf(i18n.T("watch.encode_failed"), err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf(i18n.T("watch.save_failed"), err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf(i18n.T("watch.save_failed"), err)
+	}
+	return nil
+}
+
+func sorted(watches map[string]Watch) []Watch {
+	list := make([]Watch, 0, len(watches))
+	for _, w := range watches {
+		list = append(list, w)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].Start.Equal(list[j].Start) {
+			return list[i].Start.Before(list[j].Start)
+		}
+		return list[i].ID() < list[j].ID()
+	})
+	return list
+}